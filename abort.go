@@ -0,0 +1,130 @@
+package ginbinding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientAbortError reports a request that could not be bound because its
+// client went away: it disconnected while sending the body, or stopped sending
+// it until the server's read timeout expired. No response is written for it,
+// since nobody is left to read it; the status is only set for access logs, and
+// the OnClientAbort hooks run instead of the OnError hooks, so that such
+// requests can be counted apart from the requests that failed to bind.
+type ClientAbortError struct {
+	// Timeout is set when reading the body timed out, e.g. because of the
+	// ReadTimeout of the http.Server, rather than the client disconnected
+	Timeout bool
+	// Err is the error reading the body, or the binding error of a request
+	// whose context was canceled
+	Err error
+}
+
+// Error implements the error interface
+func (e *ClientAbortError) Error() string {
+	if e.Timeout {
+		return fmt.Sprintf("timed out reading the request body: %v", e.Err)
+	}
+	return fmt.Sprintf("client closed the request: %v", e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ClientAbortError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode implements StatusCoder: 408 for timeouts and
+// StatusClientClosedRequest otherwise
+func (e *ClientAbortError) StatusCode() int {
+	if e.Timeout {
+		return http.StatusRequestTimeout
+	}
+	return StatusClientClosedRequest
+}
+
+// ErrorCode implements ErrorCoder: CodeTimeout for timeouts and CodeCanceled
+// otherwise
+func (e *ClientAbortError) ErrorCode() string {
+	if e.Timeout {
+		return CodeTimeout
+	}
+	return CodeCanceled
+}
+
+// ClientAbortHook observes a request the client aborted while it was bound
+type ClientAbortHook func(ctx *gin.Context, err *ClientAbortError)
+
+// OnClientAbort registers a hook that runs for every request that handlers
+// built afterwards could not bind because the client went away, e.g. to count
+// client aborts in metrics. Hooks run in registration order.
+func (builder *BasicFormBindingGinHandlerBuilder) OnClientAbort(hook ClientAbortHook) *BasicFormBindingGinHandlerBuilder {
+	builder.abortHooks = append(builder.abortHooks, hook)
+	return builder
+}
+
+// trackedBody records the first error reading a request body other than
+// io.EOF, which tells client aborts apart from malformed bodies: both make
+// decoders fail with io.ErrUnexpectedEOF.
+type trackedBody struct {
+	io.ReadCloser
+	err error
+}
+
+// trackBody replaces the body of ctx with a trackedBody, or returns nil for
+// requests without a body
+func trackBody(ctx *gin.Context) *trackedBody {
+	if ctx.Request.Body == nil || ctx.Request.Body == http.NoBody {
+		return nil
+	}
+	body := &trackedBody{ReadCloser: ctx.Request.Body}
+	ctx.Request.Body = body
+	return body
+}
+
+// Read implements io.Reader
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF && b.err == nil {
+		b.err = err
+	}
+	return n, err
+}
+
+// abortError returns a ClientAbortError for the binding error err of ctx if
+// the body b could not be read or the request was canceled, and err otherwise.
+// b may be nil.
+func (b *trackedBody) abortError(ctx *gin.Context, err error) error {
+	var abortErr *ClientAbortError
+	if errors.As(err, &abortErr) {
+		return err
+	}
+
+	if b != nil && b.err != nil {
+		// Bodies too large are rejected with 413
+		var maxBytesErr *http.MaxBytesError
+		if !errors.As(b.err, &maxBytesErr) {
+			var netErr net.Error
+			return &ClientAbortError{Timeout: errors.As(b.err, &netErr) && netErr.Timeout(), Err: b.err}
+		}
+	}
+
+	if errors.Is(ctx.Request.Context().Err(), context.Canceled) {
+		return &ClientAbortError{Err: err}
+	}
+	return err
+}
+
+// handleClientAbort aborts the request err reports without writing a response
+// and runs the client abort hooks
+func (builder *BasicFormBindingGinHandlerBuilder) handleClientAbort(ctx *gin.Context, err *ClientAbortError) {
+	ctx.AbortWithStatus(err.StatusCode())
+	for _, hook := range builder.abortHooks {
+		hook(ctx, err)
+	}
+}
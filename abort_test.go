@@ -0,0 +1,117 @@
+package ginbinding
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingReader returns its data, then err
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, f.err
+	}
+	return n, err
+}
+
+func TestClientAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		Name string `json:"name"`
+		Page int    `form:"page"`
+	}
+
+	serve := func(req *http.Request) (*httptest.ResponseRecorder, []*ClientAbortError, []error) {
+		var aborts []*ClientAbortError
+		var errs []error
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+		builder.OnClientAbort(func(ctx *gin.Context, err *ClientAbortError) {
+			aborts = append(aborts, err)
+		})
+		builder.OnError(func(ctx *gin.Context, req any, err error) {
+			errs = append(errs, err)
+		})
+
+		router := gin.New()
+		router.POST("/items", builder.MustFormBindingGinHandlerFunc(func(req request) error {
+			return nil
+		}))
+
+		w := httptest.NewRecorder()
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w, aborts, errs
+	}
+
+	t.Run("disconnect", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/items", &failingReader{r: strings.NewReader(`{"name":`), err: io.ErrUnexpectedEOF})
+		w, aborts, errs := serve(req)
+
+		assert.Equal(t, StatusClientClosedRequest, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Empty(t, errs)
+		require.Len(t, aborts, 1)
+		assert.False(t, aborts[0].Timeout)
+		assert.ErrorIs(t, aborts[0], io.ErrUnexpectedEOF)
+		assert.Equal(t, CodeCanceled, ErrorCode(aborts[0]))
+	})
+
+	t.Run("read timeout", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/items", &failingReader{r: strings.NewReader(`{"na`), err: os.ErrDeadlineExceeded})
+		w, aborts, errs := serve(req)
+
+		assert.Equal(t, http.StatusRequestTimeout, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Empty(t, errs)
+		require.Len(t, aborts, 1)
+		assert.True(t, aborts[0].Timeout)
+		assert.Equal(t, CodeTimeout, ErrorCode(aborts[0]))
+	})
+
+	t.Run("canceled request", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req, _ := http.NewRequestWithContext(ctx, "POST", "/items?page=x", strings.NewReader(`{}`))
+		w, aborts, errs := serve(req)
+
+		assert.Equal(t, StatusClientClosedRequest, w.Code)
+		assert.Empty(t, errs)
+		require.Len(t, aborts, 1)
+		var bindingErr *BindingError
+		assert.ErrorAs(t, aborts[0], &bindingErr)
+	})
+
+	t.Run("truncated body", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/items", strings.NewReader(`{"name":`))
+		w, aborts, errs := serve(req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, aborts)
+		assert.Len(t, errs, 1)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/items", strings.NewReader(`{"name":"long name"}`))
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 4)
+		w, aborts, errs := serve(req)
+
+		assert.NotEqual(t, StatusClientClosedRequest, w.Code)
+		assert.Empty(t, aborts)
+		assert.Len(t, errs, 1)
+	})
+}
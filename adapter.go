@@ -0,0 +1,75 @@
+package ginbinding
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StdHandlerFunc converts a handler function like FormBindingGinHandlerFunc
+// to an http.HandlerFunc for a plain http.ServeMux, whose patterns name the
+// path parameters of the request structs, read with r.PathValue. Requests go
+// through the same binding, validation and response handling as on gin:
+//
+//	mux.Handle("GET /users/{id}", ginbinding.Must(builder.StdHandlerFunc(getUser)))
+//
+// Handlers taking a *gin.Context receive one backed by the request, whose
+// FullPath is empty and whose ClientIP is the remote address, since the
+// proxies in front of the mux are unknown.
+func (builder *BasicFormBindingGinHandlerBuilder) StdHandlerFunc(i any, opts ...Option) (http.HandlerFunc, error) {
+	if len(opts) > 0 {
+		builder = builder.Clone(opts...)
+	}
+
+	meta, err := builder.Describe(i)
+	if err != nil {
+		return nil, err
+	}
+	handler, err := builder.FormBindingGinHandlerFunc(i)
+	if err != nil {
+		return nil, err
+	}
+	names := pathParamNames(meta.RequestType, meta.BodyType)
+
+	// The engine has no routes, so that every request reaches its NoRoute
+	// handlers whatever its method and path
+	engine := gin.New()
+	engine.ContextWithFallback = true
+	_ = engine.SetTrustedProxies(nil)
+	engine.NoRoute(func(ctx *gin.Context) {
+		// NoRoute handlers start with a 404 status
+		ctx.Status(http.StatusOK)
+		for _, name := range names {
+			ctx.Params = append(ctx.Params, gin.Param{Key: name, Value: ctx.Request.PathValue(name)})
+		}
+		handler(ctx)
+	})
+
+	return engine.ServeHTTP, nil
+}
+
+// pathParamNames returns the names of the path parameters bound by the
+// request struct types
+func pathParamNames(types ...reflect.Type) []string {
+	var names []string
+	for _, ty := range types {
+		if ty == nil {
+			continue
+		}
+		if ty.Kind() == reflect.Pointer {
+			ty = ty.Elem()
+		}
+		if ty.Kind() != reflect.Struct {
+			continue
+		}
+		_ = walkTypeFields(ty, func(sf reflect.StructField) error {
+			if name, ok := pathTag(sf); ok && name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+			return nil
+		})
+	}
+	return names
+}
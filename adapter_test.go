@@ -0,0 +1,84 @@
+package ginbinding
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type adapterRequest struct {
+	ID     int    `path:"id" binding:"required"`
+	Filter string `form:"filter" default:"all"`
+	Name   string `json:"name"`
+}
+
+func TestStdHandlerFunc(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	update, err := builder.StdHandlerFunc(func(ctx context.Context, req adapterRequest) (any, error) {
+		if req.ID == 404 {
+			return nil, ErrNotFound
+		}
+		return req, nil
+	})
+	assert.NoError(t, err)
+
+	purge, err := builder.StdHandlerFunc(func(c *gin.Context) (any, error) {
+		return gin.H{"method": c.Request.Method}, nil
+	})
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("PUT /users/{id}", update)
+	mux.Handle("/cache/", purge)
+
+	serve := func(method, target, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		mux.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("bound", func(t *testing.T) {
+		w, response := serve("PUT", "/users/7", `{"name":"Ann"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, map[string]interface{}{"ID": float64(7), "Filter": "all", "name": "Ann"}, response["data"])
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		w, _ := serve("PUT", "/users/404", `{}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("binding error", func(t *testing.T) {
+		w, response := serve("PUT", "/users/abc", `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "path", response["source"])
+	})
+
+	t.Run("any method", func(t *testing.T) {
+		w, response := serve("PURGE", "/cache/users", ``)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, map[string]interface{}{"method": "PURGE"}, response["data"])
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		_, err := builder.StdHandlerFunc(func() {})
+		assert.Error(t, err)
+	})
+}
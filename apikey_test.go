@@ -0,0 +1,104 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAPIKeyAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type account struct {
+		Name     string
+		ReadOnly bool
+	}
+	accounts := map[string]*account{
+		"k1": {Name: "ann"},
+		"k2": {Name: "bob", ReadOnly: true},
+	}
+
+	bound := 0
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithAPIKeyAuth("X-API-Key", func(c *gin.Context, key string) (any, error) {
+		a, ok := accounts[key]
+		if !ok {
+			return nil, ErrUnauthorized
+		}
+		if a.ReadOnly && c.Request.Method != http.MethodGet {
+			return nil, ErrForbidden
+		}
+		return a, nil
+	}))
+	builder.OnSuccess(func(ctx *gin.Context, req any, resp any) { bound++ })
+
+	router := gin.New()
+	router.POST("/reports", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Account *account `ctx:"principal"`
+		Title   string   `json:"title"`
+	}) (string, error) {
+		return req.Account.Name + ": " + req.Title, nil
+	}))
+
+	tests := []struct {
+		name           string
+		header         string
+		query          string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "header", header: "k1", body: `{"title":"q1"}`, expectedStatus: http.StatusOK, expectedBody: "ann: q1"},
+		{name: "query", query: "?X-API-Key=k1", body: `{"title":"q1"}`, expectedStatus: http.StatusOK, expectedBody: "ann: q1"},
+		{name: "missing", body: `{"title":"q1"}`, expectedStatus: http.StatusUnauthorized},
+		{name: "unknown", header: "nope", body: `{"title":"q1"}`, expectedStatus: http.StatusUnauthorized},
+		{name: "forbidden", header: "k2", body: `{"title":"q1"}`, expectedStatus: http.StatusForbidden},
+		{name: "principal cannot be sent", header: "k1", body: `{"title":"q1","Account":{"Name":"mallory"}}`, expectedStatus: http.StatusOK, expectedBody: "ann: q1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/reports"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set("X-API-Key", tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+	assert.Equal(t, 3, bound)
+}
+
+func TestContextTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("tenant", c.GetHeader("X-Tenant"))
+	})
+	router.GET("/items", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Tenant string `ctx:"tenant"`
+		UserID int    `ctx:"user_id"`
+	}) (any, error) {
+		return gin.H{"tenant": req.Tenant, "user_id": req.UserID}, nil
+	}))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/items?Tenant=mallory&UserID=7", nil)
+	req.Header.Set("X-Tenant", "acme")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"success","data":{"tenant":"acme","user_id":0}}`, w.Body.String())
+}
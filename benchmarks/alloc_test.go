@@ -0,0 +1,48 @@
+//go:build !race
+
+package benchmarks
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestAllocationBudget is left out of race builds, since the race detector
+// allocates on its own
+func TestAllocationBudget(t *testing.T) {
+	fixtures := map[string]fixture{
+		"Dispatch":         dispatchFixture(),
+		"HealthCheck":      healthCheckFixture(),
+		"SmallQuery":       smallQueryFixture(),
+		"LargeMixed":       largeMixedFixture(),
+		"PooledLargeMixed": pooledLargeMixedFixture(),
+		"FileUpload":       uploadFixture(),
+		"DeepJSON":         deepJSONFixture(),
+		"SimpleJSON":       simpleJSONFixture(),
+		"DefaultsHeavy":    defaultsHeavyFixture(),
+		"LargeBody":        largeBodyFixture(),
+	}
+
+	for name, f := range fixtures {
+		t.Run(name, func(t *testing.T) {
+			budget, ok := BaselineAllocsPerOp[name]
+			if !ok {
+				t.Fatalf("no allocation baseline for %s", name)
+			}
+
+			if code := f.serve(); code != http.StatusOK {
+				t.Fatalf("unexpected status code %d", code)
+			}
+
+			allocs := testing.AllocsPerRun(100, func() {
+				f.serve()
+			})
+
+			t.Logf("%s: %.0f allocs/op (baseline %d)", name, allocs, budget)
+
+			if allocs > float64(budget)*(1+AllocsTolerance) {
+				t.Errorf("%s allocates %.0f times per request, baseline is %d", name, allocs, budget)
+			}
+		})
+	}
+}
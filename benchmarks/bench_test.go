@@ -0,0 +1,77 @@
+package benchmarks
+
+import (
+	"net/http"
+	"testing"
+)
+
+func runBenchmark(b *testing.B, f fixture) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if code := f.serve(); code != http.StatusOK {
+			b.Fatalf("unexpected status code %d", code)
+		}
+	}
+}
+
+func BenchmarkDispatch(b *testing.B) {
+	runBenchmark(b, dispatchFixture())
+}
+
+func BenchmarkHealthCheck(b *testing.B) {
+	runBenchmark(b, healthCheckFixture())
+}
+
+func BenchmarkSmallQuery(b *testing.B) {
+	runBenchmark(b, smallQueryFixture())
+}
+
+func BenchmarkLargeMixed(b *testing.B) {
+	runBenchmark(b, largeMixedFixture())
+}
+
+func BenchmarkPooledLargeMixed(b *testing.B) {
+	runBenchmark(b, pooledLargeMixedFixture())
+}
+
+func BenchmarkFileUpload(b *testing.B) {
+	runBenchmark(b, uploadFixture())
+}
+
+func BenchmarkDeepJSON(b *testing.B) {
+	runBenchmark(b, deepJSONFixture())
+}
+
+func BenchmarkSimpleJSON(b *testing.B) {
+	runBenchmark(b, simpleJSONFixture())
+}
+
+func BenchmarkDefaultsHeavy(b *testing.B) {
+	runBenchmark(b, defaultsHeavyFixture())
+}
+
+func BenchmarkLargeBody(b *testing.B) {
+	runBenchmark(b, largeBodyFixture())
+}
+
+func BenchmarkRawGinSimpleJSON(b *testing.B) {
+	runBenchmark(b, rawSimpleJSONFixture())
+}
+
+func BenchmarkRawGinSmallQuery(b *testing.B) {
+	runBenchmark(b, rawSmallQueryFixture())
+}
+
+func BenchmarkRawGinLargeMixed(b *testing.B) {
+	runBenchmark(b, rawLargeMixedFixture())
+}
+
+func BenchmarkRawGinDefaultsHeavy(b *testing.B) {
+	runBenchmark(b, rawDefaultsHeavyFixture())
+}
+
+func BenchmarkRawGinLargeBody(b *testing.B) {
+	runBenchmark(b, rawLargeBodyFixture())
+}
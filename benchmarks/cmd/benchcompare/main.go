@@ -0,0 +1,72 @@
+// Command benchcompare compares two `go test -bench -benchmem` outputs and
+// exits with a non-zero status when a benchmark regressed beyond the threshold.
+// With -overhead, it instead reports the overhead of every benchmark of a
+// single output over its raw gin baseline.
+//
+// Usage:
+//
+//	benchcompare [-threshold 0.1] old.txt new.txt
+//	benchcompare -overhead new.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/zgs225/gin-form-binding/benchmarks"
+)
+
+func main() {
+	threshold := flag.Float64("threshold", 0.1, "relative growth of ns/op or allocs/op treated as a regression")
+	overhead := flag.Bool("overhead", false, "report the overhead of each benchmark over its raw gin baseline")
+	flag.Parse()
+
+	if *overhead && flag.NArg() == 1 {
+		results, err := parseFile(flag.Arg(0))
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, d := range benchmarks.Overhead(results) {
+			fmt.Println(d)
+		}
+		return
+	}
+
+	if *overhead || flag.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: benchcompare [-threshold 0.1] old.txt new.txt")
+		fmt.Fprintln(os.Stderr, "       benchcompare -overhead new.txt")
+		os.Exit(2)
+	}
+
+	baseline, err := parseFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	current, err := parseFile(flag.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	deltas := benchmarks.Compare(baseline, current)
+	for _, d := range deltas {
+		fmt.Println(d)
+	}
+
+	if regressions := benchmarks.Regressions(deltas, *threshold); len(regressions) > 0 {
+		fmt.Printf("\n%d benchmark(s) regressed by more than %.0f%%\n", len(regressions), *threshold*100)
+		os.Exit(1)
+	}
+}
+
+func parseFile(name string) (map[string]benchmarks.Result, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return benchmarks.ParseResults(f)
+}
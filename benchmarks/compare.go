@@ -0,0 +1,169 @@
+package benchmarks
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Result holds the measurements of a single benchmark line produced by
+// `go test -bench . -benchmem`
+type Result struct {
+	Name        string
+	NsPerOp     float64
+	BytesPerOp  int64
+	AllocsPerOp int64
+}
+
+// Delta pairs the baseline and current measurements of one benchmark
+type Delta struct {
+	Name     string
+	Baseline Result
+	Current  Result
+}
+
+// NsPerOpChange returns the relative change of ns/op, e.g. 0.1 for a 10% slowdown
+func (d Delta) NsPerOpChange() float64 {
+	return relativeChange(d.Baseline.NsPerOp, d.Current.NsPerOp)
+}
+
+// AllocsPerOpChange returns the relative change of allocs/op
+func (d Delta) AllocsPerOpChange() float64 {
+	return relativeChange(float64(d.Baseline.AllocsPerOp), float64(d.Current.AllocsPerOp))
+}
+
+// String formats the delta as a single human readable line
+func (d Delta) String() string {
+	return fmt.Sprintf("%-24s %12.0f -> %-12.0f ns/op (%+6.1f%%) %6d -> %-6d allocs/op (%+6.1f%%)",
+		d.Name,
+		d.Baseline.NsPerOp, d.Current.NsPerOp, d.NsPerOpChange()*100,
+		d.Baseline.AllocsPerOp, d.Current.AllocsPerOp, d.AllocsPerOpChange()*100,
+	)
+}
+
+// ParseResults reads `go test -bench` output and returns the results keyed by
+// benchmark name with the "Benchmark" prefix and the GOMAXPROCS suffix removed.
+// When a benchmark appears multiple times (-count > 1) the fastest run is kept.
+func ParseResults(r io.Reader) (map[string]Result, error) {
+	results := make(map[string]Result)
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+
+		result := Result{Name: benchmarkName(fields[0])}
+
+		// fields[1] is the iteration count, followed by value/unit pairs
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, unit := fields[i], fields[i+1]
+
+			var err error
+			switch unit {
+			case "ns/op":
+				result.NsPerOp, err = strconv.ParseFloat(value, 64)
+			case "B/op":
+				result.BytesPerOp, err = strconv.ParseInt(value, 10, 64)
+			case "allocs/op":
+				result.AllocsPerOp, err = strconv.ParseInt(value, 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("benchmark %s: invalid %s value %q: %w", result.Name, unit, value, err)
+			}
+		}
+
+		if prev, ok := results[result.Name]; ok && prev.NsPerOp <= result.NsPerOp {
+			continue
+		}
+		results[result.Name] = result
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Compare pairs every benchmark present in both runs, sorted by name
+func Compare(baseline, current map[string]Result) []Delta {
+	deltas := make([]Delta, 0, len(current))
+
+	for name, cur := range current {
+		base, ok := baseline[name]
+		if !ok {
+			continue
+		}
+		deltas = append(deltas, Delta{Name: name, Baseline: base, Current: cur})
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].Name < deltas[j].Name
+	})
+
+	return deltas
+}
+
+// RawGinPrefix names the raw gin baseline of a benchmark: RawGinSmallQuery
+// serves the requests of SmallQuery with a plain gin handler
+const RawGinPrefix = "RawGin"
+
+// Overhead pairs every benchmark of a run with its raw gin baseline from the
+// same run, sorted by name. The deltas report the cost of ginbinding over
+// binding with gin's ShouldBind.
+func Overhead(results map[string]Result) []Delta {
+	baseline := make(map[string]Result)
+	current := make(map[string]Result)
+
+	for name, r := range results {
+		if raw, ok := strings.CutPrefix(name, RawGinPrefix); ok {
+			baseline[raw] = r
+		} else {
+			current[name] = r
+		}
+	}
+
+	return Compare(baseline, current)
+}
+
+// Regressions returns the deltas whose ns/op or allocs/op grew by more than
+// the given relative threshold (0.1 means 10%)
+func Regressions(deltas []Delta, threshold float64) []Delta {
+	var regressions []Delta
+
+	for _, d := range deltas {
+		if d.NsPerOpChange() > threshold || d.AllocsPerOpChange() > threshold {
+			regressions = append(regressions, d)
+		}
+	}
+
+	return regressions
+}
+
+func benchmarkName(s string) string {
+	s = strings.TrimPrefix(s, "Benchmark")
+
+	// Strip the "-8" GOMAXPROCS suffix
+	if i := strings.LastIndexByte(s, '-'); i >= 0 {
+		if _, err := strconv.Atoi(s[i+1:]); err == nil {
+			s = s[:i]
+		}
+	}
+
+	return s
+}
+
+func relativeChange(base, cur float64) float64 {
+	if base == 0 {
+		if cur == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (cur - base) / base
+}
@@ -0,0 +1,69 @@
+package benchmarks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleOutput = `goos: linux
+goarch: amd64
+pkg: github.com/zgs225/gin-form-binding/benchmarks
+BenchmarkSmallQuery-8   	  113769	     11183 ns/op	    3032 B/op	      35 allocs/op
+BenchmarkSmallQuery-8   	  113769	     10900 ns/op	    3032 B/op	      35 allocs/op
+BenchmarkLargeMixed-8   	   32078	     38664 ns/op	    5209 B/op	      64 allocs/op
+PASS
+`
+
+func TestParseResults(t *testing.T) {
+	results, err := ParseResults(strings.NewReader(sampleOutput))
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	assert.Equal(t, Result{Name: "SmallQuery", NsPerOp: 10900, BytesPerOp: 3032, AllocsPerOp: 35}, results["SmallQuery"])
+	assert.Equal(t, Result{Name: "LargeMixed", NsPerOp: 38664, BytesPerOp: 5209, AllocsPerOp: 64}, results["LargeMixed"])
+}
+
+func TestParseResultsInvalidValue(t *testing.T) {
+	_, err := ParseResults(strings.NewReader("BenchmarkX-8 10 abc ns/op\n"))
+	assert.Error(t, err)
+}
+
+func TestCompareAndRegressions(t *testing.T) {
+	baseline := map[string]Result{
+		"A": {Name: "A", NsPerOp: 100, AllocsPerOp: 10},
+		"B": {Name: "B", NsPerOp: 100, AllocsPerOp: 10},
+		"C": {Name: "C", NsPerOp: 100, AllocsPerOp: 10},
+	}
+	current := map[string]Result{
+		"A": {Name: "A", NsPerOp: 105, AllocsPerOp: 10},
+		"B": {Name: "B", NsPerOp: 100, AllocsPerOp: 12},
+		"D": {Name: "D", NsPerOp: 100, AllocsPerOp: 10},
+	}
+
+	deltas := Compare(baseline, current)
+	assert.Len(t, deltas, 2)
+	assert.Equal(t, "A", deltas[0].Name)
+	assert.Equal(t, "B", deltas[1].Name)
+	assert.InDelta(t, 0.05, deltas[0].NsPerOpChange(), 1e-9)
+	assert.InDelta(t, 0.2, deltas[1].AllocsPerOpChange(), 1e-9)
+
+	regressions := Regressions(deltas, 0.1)
+	assert.Len(t, regressions, 1)
+	assert.Equal(t, "B", regressions[0].Name)
+}
+
+func TestOverhead(t *testing.T) {
+	results := map[string]Result{
+		"SmallQuery":       {Name: "SmallQuery", NsPerOp: 150, AllocsPerOp: 30},
+		"RawGinSmallQuery": {Name: "RawGinSmallQuery", NsPerOp: 100, AllocsPerOp: 20},
+		"DeepJSON":         {Name: "DeepJSON", NsPerOp: 100, AllocsPerOp: 10},
+	}
+
+	deltas := Overhead(results)
+	assert.Len(t, deltas, 1)
+	assert.Equal(t, "SmallQuery", deltas[0].Name)
+	assert.InDelta(t, 0.5, deltas[0].NsPerOpChange(), 1e-9)
+	assert.InDelta(t, 0.5, deltas[0].AllocsPerOpChange(), 1e-9)
+}
@@ -0,0 +1,67 @@
+// Package benchmarks contains the performance suite of ginbinding.
+//
+// The suite covers ten representative handlers:
+//
+//   - Dispatch:   a handler without a request, measuring the cost of calling it
+//   - HealthCheck: a func(*gin.Context) (any, error) handler, called without reflection
+//   - SmallQuery: a GET list request bound from three query parameters with defaults
+//   - LargeMixed: path, query, header, wildcard header, JSON body and defaults in one struct
+//   - PooledLargeMixed: LargeMixed with a request struct implementing ginbinding.Resetter
+//   - FileUpload: a multipart form with a 4KB file and a text field
+//   - DeepJSON:   a JSON tree six levels deep with two children per node
+//   - SimpleJSON: a JSON body of three fields and no other source
+//   - DefaultsHeavy: twelve query parameters, all absent and set from their defaults
+//   - LargeBody:  a JSON body of 500 records, about 30KB
+//
+// SimpleJSON, SmallQuery, LargeMixed, DefaultsHeavy and LargeBody have a raw
+// gin baseline, RawGin<Name>, serving the same requests with a plain gin
+// handler calling ShouldBind. Report the overhead of ginbinding over gin with:
+//
+//	go run ./benchmarks/cmd/benchcompare -overhead new.txt
+//
+// Run the suite with:
+//
+//	go test ./benchmarks -run '^$' -bench . -benchmem -count 5 > new.txt
+//
+// and compare it against a previous run with:
+//
+//	go run ./benchmarks/cmd/benchcompare old.txt new.txt
+//
+// Allocations are deterministic enough to be used as a regression gate, so
+// TestAllocationBudget fails whenever a handler allocates more than its entry
+// in BaselineAllocsPerOp plus AllocsTolerance. It does not run under the race
+// detector, which allocates on its own. The baseline was recorded with
+// gin v1.11.0 on linux/amd64:
+//
+//	Benchmark           ns/op   B/op   allocs/op
+//	Dispatch             7063   2016   22
+//	HealthCheck          6497   1936   19
+//	SmallQuery          18702   3032   35
+//	LargeMixed          59517   5921   66
+//	PooledLargeMixed    61030   5554   62
+//	FileUpload          60248  28561   94
+//	DeepJSON           114885  15581  102
+//	SimpleJSON          15798   3048   34
+//	DefaultsHeavy       41429   2904   44
+//	LargeBody          726418 133348   53
+//
+// When a change intentionally alters allocations, update BaselineAllocsPerOp
+// and the table above in the same commit.
+package benchmarks
+
+// AllocsTolerance is the relative allocation growth tolerated by the gate
+const AllocsTolerance = 0.1
+
+// BaselineAllocsPerOp is the allocation budget of each benchmark fixture
+var BaselineAllocsPerOp = map[string]int64{
+	"Dispatch":         22,
+	"HealthCheck":      19,
+	"SmallQuery":       35,
+	"LargeMixed":       66,
+	"PooledLargeMixed": 62,
+	"FileUpload":       94,
+	"DeepJSON":         102,
+	"SimpleJSON":       34,
+	"DefaultsHeavy":    44,
+	"LargeBody":        53,
+}
@@ -0,0 +1,316 @@
+package benchmarks
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	ginbinding "github.com/zgs225/gin-form-binding"
+)
+
+// SmallQueryRequest is a typical GET list request bound from the query string
+type SmallQueryRequest struct {
+	Page     int    `form:"page" default:"1"`
+	PageSize int    `form:"page_size" default:"20"`
+	Search   string `form:"search"`
+}
+
+// Address is a nested struct used by the large and deep fixtures
+type Address struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+	Zip    string `json:"zip"`
+}
+
+// LargeMixedRequest binds from every supported source at once
+type LargeMixedRequest struct {
+	UserID    int               `path:"user_id"`
+	Page      int               `form:"page" default:"1"`
+	PageSize  int               `form:"page_size" default:"20"`
+	Sort      string            `form:"sort" default:"created_at"`
+	AuthToken string            `header:"Authorization"`
+	RequestID string            `header:"X-Request-Id"`
+	Meta      map[string]string `header:"X-Meta-*"`
+	Name      string            `json:"name"`
+	Email     string            `json:"email"`
+	Age       int               `json:"age" default:"18"`
+	IsActive  bool              `json:"is_active" default:"true"`
+	Timeout   time.Duration     `json:"timeout" default:"30s"`
+	Created   time.Time         `json:"created" default:"2023-01-01T00:00:00Z"`
+	Tags      []string          `json:"tags"`
+	Address   Address           `json:"address"`
+	Score     float64           `json:"score"`
+	Nickname  *string           `json:"nickname" default:"anonymous"`
+}
+
+// SimpleJSONRequest is a small JSON body without other sources
+type SimpleJSONRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+// DefaultsHeavyRequest is a settings request whose fields all fall back to
+// their default values
+type DefaultsHeavyRequest struct {
+	Page      int           `form:"page" default:"1"`
+	PageSize  int           `form:"page_size" default:"20"`
+	Sort      string        `form:"sort" default:"created_at"`
+	Order     string        `form:"order" default:"desc"`
+	Locale    string        `form:"locale" default:"en-US"`
+	Timezone  string        `form:"tz" default:"UTC"`
+	Currency  string        `form:"currency" default:"USD"`
+	Verbose   bool          `form:"verbose" default:"false"`
+	Archived  bool          `form:"archived" default:"false"`
+	Threshold float64       `form:"threshold" default:"0.5"`
+	Timeout   time.Duration `form:"timeout" default:"30s"`
+	MaxDepth  int           `form:"max_depth" default:"5"`
+}
+
+// LargeBodyRequest binds a JSON body of a few hundred records
+type LargeBodyRequest struct {
+	Batch   string    `json:"batch"`
+	Records []Address `json:"records"`
+}
+
+// PooledLargeMixedRequest is LargeMixedRequest reused across requests
+type PooledLargeMixedRequest LargeMixedRequest
+
+// Reset implements ginbinding.Resetter, keeping the capacity of Tags
+func (r *PooledLargeMixedRequest) Reset() {
+	*r = PooledLargeMixedRequest{Tags: r.Tags[:0]}
+}
+
+// UploadRequest binds a multipart file together with a form field
+type UploadRequest struct {
+	Title string                `form:"title"`
+	File  *multipart.FileHeader `form:"file"`
+}
+
+// DeepNode is a recursive JSON structure used for the deep body fixture
+type DeepNode struct {
+	Name     string     `json:"name"`
+	Value    int        `json:"value"`
+	Children []DeepNode `json:"children"`
+}
+
+// DeepJSONRequest binds a deeply nested JSON document
+type DeepJSONRequest struct {
+	Root DeepNode `json:"root"`
+}
+
+// fixture describes a prepared router plus a factory for identical requests
+type fixture struct {
+	router     *gin.Engine
+	newRequest func() *http.Request
+}
+
+func newFixture(method, route string, handler any, newRequest func() *http.Request) fixture {
+	gin.SetMode(gin.ReleaseMode)
+
+	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	if err != nil {
+		panic(err)
+	}
+
+	router := gin.New()
+	router.Handle(method, route, ginHandler)
+
+	return fixture{router: router, newRequest: newRequest}
+}
+
+// serve runs one request through the fixture and reports the status code
+func (f fixture) serve() int {
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, f.newRequest())
+	return w.Code
+}
+
+func smallQueryFixture() fixture {
+	return newFixture(http.MethodGet, "/users",
+		func(c *gin.Context, req SmallQueryRequest) (any, error) {
+			return nil, nil
+		},
+		func() *http.Request {
+			req, _ := http.NewRequest(http.MethodGet, "/users?page=2&search=john", nil)
+			return req
+		},
+	)
+}
+
+const largeMixedBody = `{"name":"John","email":"john@example.com","age":30,"tags":["a","b","c"],` +
+	`"address":{"street":"1 Main St","city":"Springfield","zip":"12345"},"score":9.5}`
+
+const simpleJSONBody = `{"name":"John","email":"john@example.com","age":30}`
+
+func simpleJSONFixture() fixture {
+	return newFixture(http.MethodPost, "/users",
+		func(c *gin.Context, req SimpleJSONRequest) (any, error) {
+			return nil, nil
+		},
+		newJSONRequest("/users", simpleJSONBody),
+	)
+}
+
+func defaultsHeavyFixture() fixture {
+	return newFixture(http.MethodGet, "/settings",
+		func(c *gin.Context, req DefaultsHeavyRequest) (any, error) {
+			return nil, nil
+		},
+		func() *http.Request {
+			req, _ := http.NewRequest(http.MethodGet, "/settings", nil)
+			return req
+		},
+	)
+}
+
+// largeBody is a JSON body of 500 records, about 30KB
+var largeBody = func() string {
+	var sb strings.Builder
+	sb.WriteString(`{"batch":"import-1","records":[`)
+	for i := 0; i < 500; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(`{"street":"1 Main St","city":"Springfield","zip":"12345"}`)
+	}
+	sb.WriteString("]}")
+	return sb.String()
+}()
+
+func largeBodyFixture() fixture {
+	return newFixture(http.MethodPost, "/imports",
+		func(c *gin.Context, req LargeBodyRequest) (any, error) {
+			return nil, nil
+		},
+		newJSONRequest("/imports", largeBody),
+	)
+}
+
+// newJSONRequest returns a factory of POST requests to target with the JSON
+// body
+func newJSONRequest(target, body string) func() *http.Request {
+	return func() *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+}
+
+func largeMixedFixture() fixture {
+	return newFixture(http.MethodPost, "/users/:user_id",
+		func(c *gin.Context, req LargeMixedRequest) (any, error) {
+			return nil, nil
+		},
+		func() *http.Request {
+			req, _ := http.NewRequest(http.MethodPost, "/users/42?page=3&sort=name", strings.NewReader(largeMixedBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("X-Request-Id", "req-1")
+			req.Header.Set("X-Meta-Tenant", "acme")
+			return req
+		},
+	)
+}
+
+func pooledLargeMixedFixture() fixture {
+	f := largeMixedFixture()
+	return newFixture(http.MethodPost, "/users/:user_id",
+		func(c *gin.Context, req PooledLargeMixedRequest) (any, error) {
+			return nil, nil
+		},
+		f.newRequest,
+	)
+}
+
+// dispatchFixture serves a handler without a request, so that it measures the
+// cost of calling handler functions and writing their response
+func dispatchFixture() fixture {
+	return newFixture(http.MethodGet, "/ping",
+		func(ctx context.Context) (any, error) {
+			return nil, nil
+		},
+		func() *http.Request {
+			req, _ := http.NewRequest(http.MethodGet, "/ping", nil)
+			return req
+		},
+	)
+}
+
+// healthCheckFixture serves a func(*gin.Context) (any, error) handler, which
+// is called directly rather than through reflection
+func healthCheckFixture() fixture {
+	return newFixture(http.MethodGet, "/health",
+		func(c *gin.Context) (any, error) {
+			return nil, nil
+		},
+		func() *http.Request {
+			req, _ := http.NewRequest(http.MethodGet, "/health", nil)
+			return req
+		},
+	)
+}
+
+func uploadFixture() fixture {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	_ = mw.WriteField("title", "report")
+	fw, _ := mw.CreateFormFile("file", "report.csv")
+	_, _ = fw.Write(bytes.Repeat([]byte("id,name\n1,john\n"), 256))
+	_ = mw.Close()
+
+	body := buf.Bytes()
+	contentType := mw.FormDataContentType()
+
+	return newFixture(http.MethodPost, "/upload",
+		func(c *gin.Context, req UploadRequest) (any, error) {
+			return nil, nil
+		},
+		func() *http.Request {
+			req, _ := http.NewRequest(http.MethodPost, "/upload", bytes.NewReader(body))
+			req.Header.Set("Content-Type", contentType)
+			return req
+		},
+	)
+}
+
+// deepJSONBody builds a tree of the given depth where every node has two children
+func deepJSONBody(depth int) string {
+	var sb strings.Builder
+	var write func(level int)
+	write = func(level int) {
+		sb.WriteString(`{"name":"node","value":1,"children":[`)
+		if level < depth {
+			write(level + 1)
+			sb.WriteString(",")
+			write(level + 1)
+		}
+		sb.WriteString("]}")
+	}
+
+	sb.WriteString(`{"root":`)
+	write(1)
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func deepJSONFixture() fixture {
+	body := deepJSONBody(6)
+
+	return newFixture(http.MethodPost, "/tree",
+		func(c *gin.Context, req DeepJSONRequest) (any, error) {
+			return nil, nil
+		},
+		func() *http.Request {
+			req, _ := http.NewRequest(http.MethodPost, "/tree", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			return req
+		},
+	)
+}
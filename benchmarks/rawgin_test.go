@@ -0,0 +1,136 @@
+package benchmarks
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The raw gin fixtures serve the same requests as their ginbinding
+// counterparts with plain gin handlers calling ShouldBind and friends, and
+// write the same success response. They are the baselines that the overhead
+// of ginbinding is measured against, with benchcompare -overhead.
+
+// RawLargeMixedRequest is LargeMixedRequest with gin's own tags. Gin has no
+// wildcard headers and no defaults for body fields, so it binds less.
+type RawLargeMixedRequest struct {
+	UserID    int    `uri:"user_id"`
+	Page      int    `form:"page,default=1"`
+	PageSize  int    `form:"page_size,default=20"`
+	Sort      string `form:"sort,default=created_at"`
+	AuthToken string `header:"Authorization"`
+	RequestID string `header:"X-Request-Id"`
+	Body      struct {
+		Name     string        `json:"name"`
+		Email    string        `json:"email"`
+		Age      int           `json:"age"`
+		IsActive bool          `json:"is_active"`
+		Timeout  time.Duration `json:"timeout"`
+		Created  time.Time     `json:"created"`
+		Tags     []string      `json:"tags"`
+		Address  Address       `json:"address"`
+		Score    float64       `json:"score"`
+		Nickname *string       `json:"nickname"`
+	}
+}
+
+// RawDefaultsHeavyRequest is DefaultsHeavyRequest with gin's default syntax
+type RawDefaultsHeavyRequest struct {
+	Page      int     `form:"page,default=1"`
+	PageSize  int     `form:"page_size,default=20"`
+	Sort      string  `form:"sort,default=created_at"`
+	Order     string  `form:"order,default=desc"`
+	Locale    string  `form:"locale,default=en-US"`
+	Timezone  string  `form:"tz,default=UTC"`
+	Currency  string  `form:"currency,default=USD"`
+	Verbose   bool    `form:"verbose,default=false"`
+	Archived  bool    `form:"archived,default=false"`
+	Threshold float64 `form:"threshold,default=0.5"`
+	Timeout   string  `form:"timeout,default=30s"`
+	MaxDepth  int     `form:"max_depth,default=5"`
+}
+
+// newRawFixture serves handler as a plain gin handler
+func newRawFixture(method, route string, handler gin.HandlerFunc, newRequest func() *http.Request) fixture {
+	gin.SetMode(gin.ReleaseMode)
+
+	router := gin.New()
+	router.Handle(method, route, handler)
+
+	return fixture{router: router, newRequest: newRequest}
+}
+
+// rawBind returns a gin handler binding a new T with bind and answering like
+// ginbinding's default response handler
+func rawBind[T any](bind func(c *gin.Context, req *T) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req T
+		if err := bind(c, &req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "success"})
+	}
+}
+
+func rawSimpleJSONFixture() fixture {
+	return newRawFixture(http.MethodPost, "/users",
+		rawBind(func(c *gin.Context, req *SimpleJSONRequest) error {
+			return c.ShouldBind(req)
+		}),
+		newJSONRequest("/users", simpleJSONBody),
+	)
+}
+
+func rawSmallQueryFixture() fixture {
+	f := smallQueryFixture()
+	return newRawFixture(http.MethodGet, "/users",
+		rawBind(func(c *gin.Context, req *struct {
+			Page     int    `form:"page,default=1"`
+			PageSize int    `form:"page_size,default=20"`
+			Search   string `form:"search"`
+		}) error {
+			return c.ShouldBind(req)
+		}),
+		f.newRequest,
+	)
+}
+
+func rawLargeMixedFixture() fixture {
+	f := largeMixedFixture()
+	return newRawFixture(http.MethodPost, "/users/:user_id",
+		rawBind(func(c *gin.Context, req *RawLargeMixedRequest) error {
+			if err := c.ShouldBindUri(req); err != nil {
+				return err
+			}
+			if err := c.ShouldBindQuery(req); err != nil {
+				return err
+			}
+			if err := c.ShouldBindHeader(req); err != nil {
+				return err
+			}
+			return c.ShouldBindJSON(&req.Body)
+		}),
+		f.newRequest,
+	)
+}
+
+func rawDefaultsHeavyFixture() fixture {
+	f := defaultsHeavyFixture()
+	return newRawFixture(http.MethodGet, "/settings",
+		rawBind(func(c *gin.Context, req *RawDefaultsHeavyRequest) error {
+			return c.ShouldBind(req)
+		}),
+		f.newRequest,
+	)
+}
+
+func rawLargeBodyFixture() fixture {
+	return newRawFixture(http.MethodPost, "/imports",
+		rawBind(func(c *gin.Context, req *LargeBodyRequest) error {
+			return c.ShouldBind(req)
+		}),
+		newJSONRequest("/imports", largeBody),
+	)
+}
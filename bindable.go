@@ -0,0 +1,213 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// WithBindableFields lists the fields of JSON request bodies clients may set,
+// by their dotted JSON paths such as "name" or "address.city". Other keys are
+// removed from the body before it is decoded, so that internal fields with
+// json tags, such as an owner ID or a status set by the server, can never be
+// set by clients. Listing an object allows all of its fields.
+//
+//	r.POST("/posts", builder.MustFormBindingGinHandlerFunc(createPost,
+//		ginbinding.WithBindableFields("title", "body", "tags")))
+//
+// Fields tagged bindable:"false" are never bound from request bodies of any
+// media type, nor from the items of a Stream, with or without the option.
+// Path, query and header inputs are not affected.
+func WithBindableFields(fields ...string) Option {
+	fields = slices.Clone(fields)
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.bindableFields = fields
+	}
+}
+
+// unbindableFields returns the fields of the struct type ty tagged
+// bindable:"false", named by their dotted JSON paths
+func unbindableFields(ty reflect.Type) []taggedField {
+	var fields []taggedField
+	for _, f := range taggedFields(ty, true, func(sf reflect.StructField) (string, bool) {
+		bindable, err := strconv.ParseBool(sf.Tag.Get("bindable"))
+		return "", err == nil && !bindable
+	}) {
+		// Top-level fields named by other inputs are still decoded from
+		// the JSON key of the field
+		if len(f.path) == 1 {
+			sf := typeFieldByPath(ty, f.path)
+			if sf.Tag.Get("json") == "-" {
+				continue
+			}
+			f.name = jsonFieldName(sf)
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// resetUnbindable resets the fields of the request struct val tagged
+// bindable:"false" to their values in before, the request as it was before
+// its body was bound. Fields in slices, maps and behind pointers are zeroed,
+// since only the body fills them. This catches the bodies of every binding,
+// such as forms and XML whose keys match Go field names.
+func resetUnbindable(val, before reflect.Value, fields []taggedField) {
+	for _, f := range fields {
+		resetFieldPath(val, before, f.path)
+	}
+}
+
+// resetFieldPath resets the field at path in val to its value in before, or
+// zeroes it when before is invalid
+func resetFieldPath(val, before reflect.Value, path []string) {
+	if len(path) == 0 {
+		if before.IsValid() {
+			val.Set(before)
+		} else {
+			val.SetZero()
+		}
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.Pointer:
+		if !val.IsNil() {
+			resetFieldPath(val.Elem(), reflect.Value{}, path)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range val.Len() {
+			resetFieldPath(val.Index(i), reflect.Value{}, path)
+		}
+	case reflect.Map:
+		iter := val.MapRange()
+		for iter.Next() {
+			elem := reflect.New(val.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			resetFieldPath(elem, reflect.Value{}, path)
+			val.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.Struct:
+		sf, ok := val.Type().FieldByName(path[0])
+		if !ok {
+			return
+		}
+		// Promoted fields of nil embedded pointers were never bound
+		field, err := val.FieldByIndexErr(sf.Index)
+		if err != nil {
+			return
+		}
+		var prev reflect.Value
+		if before.IsValid() {
+			if prev, err = before.FieldByIndexErr(sf.Index); err != nil {
+				prev = reflect.Value{}
+			}
+		}
+		resetFieldPath(field, prev, path[1:])
+	}
+}
+
+// typeFieldByPath returns the field at the Go field path of the struct type ty
+func typeFieldByPath(ty reflect.Type, path []string) reflect.StructField {
+	var sf reflect.StructField
+	for _, name := range path {
+		for ty.Kind() == reflect.Pointer {
+			ty = ty.Elem()
+		}
+		sf, _ = ty.FieldByName(name)
+		ty = sf.Type
+	}
+	return sf
+}
+
+// stripUnbindableJSON removes the keys of the JSON body of ctx that bind to
+// fields of the struct type ty clients may not set
+func (builder *BasicFormBindingGinHandlerBuilder) stripUnbindableJSON(ctx *gin.Context, ty reflect.Type) error {
+	if ctx.Request.Body == nil || ctx.ContentType() != binding.MIMEJSON {
+		return nil
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return err
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var doc any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		// Leave malformed bodies to the regular JSON binding to report
+		return nil
+	}
+
+	s := bindableFilter{allowed: builder.bindableFields, blocked: typeInfoOf(ty).unbindable}
+	if !s.filter(doc, "", builder.bindableFields == nil) {
+		return nil
+	}
+
+	body, err = json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	ctx.Request.ContentLength = int64(len(body))
+	return nil
+}
+
+// bindableFilter removes the keys of a decoded JSON document outside of
+// allowed or within blocked. Paths are compared case-insensitively, the way
+// encoding/json matches keys to fields.
+type bindableFilter struct {
+	allowed []string
+	blocked []taggedField
+}
+
+// filter removes the keys of the decoded JSON value v at path, and reports
+// whether any was removed. allowAll is set within allowed paths.
+func (s bindableFilter) filter(v any, path string, allowAll bool) bool {
+	removed := false
+	switch v := v.(type) {
+	case []any:
+		// The elements of arrays share the path of the array
+		for _, elem := range v {
+			if s.filter(elem, path, allowAll) {
+				removed = true
+			}
+		}
+	case map[string]any:
+		for key, elem := range v {
+			child := joinJSONPath(path, key)
+			if slices.ContainsFunc(s.blocked, func(f taggedField) bool { return strings.EqualFold(f.name, child) }) {
+				delete(v, key)
+				removed = true
+				continue
+			}
+
+			childAllowAll := allowAll || slices.ContainsFunc(s.allowed, func(p string) bool {
+				return strings.EqualFold(p, child) || hasPrefixFold(child, p+".")
+			})
+			if !childAllowAll && !slices.ContainsFunc(s.allowed, func(p string) bool { return hasPrefixFold(p, child+".") }) {
+				delete(v, key)
+				removed = true
+				continue
+			}
+			if s.filter(elem, child, childAllowAll) {
+				removed = true
+			}
+		}
+	}
+	return removed
+}
+
+// hasPrefixFold reports whether s begins with prefix, ignoring case
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
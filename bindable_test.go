@@ -0,0 +1,142 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type bindableAuthor struct {
+	Name    string `json:"name"`
+	Karma   int    `json:"karma" bindable:"false"`
+	Country string `json:"country"`
+}
+
+type bindableRequest struct {
+	Title   string         `json:"title"`
+	OwnerID int            `json:"owner_id" bindable:"false"`
+	Status  string         `json:"status" default:"draft"`
+	Author  bindableAuthor `json:"author"`
+	Tags    []bindableTag  `json:"tags"`
+	Tenant  string         `header:"X-Tenant" bindable:"false"`
+}
+
+type bindableTag struct {
+	Name  string `json:"name"`
+	Score int    `json:"score" bindable:"false"`
+}
+
+func TestBindableFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		body         string
+		expectedBody string
+	}{
+		{
+			name:         "tagged fields",
+			body:         `{"title":"Hi","OWNER_ID":7,"author":{"name":"Ann","karma":99},"tags":[{"name":"go","score":5}],"Tenant":"evil"}`,
+			expectedBody: `{"title":"Hi","owner_id":0,"status":"draft","author":{"name":"Ann","karma":0,"country":""},"tags":[{"name":"go","score":0}],"Tenant":"acme"}`,
+		},
+		{
+			name:         "listed fields",
+			opts:         []Option{WithBindableFields("title", "author.name", "tags")},
+			body:         `{"title":"Hi","status":"published","author":{"name":"Ann","country":"NZ"},"tags":[{"name":"go","score":5}]}`,
+			expectedBody: `{"title":"Hi","owner_id":0,"status":"draft","author":{"name":"Ann","karma":0,"country":""},"tags":[{"name":"go","score":0}],"Tenant":"acme"}`,
+		},
+		{
+			name:         "nothing removed",
+			opts:         []Option{WithBindableFields("title", "status")},
+			body:         `{"title":"Hi","status":"published"}`,
+			expectedBody: `{"title":"Hi","owner_id":0,"status":"published","author":{"name":"","karma":0,"country":""},"tags":null,"Tenant":"acme"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+			router := gin.New()
+			router.POST("/posts", builder.MustFormBindingGinHandlerFunc(func(req bindableRequest) (any, error) {
+				return req, nil
+			}, tt.opts...))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/posts", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant", "acme")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestBindableFieldsOtherBodies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.POST("/posts", builder.MustFormBindingGinHandlerFunc(func(req bindableRequest) (any, error) {
+		return req, nil
+	}))
+	router.POST("/import", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, posts Stream[bindableRequest]) ([]bindableRequest, error) {
+		var imported []bindableRequest
+		for post, err := range posts.All() {
+			if err != nil {
+				return nil, err
+			}
+			imported = append(imported, post)
+		}
+		return imported, nil
+	}))
+
+	tests := []struct {
+		name         string
+		path         string
+		contentType  string
+		body         string
+		expectedBody string
+	}{
+		{
+			name:         "form",
+			path:         "/posts",
+			contentType:  "application/x-www-form-urlencoded",
+			body:         "Title=Hi&OwnerID=7&owner_id=7&Tenant=evil",
+			expectedBody: `{"title":"Hi","owner_id":0,"status":"draft","author":{"name":"","karma":0,"country":""},"tags":null,"Tenant":"acme"}`,
+		},
+		{
+			name:         "xml",
+			path:         "/posts",
+			contentType:  "application/xml",
+			body:         `<post><Title>Hi</Title><OwnerID>7</OwnerID><Author><Name>Ann</Name><Karma>99</Karma></Author><Tags><Name>go</Name><Score>5</Score></Tags><Tenant>evil</Tenant></post>`,
+			expectedBody: `{"title":"Hi","owner_id":0,"status":"draft","author":{"name":"Ann","karma":0,"country":""},"tags":[{"name":"go","score":0}],"Tenant":"acme"}`,
+		},
+		{
+			name:         "ndjson items",
+			path:         "/import",
+			contentType:  MIMENDJSON,
+			body:         "{\"title\":\"Hi\",\"owner_id\":7,\"author\":{\"karma\":99},\"Tenant\":\"evil\"}\n",
+			expectedBody: `[{"title":"Hi","owner_id":0,"status":"draft","author":{"name":"","karma":0,"country":""},"tags":null,"Tenant":""}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("X-Tenant", "acme")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package ginbinding
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/textproto"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +20,7 @@ import (
 var (
 	ginCtxTy   = reflect.TypeOf(gin.Context{})
 	errTy      = reflect.TypeOf((*error)(nil)).Elem()
+	stdCtxTy   = reflect.TypeOf((*context.Context)(nil)).Elem()
 	strTy      = reflect.TypeOf("")
 	timeTy     = reflect.TypeOf(time.Time{})
 	durationTy = reflect.TypeOf(time.Duration(0))
@@ -23,22 +29,160 @@ var (
 // BasicFormBindingGinHandlerBuilder is the basic implementation of FormBindingGinHandlerBuilder
 // that supports validation and customizable response handling.
 type BasicFormBindingGinHandlerBuilder struct {
-	validator       binding.StructValidator
-	responseHandler ResponseHandler
+	validator         binding.StructValidator
+	responseHandler   ResponseHandler
+	timeLocation      *time.Location
+	sortFields        []string
+	filterFields      []string
+	defaultPageSize   int
+	maxPageSize       int
+	timeout           time.Duration
+	maxConcurrency    int
+	etagFunc          func(data any) string
+	hostResolver      HostResolver
+	debugWriter       io.Writer
+	deprecationLog    *slog.Logger
+	providers         []reflect.Value
+	successHooks      []SuccessHook
+	errorHooks        []ErrorHook
+	abortHooks        []ClientAbortHook
+	preBindHooks      []func(*gin.Context) error
+	defaultTag        string
+	discriminators    map[reflect.Type]map[string]reflect.Type
+	useNumber         bool
+	maxUploadSize     int64
+	boolValues        map[string]bool
+	redactErrors      bool
+	ginErrors         bool
+	partialValidation bool
+	pipeline          *Pipeline
+	optionalBody      bool
+	strictSchema      bool
+	validationStatus  int
+	// allowedContentTypes is nil when any content type is accepted
+	allowedContentTypes []string
+	// cors is nil when Router adds no CORS headers
+	cors *CORSConfig
+	// streamFlushEvery is 0 for DefaultStreamFlushEvery
+	streamFlushEvery int
+	// sparseFields is nil when responses cannot be pruned with ?fields=
+	sparseFields []string
+	nullPolicy   NullPolicy
+	// cache is nil when responses are not cached
+	cache *responseCache
+	// rateLimit is nil when requests are not rate limited
+	rateLimit *rateLimit
+	// apiKeyAuth is nil when no API key is required
+	apiKeyAuth *apiKeyAuth
+	// csrf is nil when CSRF tokens are not checked
+	csrf *csrfCheck
+	// apiVersion is nil when requests are bound regardless of their version
+	apiVersion *apiVersioning
+	// mirror is nil when requests are not mirrored
+	mirror *mirror
+	// captureDir is empty when requests are not captured
+	captureDir string
+	// roleResolver is nil when requires_role tags cannot be checked
+	roleResolver RoleResolver
+	rolePolicy   RolePolicy
+	// bindableFields is nil when clients may set every body field
+	bindableFields []string
+	// sanitizers holds the sanitize policies registered with WithSanitizer
+	sanitizers map[string]Sanitizer
+	// normalizeUnicode is set by WithUnicodeNormalization
+	normalizeUnicode bool
+	// jsonLimits is nil when JSON bodies are not limited
+	jsonLimits *JSONLimits
+	// rejectDuplicateKeys is set by WithDuplicateKeyRejection
+	rejectDuplicateKeys bool
+	// queryLimits is nil when query strings are not limited
+	queryLimits    *QueryLimits
+	paginationMode PaginationMode
+	// compression is nil when responses are not compressed
+	compression *compression
+	// responseBudget is nil when success data is not limited
+	responseBudget *responseBudget
+	// noContent is set by WithNoContent
+	noContent bool
+	// rawContentType is empty unless WithRawResponse is set
+	rawContentType string
+	// lint is set by WithSchemaLint, which accepts the tag keys lintTagKeys
+	// in addition to the known ones
+	lint        bool
+	lintTagKeys []string
+	// routes is nil for builders not created by
+	// NewBasicFormBindingGinHandlerBuilder, which track no routes
+	routes *routeTable
 }
 
-// NewBasicFormBindingGinHandlerBuilder creates a new builder with optional validator and response handler
+// NewBasicFormBindingGinHandlerBuilder creates a new builder with optional validator and response handler.
+// Additional behaviour can be configured with options such as WithTimeLocation.
 func NewBasicFormBindingGinHandlerBuilder(
 	validator binding.StructValidator,
 	responseHandler ResponseHandler,
+	opts ...Option,
 ) *BasicFormBindingGinHandlerBuilder {
 	if responseHandler == nil {
 		responseHandler = NewDefaultResponseHandler()
 	}
-	return &BasicFormBindingGinHandlerBuilder{
+	builder := &BasicFormBindingGinHandlerBuilder{
 		validator:       validator,
 		responseHandler: responseHandler,
+		timeLocation:    time.UTC,
+		defaultPageSize: DefaultPageSize,
+		maxPageSize:     DefaultMaxPageSize,
+		defaultTag:      "default",
+		routes:          &routeTable{},
 	}
+	for _, opt := range opts {
+		opt(builder)
+	}
+	return builder
+}
+
+// contextParam tells what the first handler parameter receives
+type contextParam int
+
+const (
+	contextGin contextParam = iota
+	contextStd
+	contextNone
+)
+
+// value returns the first argument for a handler called for ctx, whose
+// request context is reqCtx. The context is passed by its dynamic type, which
+// Call converts, so that reqCtx is not moved to the heap.
+func (p contextParam) value(ctx *gin.Context, reqCtx context.Context) reflect.Value {
+	if p == contextStd {
+		return reflect.ValueOf(reqCtx)
+	}
+	return reflect.ValueOf(ctx)
+}
+
+// Clone returns a copy of the builder with opts applied, so that builders of
+// different modules can be derived from a base configuration, e.g. with
+// another response handler or validator. Configuring the copy, including with
+// OnSuccess, OnError, OnClientAbort and Provide, never affects the builder it
+// was cloned from and vice versa, and cloning is safe while handlers of the
+// original are serving requests. The copy starts without routes. The response
+// cache and rate limiter configured on the original, if any, are shared with
+// the copy.
+func (builder *BasicFormBindingGinHandlerBuilder) Clone(opts ...Option) *BasicFormBindingGinHandlerBuilder {
+	b := *builder
+	// Clip the slices so that appending to either builder reallocates rather
+	// than writing to the array they share
+	b.providers = slices.Clip(b.providers)
+	b.successHooks = slices.Clip(b.successHooks)
+	b.errorHooks = slices.Clip(b.errorHooks)
+	b.abortHooks = slices.Clip(b.abortHooks)
+	b.preBindHooks = slices.Clip(b.preBindHooks)
+	if b.routes != nil {
+		b.routes = &routeTable{}
+	}
+	for _, opt := range opts {
+		opt(&b)
+	}
+	return &b
 }
 
 // FormBindingGinHandlerFunc converts a function to a gin.HandlerFunc
@@ -46,125 +190,469 @@ func NewBasicFormBindingGinHandlerBuilder(
 //  1. func(*gin.Context, any struct) error
 //  2. func(*gin.Context, any struct) (any, error)
 //  3. func(*gin.Context) (any, error)
+//
+// The *gin.Context parameter can be replaced by a context.Context, which
+// receives the request context, to keep business logic free of gin, or be
+// omitted altogether, e.g. func(Req) (any, error). The data return value can
+// be a concrete type such as func(*gin.Context, Req) (Resp, error); Describe
+// reports it as the handler's response type.
+//
+// The request can be split into two structs, func(*gin.Context, Query, Body),
+// where the first binds every source except the body and the second binds
+// only the body.
+//
+// A Stream parameter in place of the request or body struct reads an
+// application/x-ndjson body item by item.
+//
+// Services registered with Provide can be declared as additional parameters
+// after the request struct, e.g. func(*gin.Context, Req, UserService) (any, error).
+//
+// Options override the builder configuration for this handler only.
 func (builder *BasicFormBindingGinHandlerBuilder) FormBindingGinHandlerFunc(
 	i any,
+	opts ...Option,
 ) (gin.HandlerFunc, error) {
-	ity := reflect.TypeOf(i)
-
-	if ity.Kind() != reflect.Func {
-		return nil, errors.New("input must be a function")
+	if len(opts) > 0 {
+		builder = builder.Clone(opts...)
 	}
 
-	// Check parameter and return value counts
-	inNum := ity.NumIn()
-	outNum := ity.NumOut()
-
-	if inNum == 0 {
-		return nil, errors.New("function must have at least one parameter")
+	sig, err := builder.analyzeHandler(i)
+	if err != nil {
+		return nil, err
 	}
 
-	if inNum > 2 {
-		return nil, errors.New("function can have at most 2 parameters")
+	if builder.rawContentType != "" {
+		if err := checkRawResponseType(sig.metadata().ResponseType); err != nil {
+			return nil, err
+		}
 	}
-
-	if outNum == 0 {
-		return nil, errors.New("function must have at least one return value")
+	// the items of a Stream are read by the handler function, too late to
+	// be part of the cache key
+	if builder.cache != nil && sig.stream {
+		return nil, fmt.Errorf("WithCache cannot cache handlers reading a Stream")
 	}
 
-	if outNum > 2 {
-		return nil, errors.New("function can have at most 2 return values")
+	// responseType is the declared response type data is checked against,
+	// or nil
+	var responseType reflect.Type
+	if builder.strictSchema && gin.IsDebugging() {
+		if ty := sig.metadata().ResponseType; ty != nil && ty.Kind() != reflect.Interface {
+			responseType = ty
+		}
 	}
 
-	// Check first parameter is *gin.Context
-	in0Ty := ity.In(0)
-	if in0Ty.Kind() != reflect.Pointer || in0Ty.Elem() != ginCtxTy {
-		return nil, errors.New("first parameter must be *gin.Context")
-	}
+	limiter := newConcurrencyLimiter(builder.maxConcurrency)
+	pooledReq := sig.reqType != nil && builder.isPooledRequest(sig.reqType)
+	pooledBody := sig.bodyType != nil && !sig.stream && builder.isPooledRequest(sig.bodyType)
+
+	return func(ctx *gin.Context) {
+		// req is the bound request, passed to the OnSuccess and OnError hooks
+		var req reflect.Value
+		// bodyVal is the bound body of handlers with a separate body struct,
+		// but not the Stream of streaming handlers, read by the handler
+		var bodyVal reflect.Value
+		// running is closed when a handler function that outlived its
+		// timeout returns, which is when what it uses can be released
+		var running <-chan struct{}
 
-	// If function has second parameter, it must be a struct or pointer to struct
-	if inNum == 2 {
-		in1Ty := ity.In(1)
-		if in1Ty.Kind() != reflect.Struct &&
-			(in1Ty.Kind() != reflect.Pointer || in1Ty.Elem().Kind() != reflect.Struct) {
-			return nil, errors.New("second parameter must be a struct or pointer to struct")
+		if limiter != nil {
+			if err := limiter.acquire(ctx); err != nil {
+				builder.handleError(ctx, req, err)
+				return
+			}
+			defer func() { limiter.releaseAfter(running) }()
 		}
-	}
 
-	// Check return value types
-	if outNum == 1 {
-		out0Ty := ity.Out(0)
-		if !out0Ty.Implements(errTy) {
-			return nil, errors.New("single return value must be error")
+		// body tells client aborts apart from malformed bodies
+		body := trackBody(ctx)
+
+		for _, hook := range builder.preBindHooks {
+			if err := hook(ctx); err != nil {
+				builder.handleError(ctx, req, body.abortError(ctx, err))
+				return
+			}
 		}
-	}
 
-	if outNum == 2 {
-		out1Ty := ity.Out(1)
-		if !out1Ty.Implements(errTy) {
-			return nil, errors.New("second return value must be error")
+		if builder.apiKeyAuth != nil {
+			if err := builder.apiKeyAuth.authenticate(ctx); err != nil {
+				builder.handleError(ctx, req, err)
+				return
+			}
+		}
+
+		if builder.csrf != nil {
+			if err := builder.csrf.verify(ctx); err != nil {
+				builder.handleError(ctx, req, err)
+				return
+			}
 		}
-	}
 
-	funcVal := reflect.ValueOf(i)
+		if err := builder.checkContentType(ctx); err != nil {
+			builder.handleError(ctx, req, err)
+			return
+		}
 
-	return func(ctx *gin.Context) {
-		in := make([]reflect.Value, 0, 2)
-		in = append(in, reflect.ValueOf(ctx))
+		if err := builder.checkQueryLimits(ctx); err != nil {
+			builder.handleError(ctx, req, err)
+			return
+		}
 
-		if inNum == 2 {
-			form, err := bindingFormValue(ctx, ity.In(1))
+		fields, err := builder.sparseFieldset(ctx)
+		if err != nil {
+			builder.handleError(ctx, req, err)
+			return
+		}
+
+		var in []reflect.Value
+		if sig.direct == nil {
+			args := sig.newArgs(ctx)
+			defer sig.releaseArgs(args)
+			in = args.in
+		}
+
+		if sig.reqIndex >= 0 {
+			scope := bindAll
+			if sig.bodyIndex >= 0 {
+				scope = bindInputs
+			}
+			form, err := builder.bindParam(ctx, sig.reqType, scope)
+			if pooledReq {
+				defer func() { builder.releaseRequestAfter(form, running) }()
+			}
 			if err != nil {
-				builder.responseHandler.HandleError(ctx, &BindingError{Err: err})
+				builder.handleError(ctx, req, body.abortError(ctx, err))
 				return
 			}
+			in[sig.reqIndex] = form
+			req = form
+		}
 
-			if builder.validator != nil {
-				if err := builder.validator.ValidateStruct(form.Interface()); err != nil {
-					builder.responseHandler.HandleError(ctx, err)
-					return
+		if sig.bodyIndex >= 0 {
+			var err error
+			if sig.stream {
+				in[sig.bodyIndex], err = builder.bindStream(ctx, sig.bodyType)
+			} else {
+				bodyVal, err = builder.bindParam(ctx, sig.bodyType, bindBody)
+				if pooledBody {
+					defer func() { builder.releaseRequestAfter(bodyVal, running) }()
 				}
+				in[sig.bodyIndex] = bodyVal
 			}
+			if err != nil {
+				builder.handleError(ctx, req, body.abortError(ctx, err))
+				return
+			}
+		}
 
-			in = append(in, form)
+		if builder.rateLimit != nil {
+			if err := builder.rateLimit.check(ctx, hookRequest(req)); err != nil {
+				builder.handleError(ctx, req, err)
+				return
+			}
 		}
 
-		out := funcVal.Call(in)
+		if builder.mirror != nil {
+			builder.mirror.forward(ctx, req, bodyVal)
+		}
+		if builder.captureDir != "" && req.IsValid() {
+			if err := capture(ctx, builder.captureDir, req, bodyVal); err != nil {
+				_ = ctx.Error(err)
+			}
+		}
 
-		if outNum == 1 {
-			err := out[0].Interface()
-			if err != nil {
-				builder.responseHandler.HandleError(ctx, err.(error))
+		// the compressing writer goes beneath the cache, which stores and
+		// replays uncompressed responses
+		if builder.compression != nil {
+			if w := builder.compression.wrap(ctx); w != nil {
+				defer w.finish(ctx)
+			}
+		}
+		if builder.cache != nil {
+			served, store := builder.cache.serveCached(ctx, req, bodyVal)
+			if served {
 				return
 			}
-			builder.responseHandler.HandleSuccess(ctx, nil)
-			return
+			if store != nil {
+				defer store()
+			}
 		}
 
-		err := out[1].Interface()
+		data, hasData, running, err := builder.invoke(ctx, sig, in)
 		if err != nil {
-			builder.responseHandler.HandleError(ctx, err.(error))
+			builder.handleError(ctx, req, err)
+			return
+		}
+		if !hasData {
+			builder.handleSuccess(ctx, req, nil, nil)
 			return
 		}
 
-		builder.responseHandler.HandleSuccess(ctx, out[0].Interface())
+		// headers returned WithHeaders are set once the data is certain to
+		// be served
+		data, headers := splitHeaders(data)
+		if builder.rawContentType != "" && isRawData(data) {
+			setHeaders(ctx, headers)
+			builder.handleSuccess(ctx, req, data, data)
+			return
+		}
+		if builder.paginationMode != PaginationEnvelope {
+			data, headers = builder.paginationHeaders(ctx, data, headers)
+		}
+
+		if responseType != nil {
+			if err := checkResponseSchema(responseType, data); err != nil {
+				builder.handleError(ctx, req, err)
+				return
+			}
+		}
+
+		if data, err = builder.applyNullPolicy(data); err != nil {
+			builder.handleError(ctx, req, err)
+			return
+		}
+		if data, err = fields.prune(data); err != nil {
+			builder.handleError(ctx, req, err)
+			return
+		}
+		served := data
+		if builder.responseBudget != nil {
+			if served, err = builder.responseBudget.fitBudget(data); err != nil {
+				builder.handleError(ctx, req, err)
+				return
+			}
+		}
+		setHeaders(ctx, headers)
+		builder.handleSuccess(ctx, req, served, data)
 	}, nil
 }
 
-func bindingFormValue(ctx *gin.Context, ty reflect.Type) (reflect.Value, error) {
+// bindParam binds a request parameter of type ty and runs it through the
+// stages of the pipeline up to the handler. Binding failures are wrapped in a
+// BindingError.
+func (builder *BasicFormBindingGinHandlerBuilder) bindParam(ctx *gin.Context, ty reflect.Type, scope bindScope) (reflect.Value, error) {
+	// Map requests have no struct tags to apply or validate
+	if ty == mapAnyTy {
+		m, err := bindMap(ctx, builder.useNumber)
+		if err != nil {
+			return reflect.Value{}, builder.bindingError(ty, &inputError{source: SourceBody, err: err})
+		}
+		return reflect.ValueOf(m), nil
+	}
+
+	// bindTy is ty, or a struct type with the tags of the requested API
+	// version, which converts to ty
+	bindTy := ty
+	if builder.apiVersion != nil {
+		if version := builder.apiVersion.resolver(ctx); version != "" {
+			if form, ok, err := builder.bindVersioned(ctx, version, ty, scope); ok {
+				return form, err
+			}
+			bindTy = versionedBindType(ty, version)
+		}
+	}
+
+	structTy := bindTy
+	if structTy.Kind() == reflect.Pointer {
+		structTy = structTy.Elem()
+	}
+
+	// sent tracks the inputs of requests to types with deprecated or role
+	// guarded fields, and present those of partially validated requests
+	var present, sent *requestPresence
+	if info := typeInfoOf(structTy); builder.partialValidation || info.deprecated != nil || info.roleFields != nil {
+		var err error
+		if sent, err = newRequestPresence(ctx); err != nil {
+			return reflect.Value{}, builder.bindingError(ty, &inputError{source: SourceBody, err: err})
+		}
+		if builder.partialValidation {
+			present = sent
+		}
+	}
+
+	form, err := builder.bindingFormValue(ctx, bindTy, scope)
+	if bindTy != ty {
+		if err != nil {
+			return reflect.Value{}, builder.bindingError(ty, err)
+		}
+		form = adoptRequest(form, ty)
+	}
+	if err != nil {
+		return form, builder.bindingError(ty, err)
+	}
+
+	if sent != nil {
+		builder.warnDeprecated(ctx, structTy, sent)
+		if err := builder.checkRoles(ctx, structTy, form, sent); err != nil {
+			return form, err
+		}
+	}
+
+	if builder.debugWriter != nil {
+		val := reflect.Indirect(form)
+		bound := reflect.New(val.Type()).Elem()
+		bound.Set(val)
+		defer func() { builder.debugBinding(ctx, bound, val) }()
+	}
+
+	if err := builder.runStages(ctx, ty, form, present); err != nil {
+		return form, err
+	}
+
+	return form, nil
+}
+
+// clientError prepares the binding or validation error err of a request of
+// type ty for the error response, applying errmsg tags and redaction
+func (builder *BasicFormBindingGinHandlerBuilder) clientError(ty reflect.Type, err error) error {
+	err = withErrorMessage(ty, err)
+	if builder.redactErrors {
+		err = redactError(ty, err)
+	}
+	return err
+}
+
+// bindScope selects the request sources bindingFormValue binds from
+type bindScope int
+
+const (
+	// bindAll binds every source into a single request struct
+	bindAll bindScope = iota
+	// bindInputs binds path, query, header and connection sources only
+	bindInputs
+	// bindBody binds the request body only
+	bindBody
+)
+
+// bindingFormValue binds a value of type ty from the sources selected by scope.
+// It only decodes the request: validation is left to the validate stage.
+func (builder *BasicFormBindingGinHandlerBuilder) bindingFormValue(ctx *gin.Context, ty reflect.Type, scope bindScope) (reflect.Value, error) {
 	if ty.Kind() == reflect.Pointer {
-		val, err := bindingFormValue(ctx, ty.Elem())
+		val, err := builder.bindingFormValue(ctx, ty.Elem(), scope)
 		if err != nil {
 			return reflect.Value{}, err
 		}
-		ret := reflect.New(ty.Elem())
-		ret.Elem().Set(val)
-		return ret, nil
+		// The struct is the addressable element of a new or pooled pointer
+		return val.Addr(), nil
+	}
+
+	val := newRequest(ty)
+
+	if scope != bindBody && typeInfoOf(ty).rewriteQuery {
+		restore, err := builder.rewriteQuery(ctx, ty)
+		if err != nil {
+			return val.Elem(), err
+		}
+		defer restore()
+	}
+
+	if scope != bindBody {
+		if err := builder.bindInputs(ctx, val); err != nil {
+			return val.Elem(), err
+		}
 	}
 
-	val := reflect.New(ty)
+	if scope != bindInputs {
+		// Unbindable fields keep the values bound from inputs, whatever the
+		// body sets
+		var unbound reflect.Value
+		if typeInfoOf(ty).unbindable != nil {
+			unbound = reflect.New(ty).Elem()
+			unbound.Set(val.Elem())
+		}
+
+		if builder.jsonLimits != nil || builder.rejectDuplicateKeys {
+			if err := checkJSONBody(ctx, ty, builder.jsonLimits, builder.rejectDuplicateKeys); err != nil {
+				return val.Elem(), err
+			}
+		}
+
+		if builder.bindableFields != nil || typeInfoOf(ty).unbindable != nil {
+			if err := builder.stripUnbindableJSON(ctx, ty); err != nil {
+				return val.Elem(), &inputError{source: SourceBody, err: err}
+			}
+		}
+
+		if builder.strictSchema {
+			if err := checkBodySchema(ctx, ty); err != nil {
+				return val.Elem(), err
+			}
+		}
+
+		if unixTimes := typeInfoOf(ty).unixTimes; unixTimes != nil {
+			if err := rewriteUnixTimeJSON(ctx, unixTimes); err != nil {
+				return val.Elem(), &inputError{source: SourceBody, err: err}
+			}
+		}
+
+		if typeInfoOf(ty).bigNumbers {
+			if err := rewriteBigNumberJSON(ctx, ty); err != nil {
+				return val.Elem(), &inputError{source: SourceBody, err: err}
+			}
+		}
+
+		var discriminated []discriminatedField
+		if typeInfoOf(ty).discriminated {
+			var err error
+			if discriminated, err = builder.prepareDiscriminated(ctx, val); err != nil {
+				return val.Elem(), err
+			}
+		}
+
+		// Gin validates the request once it is decoded, which the validate
+		// stage repeats after defaults have been applied
+		if err := builder.shouldBind(ctx, val.Interface()); err != nil && !isValidationErrors(err) && !builder.isMissingBody(ctx, err) {
+			return val.Elem(), &inputError{source: SourceBody, err: err}
+		}
+		finishDiscriminated(val, discriminated)
+
+		if typeInfoOf(ty).multipartParts {
+			if err := builder.bindMultipartParts(ctx, val); err != nil {
+				return val.Elem(), err
+			}
+		}
+
+		// Gin parses the form of form bodies and of requests without a body
+		if typeInfoOf(ty).nestedForm && ctx.Request.Form != nil {
+			if err := builder.bindNestedForm(ctx.Request.Form, ctx.Request.PostForm, val, "form"); err != nil {
+				return val.Elem(), err
+			}
+		}
+
+		if unbound.IsValid() {
+			resetUnbindable(val.Elem(), unbound, typeInfoOf(ty).unbindable)
+		}
+	}
+
+	if scope != bindInputs && typeInfoOf(ty).trailers {
+		if err := builder.bindTrailers(ctx, val); err != nil {
+			return val.Elem(), err
+		}
+	}
+
+	if typeInfoOf(ty).contextValues {
+		bindContextValues(ctx, val)
+	}
+
+	return val.Elem(), nil
+}
+
+// bindInputs binds the path, query, header, pseudo-header, host and TLS sources of the struct
+// pointed to by val
+func (builder *BasicFormBindingGinHandlerBuilder) bindInputs(ctx *gin.Context, val reflect.Value) error {
+	ty := val.Type().Elem()
+
+	if err := builder.checkRequiredInputs(ctx, ty); err != nil {
+		return err
+	}
 
 	headerTagsNum := 0
 	formTagsNum := 0
+	queryTagsNum := 0
+
+	var (
+		host *hostParts
+		err  error
+	)
 
 	for i := 0; i < ty.NumField(); i++ {
 		sf := ty.Field(i)
@@ -173,58 +661,195 @@ func bindingFormValue(ctx *gin.Context, ty reflect.Type) (reflect.Value, error)
 			continue
 		}
 
-		if pathKey, ok := sf.Tag.Lookup("path"); ok {
-			sfv, err := stringToVal(ctx.Param(pathKey), sf.Type)
+		if pathKey, ok := pathTag(sf); ok {
+			sfv, err := builder.stringToVal(ctx.Param(pathKey), sf.Type, sf.Tag)
+			if err != nil {
+				return &fieldError{path: sf.Name, err: fmt.Errorf("failed to parse path parameter %q: %w", pathKey, err), value: ctx.Param(pathKey)}
+			}
+			val.Elem().Field(i).Set(sfv)
+		}
+
+		if hostPart, ok := sf.Tag.Lookup("host"); ok {
+			if host == nil {
+				if host, err = builder.resolveHost(ctx.Request.Host); err != nil {
+					return &inputError{source: SourceHost, err: err}
+				}
+			}
+			sfv, err := builder.stringToVal(host.part(hostPart), sf.Type, sf.Tag)
+			if err != nil {
+				return &fieldError{path: sf.Name, err: fmt.Errorf("failed to parse host %s: %w", hostPart, err), value: host.part(hostPart)}
+			}
+			val.Elem().Field(i).Set(sfv)
+		}
+
+		if tlsKey, ok := sf.Tag.Lookup("tls"); ok {
+			sfv, err := peerCertificateValue(ctx.Request.TLS, tlsKey)
+			if err != nil {
+				return &fieldError{path: sf.Name, err: fmt.Errorf("field %s: %w", sf.Name, err)}
+			}
+			val.Elem().Field(i).Set(sfv)
+		}
+
+		if _, ok := sf.Tag.Lookup("ifmatch"); ok {
+			val.Elem().Field(i).SetString(ctx.GetHeader("If-Match"))
+		}
+
+		if pseudoKey, ok := sf.Tag.Lookup("pseudo"); ok {
+			value, _ := pseudoValue(ctx, pseudoKey)
+			sfv, err := builder.stringToVal(value, sf.Type, sf.Tag)
 			if err != nil {
-				return val.Elem(), fmt.Errorf("failed to parse path parameter %q: %w", pathKey, err)
+				return &fieldError{path: sf.Name, err: fmt.Errorf("failed to parse pseudo-header %s: %w", pseudoKey, err), value: value}
 			}
 			val.Elem().Field(i).Set(sfv)
 		}
 
-		if _, ok := sf.Tag.Lookup("header"); ok {
+		if headerKey, ok := sf.Tag.Lookup("header"); ok {
 			headerTagsNum += 1
+
+			// Wildcard header tags like "X-Meta-*" collect every matching header into a map
+			if prefix, isWildcard := strings.CutSuffix(headerKey, "*"); isWildcard {
+				sfv, err := headerPrefixToVal(ctx.Request.Header, prefix, sf.Type)
+				if err != nil {
+					return &fieldError{path: sf.Name, err: fmt.Errorf("failed to bind header %q: %w", headerKey, err)}
+				}
+				val.Elem().Field(i).Set(sfv)
+			}
 		}
 
 		if _, ok := sf.Tag.Lookup("form"); ok {
 			formTagsNum += 1
 		}
+
+		if _, ok := sf.Tag.Lookup("query"); ok {
+			queryTagsNum += 1
+		}
 	}
 
+	// Inputs are mapped without validation, which runs once the whole struct
+	// is bound
 	if formTagsNum > 0 {
-		if err := ctx.BindQuery(val.Interface()); err != nil {
-			return val.Elem(), err
+		if err := binding.MapFormWithTag(val.Interface(), ctx.Request.URL.Query(), "form"); err != nil {
+			return &inputError{source: SourceQuery, err: err}
+		}
+	}
+
+	if queryTagsNum > 0 {
+		if err := binding.MapFormWithTag(val.Interface(), ctx.Request.URL.Query(), "query"); err != nil {
+			return &inputError{source: SourceQuery, err: err}
+		}
+	}
+
+	if (formTagsNum > 0 || queryTagsNum > 0) && typeInfoOf(ty).nestedForm {
+		query := ctx.Request.URL.Query()
+		if formTagsNum > 0 {
+			if err := builder.bindNestedForm(query, nil, val, "form"); err != nil {
+				return err
+			}
+		}
+		if queryTagsNum > 0 {
+			if err := builder.bindNestedForm(query, nil, val, "query"); err != nil {
+				return err
+			}
 		}
 	}
 
 	if headerTagsNum > 0 {
-		if err := ctx.ShouldBindHeader(val.Interface()); err != nil {
-			return val.Elem(), err
+		if err := binding.MapFormWithTag(val.Interface(), headerValues(ctx.Request.Header, ty), "header"); err != nil {
+			return &inputError{source: SourceHeader, err: err}
 		}
 	}
 
-	err := ctx.ShouldBind(val.Interface())
+	return nil
+}
 
-	// Apply default values for zero-valued fields
-	if err == nil {
-		if defaultErr := applyDefaultValues(val.Elem()); defaultErr != nil {
-			return val.Elem(), defaultErr
+// headerValues returns the values of the headers named by the header tags of
+// ty, keyed by the tag, the way gin's header binding looks them up. Header
+// names are matched case-insensitively.
+func headerValues(header http.Header, ty reflect.Type) map[string][]string {
+	headers := typeInfoOf(ty).headers
+	values := make(map[string][]string, len(headers))
+	for _, h := range headers {
+		if v := headerValuesFold(header, h.name); len(v) > 0 {
+			values[h.key] = v
 		}
 	}
+	return values
+}
+
+// headerPrefixToVal collects all headers whose name starts with prefix into a
+// map[string]string or map[string][]string keyed by the canonical header name
+func headerPrefixToVal(header http.Header, prefix string, ty reflect.Type) (reflect.Value, error) {
+	if ty.Kind() != reflect.Map || ty.Key().Kind() != reflect.String ||
+		(ty.Elem().Kind() != reflect.String &&
+			(ty.Elem().Kind() != reflect.Slice || ty.Elem().Elem().Kind() != reflect.String)) {
+		return reflect.Zero(ty), fmt.Errorf("wildcard header requires map[string]string or map[string][]string, got %s", ty)
+	}
+
+	prefix = textproto.CanonicalMIMEHeaderKey(prefix)
+	ret := reflect.MakeMap(ty)
 
-	return val.Elem(), err
+	for name, values := range header {
+		if len(values) == 0 || !strings.HasPrefix(textproto.CanonicalMIMEHeaderKey(name), prefix) {
+			continue
+		}
+
+		key := reflect.ValueOf(textproto.CanonicalMIMEHeaderKey(name)).Convert(ty.Key())
+		if ty.Elem().Kind() == reflect.String {
+			ret.SetMapIndex(key, reflect.ValueOf(values[0]).Convert(ty.Elem()))
+			continue
+		}
+
+		elem := reflect.MakeSlice(ty.Elem(), len(values), len(values))
+		for j, v := range values {
+			elem.Index(j).Set(reflect.ValueOf(v).Convert(ty.Elem().Elem()))
+		}
+		ret.SetMapIndex(key, elem)
+	}
+
+	return ret, nil
 }
 
-func stringToVal(s string, ty reflect.Type) (reflect.Value, error) {
+func (builder *BasicFormBindingGinHandlerBuilder) stringToVal(s string, ty reflect.Type, tag reflect.StructTag) (reflect.Value, error) {
 	if s == "" {
 		return reflect.Zero(ty), nil
 	}
 
+	if ty.Kind() == reflect.Pointer {
+		elem, err := builder.stringToVal(s, ty.Elem(), tag)
+		if err != nil {
+			return reflect.Zero(ty), err
+		}
+		ptr := reflect.New(ty.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+
+	if unit, ok := tag.Lookup("unit"); ok {
+		var err error
+		if s, err = parseUnit(s, unit); err != nil {
+			return reflect.Zero(ty), err
+		}
+	} else if locale, ok := tag.Lookup("numfmt"); ok {
+		var err error
+		if s, err = parseLocaleNumber(s, locale); err != nil {
+			return reflect.Zero(ty), err
+		}
+	}
+
 	if strTy.ConvertibleTo(ty) {
 		return reflect.ValueOf(s).Convert(ty), nil
 	}
 
 	ret := reflect.New(ty)
 
+	// Types implementing binding.BindUnmarshaler parse themselves
+	if u, ok := ret.Interface().(binding.BindUnmarshaler); ok {
+		if err := u.UnmarshalParam(s); err != nil {
+			return reflect.Zero(ty), err
+		}
+		return ret.Elem(), nil
+	}
+
 	switch ty.Kind() {
 	case reflect.String:
 		ret.Elem().Set(reflect.ValueOf(s))
@@ -250,7 +875,7 @@ func stringToVal(s string, ty reflect.Type) (reflect.Value, error) {
 		}
 		ret.Elem().SetUint(i)
 	case reflect.Bool:
-		b, err := parseBool(s)
+		b, err := builder.parseBool(s)
 		if err != nil {
 			return reflect.Zero(ty), err
 		}
@@ -262,31 +887,17 @@ func stringToVal(s string, ty reflect.Type) (reflect.Value, error) {
 		}
 		ret.Elem().SetFloat(f)
 	default:
-		// Handle time.Time types
-		if ty == timeTy {
-			// Try multiple time formats
-			timeFormats := []string{
-				time.RFC3339,
-				time.RFC3339Nano,
-				"2006-01-02 15:04:05",
-				"2006-01-02T15:04:05Z",
-				"2006-01-02T15:04:05.000Z",
-				"2006-01-02",
-				"15:04:05",
-			}
-
-			var parsedTime time.Time
-			var parseErr error
-
-			for _, format := range timeFormats {
-				parsedTime, parseErr = time.Parse(format, s)
-				if parseErr == nil {
-					break
-				}
+		// Handle big numbers and time.Time types
+		if isBigNumber(ty) {
+			n, err := parseBigNumber(s, ty)
+			if err != nil {
+				return reflect.Zero(ty), err
 			}
-
-			if parseErr != nil {
-				return reflect.Zero(ty), fmt.Errorf("invalid time format %q: %w", s, parseErr)
+			ret.Elem().Set(n)
+		} else if ty == timeTy {
+			parsedTime, err := parseTime(s, tag.Get("time_format"), builder.timeLocation)
+			if err != nil {
+				return reflect.Zero(ty), err
 			}
 
 			ret.Elem().Set(reflect.ValueOf(parsedTime))
@@ -298,8 +909,9 @@ func stringToVal(s string, ty reflect.Type) (reflect.Value, error) {
 	return ret.Elem(), nil
 }
 
-// applyDefaultValues applies default values to zero-valued fields that have a "default" tag
-func applyDefaultValues(val reflect.Value) error {
+// applyDefaultValues applies default values to zero-valued fields that have a
+// "default" tag, or the tag configured with WithDefaultTag
+func (builder *BasicFormBindingGinHandlerBuilder) applyDefaultValues(val reflect.Value) error {
 	ty := val.Type()
 
 	for i := 0; i < ty.NumField(); i++ {
@@ -324,14 +936,14 @@ func applyDefaultValues(val reflect.Value) error {
 			}
 
 			// Recursively process embedded struct fields
-			if err := applyDefaultValues(fieldVal); err != nil {
+			if err := builder.applyDefaultValues(fieldVal); err != nil {
 				return fmt.Errorf("embedded struct %s: %w", sf.Name, err)
 			}
 			continue
 		}
 
 		// Handle default values for regular fields
-		defaultValue, hasDefault := sf.Tag.Lookup("default")
+		defaultValue, hasDefault := sf.Tag.Lookup(builder.defaultTag)
 		if !hasDefault {
 			continue
 		}
@@ -343,8 +955,8 @@ func applyDefaultValues(val reflect.Value) error {
 		}
 
 		// Convert and set default value based on field type
-		if err := setDefaultValue(fieldVal, defaultValue, sf.Name); err != nil {
-			return fmt.Errorf("field %s: %w", sf.Name, err)
+		if err := builder.setDefaultValue(fieldVal, defaultValue, sf); err != nil {
+			return &fieldError{path: sf.Name, err: fmt.Errorf("field %s: %w", sf.Name, err), source: SourceDefault, value: defaultValue}
 		}
 	}
 
@@ -352,7 +964,7 @@ func applyDefaultValues(val reflect.Value) error {
 }
 
 // setDefaultValue converts a string default value to the appropriate type and sets it
-func setDefaultValue(fieldVal reflect.Value, defaultValue string, fieldName string) error {
+func (builder *BasicFormBindingGinHandlerBuilder) setDefaultValue(fieldVal reflect.Value, defaultValue string, sf reflect.StructField) error {
 	// Handle pointer types
 	if fieldVal.Kind() == reflect.Ptr {
 		if fieldVal.IsNil() {
@@ -361,7 +973,7 @@ func setDefaultValue(fieldVal reflect.Value, defaultValue string, fieldName stri
 			newVal := reflect.New(elemType)
 
 			// Set the default value on the new instance
-			if err := setDefaultValue(newVal.Elem(), defaultValue, fieldName); err != nil {
+			if err := builder.setDefaultValue(newVal.Elem(), defaultValue, sf); err != nil {
 				return err
 			}
 
@@ -371,15 +983,67 @@ func setDefaultValue(fieldVal reflect.Value, defaultValue string, fieldName stri
 	}
 
 	// Use stringToVal to convert the default value to the field type
-	convertedVal, err := stringToVal(defaultValue, fieldVal.Type())
+	convertedVal, err := builder.stringToVal(defaultValue, fieldVal.Type(), sf.Tag)
 	if err != nil {
-		return fmt.Errorf("failed to convert default value %q for field %s: %w", defaultValue, fieldName, err)
+		return fmt.Errorf("failed to convert default value %q for field %s: %w", defaultValue, sf.Name, err)
 	}
 
 	fieldVal.Set(convertedVal)
 	return nil
 }
 
+// checkDefaults verifies at handler build time that the default values of
+// the request type ty parse for their fields, so that a typo such as
+// default:"thirty" on an int field fails at startup rather than every request
+func (builder *BasicFormBindingGinHandlerBuilder) checkDefaults(ty reflect.Type) error {
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	return walkTypeFields(ty, func(sf reflect.StructField) error {
+		value, ok := sf.Tag.Lookup(builder.defaultTag)
+		if !ok {
+			return nil
+		}
+		if err := builder.checkDefaultValue(value, sf); err != nil {
+			return fmt.Errorf("field %s: invalid default value %q: %w", sf.Name, value, err)
+		}
+		return nil
+	})
+}
+
+// checkDefaultValue converts the default value of sf like setDefaultValue
+// does, and also rejects numbers out of the range of the field type, which
+// the conversion would silently truncate
+func (builder *BasicFormBindingGinHandlerBuilder) checkDefaultValue(value string, sf reflect.StructField) error {
+	ty := sf.Type
+	for ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	if _, err := builder.stringToVal(value, ty, sf.Tag); err != nil {
+		return err
+	}
+	if ty == durationTy || value == "" {
+		return nil
+	}
+
+	field := reflect.New(ty).Elem()
+	switch ty.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, err := builder.stringToVal(value, reflect.TypeOf(int64(0)), sf.Tag); err == nil && field.OverflowInt(v.Int()) {
+			return fmt.Errorf("%d overflows %s", v.Int(), ty)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v, err := builder.stringToVal(value, reflect.TypeOf(uint64(0)), sf.Tag); err == nil && field.OverflowUint(v.Uint()) {
+			return fmt.Errorf("%d overflows %s", v.Uint(), ty)
+		}
+	case reflect.Float32:
+		if v, err := builder.stringToVal(value, reflect.TypeOf(float64(0)), sf.Tag); err == nil && field.OverflowFloat(v.Float()) {
+			return fmt.Errorf("%g overflows %s", v.Float(), ty)
+		}
+	}
+	return nil
+}
+
 // parseBool parses a string to boolean value
 func parseBool(s string) (bool, error) {
 	switch strings.ToLower(strings.TrimSpace(s)) {
@@ -388,6 +1052,6 @@ func parseBool(s string) (bool, error) {
 	case "false", "0", "no", "off":
 		return false, nil
 	default:
-		return false, fmt.Errorf("invalid boolean value: %s", s)
+		return false, fmt.Errorf("invalid boolean value %q", s)
 	}
 }
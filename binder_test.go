@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -86,7 +88,7 @@ func TestFormBindingGinHandlerFunc_InvalidSignatures(t *testing.T) {
 			handler: func(c *gin.Context, req struct{}, extra interface{}) error {
 				return nil
 			},
-			expected: "function can have at most 2 parameters",
+			expected: "parameter 3: no provider registered for interface {}",
 		},
 		{
 			name: "no return values",
@@ -291,3 +293,65 @@ func (h *testResponseHandler) HandleSuccess(ctx *gin.Context, data interface{})
 func (h *testResponseHandler) HandleError(ctx *gin.Context, err error) {
 	ctx.String(http.StatusBadRequest, "custom error: "+err.Error())
 }
+
+func TestClone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var calls []string
+	hook := func(name string) SuccessHook {
+		return func(ctx *gin.Context, req any, resp any) {
+			calls = append(calls, name)
+		}
+	}
+
+	// Three hooks leave spare capacity in the slice the clones start from
+	base := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	base.OnSuccess(hook("base1")).OnSuccess(hook("base2")).OnSuccess(hook("base3"))
+
+	admin := base.Clone(WithResponseHandler(&testResponseHandler{})).OnSuccess(hook("admin"))
+	public := base.Clone().OnSuccess(hook("public"))
+
+	handler := func(c *gin.Context) (any, error) {
+		return nil, nil
+	}
+
+	router := gin.New()
+	router.GET("/base", base.MustFormBindingGinHandlerFunc(handler))
+	router.GET("/admin", admin.MustFormBindingGinHandlerFunc(handler))
+	router.GET("/public", public.MustFormBindingGinHandlerFunc(handler))
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		calls = nil
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", target, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("/base")
+	assert.JSONEq(t, `{"status":"success"}`, w.Body.String())
+	assert.Equal(t, []string{"base1", "base2", "base3"}, calls)
+
+	w = serve("/admin")
+	assert.Equal(t, "custom success", w.Body.String())
+	assert.Equal(t, []string{"base1", "base2", "base3", "admin"}, calls)
+
+	w = serve("/public")
+	assert.JSONEq(t, `{"status":"success"}`, w.Body.String())
+	assert.Equal(t, []string{"base1", "base2", "base3", "public"}, calls)
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				clone := base.Clone(WithTimeout(time.Second)).OnSuccess(hook("clone")).Provide(i)
+				clone.MustFormBindingGinHandlerFunc(handler)
+			}()
+		}
+		wg.Wait()
+
+		serve("/base")
+		assert.Equal(t, []string{"base1", "base2", "base3"}, calls)
+	})
+}
@@ -145,6 +145,150 @@ func TestHeaderBinding(t *testing.T) {
 	assert.Equal(t, "application/json", data["content_type"])
 }
 
+func TestWildcardHeaderBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Meta   map[string]string   `header:"X-Meta-*"`
+		Traces map[string][]string `header:"x-trace-*"`
+	}) (interface{}, error) {
+		return gin.H{
+			"meta":   req.Meta,
+			"traces": req.Traces,
+		}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/headers", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/headers", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Meta-Tenant", "acme")
+	req.Header.Set("x-meta-region", "eu-west-1")
+	req.Header.Add("X-Trace-Hop", "a")
+	req.Header.Add("X-Trace-Hop", "b")
+	req.Header.Set("X-Other", "ignored")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"X-Meta-Tenant": "acme",
+		"X-Meta-Region": "eu-west-1",
+	}, data["meta"])
+	assert.Equal(t, map[string]interface{}{
+		"X-Trace-Hop": []interface{}{"a", "b"},
+	}, data["traces"])
+}
+
+func TestHeaderTagsCaseInsensitive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		APIKey    string `header:"x-api-key"`
+		RequestID string `header:"X-REQUEST-ID"`
+		Tenant    string `header:"x-tenant" require:"true"`
+		Trace     string `header:"x-trace,omitempty"`
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	NewRouter(router, builder).GET("/headers", func(c *gin.Context, req request) (any, error) {
+		return req, nil
+	})
+
+	serve := func(header http.Header) (*httptest.ResponseRecorder, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/headers", nil)
+		req.Header = header
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("canonical request headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-Api-Key", "secret")
+		header.Set("x-request-id", "r-1")
+		header.Set("X-Tenant", "acme")
+		header.Set("X-Trace", "t-1")
+		w, response := serve(header)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, map[string]interface{}{
+			"APIKey": "secret", "RequestID": "r-1", "Tenant": "acme", "Trace": "t-1",
+		}, response["data"])
+	})
+
+	t.Run("non-canonical request headers", func(t *testing.T) {
+		w, response := serve(http.Header{
+			"x-api-key":    {"secret"},
+			"X-REQUEST-ID": {"r-1"},
+			"x-tenant":     {"acme"},
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, map[string]interface{}{
+			"APIKey": "secret", "RequestID": "r-1", "Tenant": "acme", "Trace": "",
+		}, response["data"])
+	})
+
+	t.Run("missing required header", func(t *testing.T) {
+		w, response := serve(http.Header{"X-Api-Key": {"secret"}})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, `field Tenant: missing required header "X-Tenant"`, response["message"])
+	})
+
+	t.Run("route introspection", func(t *testing.T) {
+		var names []string
+		for _, field := range builder.Routes()[0].Fields {
+			names = append(names, field.Name)
+		}
+		assert.Equal(t, []string{"X-Api-Key", "X-Request-Id", "X-Tenant", "X-Trace"}, names)
+	})
+}
+
+func TestWildcardHeaderBindingInvalidType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Meta string `header:"X-Meta-*"`
+	}) (interface{}, error) {
+		return gin.H{"meta": req.Meta}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/headers", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/headers", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Meta-Tenant", "acme")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "wildcard header requires map")
+}
+
 func TestRequestBodyBinding(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -0,0 +1,28 @@
+package ginbinding
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithOptionalBody accepts requests without a body, such as a POST with an
+// empty JSON body, instead of failing with an EOF binding error. The request
+// struct keeps the values bound from the other sources and goes through the
+// defaults and validation stages as usual. Truncated bodies still fail.
+func WithOptionalBody() Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.optionalBody = true
+	}
+}
+
+// isMissingBody reports whether the body binding error err only means that
+// the request has no body, which WithOptionalBody accepts
+func (builder *BasicFormBindingGinHandlerBuilder) isMissingBody(ctx *gin.Context, err error) bool {
+	if !builder.optionalBody {
+		return false
+	}
+	return ctx.Request.Body == nil || ctx.Request.Body == http.NoBody || errors.Is(err, io.EOF)
+}
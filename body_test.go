@@ -0,0 +1,56 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithOptionalBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		optional       bool
+		target         string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "required body", target: "/items", expectedStatus: http.StatusBadRequest, expectedBody: `"code":"BINDING_FAILED"`},
+		{name: "empty body", optional: true, target: "/items?page=2", expectedStatus: http.StatusOK, expectedBody: `{"name":"untitled","page":2}`},
+		{name: "whitespace body", optional: true, target: "/items", body: "  \n", expectedStatus: http.StatusOK, expectedBody: `{"name":"untitled","page":1}`},
+		{name: "sent body", optional: true, target: "/items", body: `{"name":"box"}`, expectedStatus: http.StatusOK, expectedBody: `{"name":"box","page":1}`},
+		{name: "truncated body", optional: true, target: "/items", body: `{"name":`, expectedStatus: http.StatusBadRequest, expectedBody: `"code":"BINDING_FAILED"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.optional {
+				opts = append(opts, WithOptionalBody())
+			}
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, opts...)
+
+			router := gin.New()
+			router.POST("/items", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+				Name string `json:"name" default:"untitled"`
+				Page int    `form:"page" json:"page" default:"1"`
+			}) (any, error) {
+				return req, nil
+			}))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
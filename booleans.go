@@ -0,0 +1,42 @@
+package ginbinding
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// WithBoolValues adds words to the vocabulary accepted for bool fields bound
+// from path parameters, query parameters and defaults, such as "y" and "n" or
+// locale-specific words. Words are matched case-insensitively; the default
+// vocabulary of true/false, 1/0, yes/no and on/off stays accepted.
+func WithBoolValues(truthy []string, falsy []string) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		// Copy so that per-handler words never leak into the builder the
+		// handler was copied from
+		boolValues := maps.Clone(builder.boolValues)
+		if boolValues == nil {
+			boolValues = make(map[string]bool, len(truthy)+len(falsy))
+		}
+		for _, word := range truthy {
+			boolValues[strings.ToLower(strings.TrimSpace(word))] = true
+		}
+		for _, word := range falsy {
+			boolValues[strings.ToLower(strings.TrimSpace(word))] = false
+		}
+		builder.boolValues = boolValues
+	}
+}
+
+// parseBool parses s using the default vocabulary and the words added with
+// WithBoolValues
+func (builder *BasicFormBindingGinHandlerBuilder) parseBool(s string) (bool, error) {
+	b, err := parseBool(s)
+	if err == nil {
+		return b, nil
+	}
+	if b, ok := builder.boolValues[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return b, nil
+	}
+	return false, fmt.Errorf("invalid boolean value %q", s)
+}
@@ -0,0 +1,93 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBoolValues(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		Enabled bool  `path:"enabled"`
+		Notify  bool  `form:"notify"`
+		Archive *bool `form:"archive"`
+		Public  bool  `form:"public" default:"ja"`
+	}
+
+	var got request
+	handler := func(c *gin.Context, req request) error {
+		got = req
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil,
+		WithBoolValues([]string{"Y", "enabled", "ja"}, []string{"N", "disabled", "nein"}))
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/features/:enabled", ginHandler)
+
+	tests := []struct {
+		name           string
+		target         string
+		expectedStatus int
+		expected       request
+	}{
+		{
+			name:           "custom words",
+			target:         "/features/enabled?notify=y&archive=N",
+			expectedStatus: http.StatusOK,
+			expected:       request{Enabled: true, Notify: true, Archive: new(bool), Public: true},
+		},
+		{
+			name:           "default vocabulary",
+			target:         "/features/on?notify=yes",
+			expectedStatus: http.StatusOK,
+			expected:       request{Enabled: true, Notify: true, Public: true},
+		},
+		{
+			name:           "strconv values",
+			target:         "/features/disabled?notify=T",
+			expectedStatus: http.StatusOK,
+			expected:       request{Enabled: false, Notify: true, Public: true},
+		},
+		{
+			name:           "unknown word",
+			target:         "/features/maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = request{}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.target, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithBoolValues_PerHandler(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context) error {
+		return nil
+	}, WithBoolValues([]string{"y"}, nil))
+	assert.NoError(t, err)
+
+	_, err = builder.parseBool("y")
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// BudgetPolicy selects what WithResponseBudget does with success data whose
+// JSON encoding exceeds the budget
+type BudgetPolicy int
+
+const (
+	// BudgetError fails the request with ErrResponseTooLarge (500)
+	BudgetError BudgetPolicy = iota
+	// BudgetTruncate drops the trailing items of lists until they fit, and
+	// flags the response as truncated: slices are served as a
+	// TruncatedResponse and PageResponses with Truncated set. Other data
+	// fails with ErrResponseTooLarge.
+	BudgetTruncate
+	// BudgetStream serves slices as a streamed JSON array, like handlers
+	// returning an iter.Seq, instead of encoding them in one piece. Other
+	// data fails with ErrResponseTooLarge.
+	BudgetStream
+)
+
+// TruncatedResponse is served in place of a slice cut short by
+// WithResponseBudget with BudgetTruncate
+type TruncatedResponse struct {
+	Items     any  `json:"items"`
+	Truncated bool `json:"truncated"`
+}
+
+// WithResponseBudget bounds the size of the JSON encoding of success data to
+// maxBytes, guarding services against handlers that accidentally return
+// unbounded datasets. policy selects what happens to data over the budget.
+// The envelope added by the response handler is not counted, and streamed
+// responses are never limited. A zero or negative maxBytes removes the
+// budget.
+//
+//	r.GET("/events", builder.MustFormBindingGinHandlerFunc(listEvents,
+//		ginbinding.WithResponseBudget(1<<20, ginbinding.BudgetTruncate)))
+func WithResponseBudget(maxBytes int, policy BudgetPolicy) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		if maxBytes <= 0 {
+			builder.responseBudget = nil
+			return
+		}
+		builder.responseBudget = &responseBudget{maxBytes: maxBytes, policy: policy}
+	}
+}
+
+// responseBudget is the configuration of WithResponseBudget
+type responseBudget struct {
+	maxBytes int
+	policy   BudgetPolicy
+}
+
+// truncatablePage is implemented by PageResponse, whose items BudgetTruncate
+// can drop
+type truncatablePage interface {
+	PageItems() any
+	truncatePage(n int) any
+}
+
+// truncatePage implements truncatablePage
+func (r PageResponse[T]) truncatePage(n int) any {
+	r.Items = r.Items[:n]
+	r.Truncated = true
+	return r
+}
+
+// fitBudget returns the data to serve in place of data under the response
+// budget, or an error wrapping ErrResponseTooLarge. Data that cannot be
+// encoded is returned as is for the response handler to report.
+func (budget *responseBudget) fitBudget(data any) (any, error) {
+	if data == nil {
+		return data, nil
+	}
+	if _, ok := responseStream(data); ok {
+		return data, nil
+	}
+
+	page, isPage := data.(truncatablePage)
+	var items reflect.Value
+	switch {
+	case budget.policy == BudgetTruncate && isPage:
+		items = reflect.ValueOf(page.PageItems())
+	case budget.policy != BudgetError:
+		items = reflect.ValueOf(data)
+	}
+	if !items.IsValid() || items.Kind() != reflect.Slice || items.Type().Elem().Kind() == reflect.Uint8 {
+		b, err := json.Marshal(data)
+		if err != nil || len(b) <= budget.maxBytes {
+			return data, nil
+		}
+		return nil, budget.tooLarge(len(b))
+	}
+
+	// The encoding of a list is that of its items, the commas between them
+	// and the encoding of the list without items
+	empty := []byte("[]")
+	if budget.policy == BudgetTruncate {
+		var wrapper any = TruncatedResponse{Items: items.Slice(0, 0).Interface(), Truncated: true}
+		if isPage {
+			wrapper = page.truncatePage(0)
+		}
+		var err error
+		if empty, err = json.Marshal(wrapper); err != nil {
+			return data, nil
+		}
+	}
+
+	size, fit := len(empty), 0
+	for i := range items.Len() {
+		b, err := json.Marshal(items.Index(i).Interface())
+		if err != nil {
+			return data, nil
+		}
+		if i > 0 {
+			size++
+		}
+		size += len(b)
+		if size <= budget.maxBytes {
+			fit = i + 1
+		}
+	}
+	if fit == items.Len() {
+		return data, nil
+	}
+
+	switch {
+	case budget.policy == BudgetStream:
+		return sliceStream(items), nil
+	case len(empty) > budget.maxBytes:
+		return nil, budget.tooLarge(size)
+	case isPage:
+		return page.truncatePage(fit), nil
+	default:
+		return TruncatedResponse{Items: items.Slice(0, fit).Interface(), Truncated: true}, nil
+	}
+}
+
+// tooLarge returns the error of data whose encoding is size bytes
+func (budget *responseBudget) tooLarge(size int) error {
+	return fmt.Errorf("response of %d bytes exceeds the budget of %d bytes: %w", size, budget.maxBytes, ErrResponseTooLarge)
+}
+
+// sliceStream returns the items of the slice v as a streamed response
+func sliceStream(v reflect.Value) itemStream {
+	return func(yield func(item any, err error) bool) {
+		for i := range v.Len() {
+			if !yield(v.Index(i).Interface(), nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithResponseBudget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type event struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	// Every event encodes to 23 bytes, and the list to 97
+	events := []event{{1, "aaaaa"}, {2, "bbbbb"}, {3, "ccccc"}, {4, "ddddd"}}
+	page := NewPageResponse(events, 40, Pagination{Page: 1, PageSize: 4})
+
+	var hookResp any
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil).
+		OnSuccess(func(c *gin.Context, req any, resp any) {
+			hookResp = resp
+		})
+	router := gin.New()
+	handle := func(path string, data any, options ...Option) {
+		router.GET(path, builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+			return WithHeaders(data, map[string]string{"X-Served": "1"}), nil
+		}, options...))
+	}
+	handle("/fits", events, WithResponseBudget(100, BudgetError))
+	handle("/error", events, WithResponseBudget(60, BudgetError))
+	handle("/truncate", events, WithResponseBudget(80, BudgetTruncate))
+	handle("/truncate-page", page, WithResponseBudget(160, BudgetTruncate))
+	handle("/truncate-tiny", events, WithResponseBudget(10, BudgetTruncate))
+	handle("/truncate-object", event{1, strings.Repeat("a", 100)}, WithResponseBudget(60, BudgetTruncate))
+	handle("/stream", events, WithResponseBudget(60, BudgetStream))
+	handle("/stream-page", page, WithResponseBudget(60, BudgetStream))
+	handle("/disabled", events, WithResponseBudget(60, BudgetError), WithResponseBudget(0, BudgetError))
+
+	get := func(path string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+	ids := func(items any) []float64 {
+		var ids []float64
+		for _, item := range items.([]interface{}) {
+			ids = append(ids, item.(map[string]interface{})["id"].(float64))
+		}
+		return ids
+	}
+
+	t.Run("within budget", func(t *testing.T) {
+		hookResp = nil
+		w, response := get("/fits")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []float64{1, 2, 3, 4}, ids(response["data"]))
+		assert.Equal(t, events, hookResp)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		hookResp = nil
+		w, response := get("/error")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, CodeResponseTooLarge, response["code"])
+		assert.Equal(t, "response of 97 bytes exceeds the budget of 60 bytes: response too large", response["message"])
+		assert.Empty(t, w.Header().Get("X-Served"))
+		assert.Nil(t, hookResp)
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		hookResp = nil
+		w, response := get("/truncate")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, true, data["truncated"])
+		assert.Equal(t, []float64{1, 2}, ids(data["items"]))
+		assert.Equal(t, "1", w.Header().Get("X-Served"))
+		assert.Equal(t, events, hookResp)
+
+		b, err := json.Marshal(TruncatedResponse{Items: events[:2], Truncated: true})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(b), 80)
+		b, err = json.Marshal(TruncatedResponse{Items: events[:3], Truncated: true})
+		require.NoError(t, err)
+		assert.Greater(t, len(b), 80)
+	})
+
+	t.Run("truncate page", func(t *testing.T) {
+		w, response := get("/truncate-page")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, true, data["truncated"])
+		assert.Equal(t, float64(40), data["total"])
+		assert.Equal(t, []float64{1, 2, 3}, ids(data["items"]))
+	})
+
+	t.Run("truncate beyond repair", func(t *testing.T) {
+		for _, path := range []string{"/truncate-tiny", "/truncate-object"} {
+			w, response := get(path)
+
+			assert.Equal(t, http.StatusInternalServerError, w.Code, path)
+			assert.Equal(t, CodeResponseTooLarge, response["code"], path)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		hookResp = nil
+		w, _ := get("/stream")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var streamed []event
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &streamed))
+		assert.Equal(t, events, streamed)
+		assert.Equal(t, events, hookResp)
+
+		w, response := get("/stream-page")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, CodeResponseTooLarge, response["code"])
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		w, _ := get("/disabled")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestResponseBudgetErrors(t *testing.T) {
+	err := (&responseBudget{maxBytes: 10}).tooLarge(20)
+
+	assert.True(t, errors.Is(err, ErrResponseTooLarge))
+	statusCode, _ := errorStatusAndMessage(err)
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+	assert.Equal(t, CodeResponseTooLarge, ErrorCode(err))
+}
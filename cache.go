@@ -0,0 +1,232 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheStore stores the serialized responses cached by WithCache.
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Get returns the value stored under key, if it has not expired
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for ttl
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// CacheKeyFunc returns the cache key of a request, given its bound request
+// struct, or nil for handlers without a request. Handlers with a separate body
+// struct pass the struct of the other sources as req and the body struct as
+// body, which is nil for other handlers. Requests with an empty key are not
+// cached.
+type CacheKeyFunc func(ctx *gin.Context, req, body any) string
+
+// WithCache caches the success responses of the handler for ttl, serving
+// later requests with the same key from store without the handler function
+// or the success hooks running again; the request is still bound and
+// validated to compute the key. keyFunc defaults to DefaultCacheKey and store
+// to a new MemoryCacheStore. Only responses with a 2xx status are cached,
+// along with the headers set while handling them, such as Location, ETag and
+// pagination headers; Set-Cookie is never cached. Cached responses with an
+// ETag are answered with 304 Not Modified when the If-None-Match header of a
+// GET or HEAD request matches it, like fresh ones. Handlers reading a Stream
+// cannot be cached.
+//
+//	r.GET("/products/:id", builder.MustFormBindingGinHandlerFunc(getProduct,
+//		ginbinding.WithCache(time.Minute, nil, nil)))
+func WithCache(ttl time.Duration, keyFunc CacheKeyFunc, store CacheStore) Option {
+	if keyFunc == nil {
+		keyFunc = DefaultCacheKey
+	}
+	if store == nil {
+		store = NewMemoryCacheStore()
+	}
+	cache := &responseCache{ttl: ttl, key: keyFunc, store: store}
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.cache = cache
+	}
+}
+
+// DefaultCacheKey keys requests by method, path, query and bound request and
+// body structs, or by method, path and query for handlers without a request.
+// The whole query is part of the key, since parameters that are not bound,
+// such as the fields of WithSparseFieldsets or a JSONP callback, shape the
+// response too; the order of the parameters does not matter.
+func DefaultCacheKey(ctx *gin.Context, req, body any) string {
+	key := ctx.Request.Method + " " + ctx.Request.URL.Path + "?" + ctx.Request.URL.Query().Encode()
+	for _, v := range []any{req, body} {
+		if v == nil {
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		key += " " + string(b)
+	}
+	return key
+}
+
+// responseCache is the configuration of WithCache
+type responseCache struct {
+	ttl   time.Duration
+	key   CacheKeyFunc
+	store CacheStore
+}
+
+// cachedResponse is the serialized form of a cached response
+type cachedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// uncachedHeaders are response headers never stored with cached responses,
+// since they belong to a single client or to the encoding of one response
+var uncachedHeaders = []string{"Set-Cookie", "Content-Encoding", "Content-Length"}
+
+// cacheWriter records the response written through it
+type cacheWriter struct {
+	gin.ResponseWriter
+	// before are the response headers set before the handler ran, such as
+	// those of middleware, which are not cached
+	before http.Header
+	// header are the headers the handler set, recorded when the response
+	// was first written, before writers beneath change them
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (w *cacheWriter) WriteHeaderNow() {
+	w.record()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	w.record()
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	w.record()
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// record captures the headers the handler set, once
+func (w *cacheWriter) record() {
+	if w.header != nil {
+		return
+	}
+	w.header = http.Header{}
+	for key, values := range w.ResponseWriter.Header() {
+		if !slices.Contains(uncachedHeaders, key) && !slices.Equal(values, w.before[key]) {
+			w.header[key] = slices.Clone(values)
+		}
+	}
+}
+
+// serveCached writes the response cached for the request of ctx, if any, and
+// otherwise starts recording the response. The returned function stores the
+// recorded response once the request has been handled; it is nil when the
+// response was served from the cache or is not cached.
+func (c *responseCache) serveCached(ctx *gin.Context, req, body reflect.Value) (served bool, store func()) {
+	key := c.key(ctx, hookRequest(req), hookRequest(body))
+	if key == "" {
+		return false, nil
+	}
+
+	if value, ok := c.store.Get(key); ok {
+		var cached cachedResponse
+		if err := json.Unmarshal(value, &cached); err == nil {
+			header := ctx.Writer.Header()
+			for key, values := range cached.Header {
+				header[key] = values
+			}
+			if !answerNotModified(ctx, header.Get("ETag")) {
+				ctx.Data(cached.Status, header.Get("Content-Type"), cached.Body)
+			}
+			return true, nil
+		}
+	}
+
+	w := &cacheWriter{ResponseWriter: ctx.Writer, before: ctx.Writer.Header().Clone()}
+	ctx.Writer = w
+	return false, func() {
+		ctx.Writer = w.ResponseWriter
+		if w.Status() < http.StatusOK || w.Status() >= http.StatusMultipleChoices {
+			return
+		}
+		w.record()
+		value, err := json.Marshal(cachedResponse{
+			Status: w.Status(),
+			Header: w.header,
+			Body:   w.body.Bytes(),
+		})
+		if err == nil {
+			c.store.Set(key, value, c.ttl)
+		}
+	}
+}
+
+// MemoryCacheStore is a CacheStore keeping entries in memory. Expired entries
+// are removed when they are read or overwritten, and all of them at most once
+// per ttl of the entries stored, so that the store does not grow with every
+// key ever seen.
+type MemoryCacheStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+	// swept is when the expired entries were last dropped
+	swept time.Time
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCacheStore returns an empty MemoryCacheStore
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{entries: map[string]memoryCacheEntry{}}
+}
+
+// Get implements CacheStore
+func (s *MemoryCacheStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(s.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set implements CacheStore
+func (s *MemoryCacheStore) Set(key string, value []byte, ttl time.Duration) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.swept) >= ttl {
+		for k, entry := range s.entries {
+			if now.After(entry.expires) {
+				delete(s.entries, k)
+			}
+		}
+		s.swept = now
+	}
+	s.entries[key] = memoryCacheEntry{value: value, expires: now.Add(ttl)}
+}
@@ -0,0 +1,208 @@
+package ginbinding
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	router := gin.New()
+	router.GET("/products/:id", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		ID   int    `uri:"id"`
+		Lang string `form:"lang"`
+	}) (any, error) {
+		calls++
+		if req.ID == 0 {
+			return nil, errors.New("database gone")
+		}
+		return gin.H{"id": req.ID, "lang": req.Lang, "call": calls}, nil
+	}, WithCache(time.Minute, nil, nil)))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	first := get("/products/1?lang=en&v=2")
+	assert.Equal(t, http.StatusOK, first.Code)
+
+	hit := get("/products/1?v=2&lang=en")
+	assert.Equal(t, http.StatusOK, hit.Code)
+	assert.Equal(t, first.Body.String(), hit.Body.String())
+	assert.Equal(t, first.Header().Get("Content-Type"), hit.Header().Get("Content-Type"))
+	assert.Equal(t, 1, calls)
+
+	assert.Contains(t, get("/products/1?lang=de").Body.String(), `"call":2`)
+	assert.Equal(t, 2, calls)
+
+	// Errors are not cached
+	assert.Equal(t, http.StatusInternalServerError, get("/products/0").Code)
+	assert.Equal(t, http.StatusInternalServerError, get("/products/0").Code)
+	assert.Equal(t, 4, calls)
+}
+
+func TestWithCacheQueryAndHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Header("X-Request-Id", c.Query("rid"))
+		c.Next()
+	})
+	router.POST("/products/:id", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		ID int `uri:"id"`
+	}) (any, error) {
+		calls++
+		c.SetCookie("session", "secret", 0, "/", "", false, true)
+		return WithHeaders(gin.H{"id": req.ID, "name": "pen", "call": calls}, map[string]string{
+			"Location": "/products/1",
+		}), nil
+	}, WithCache(time.Minute, nil, nil), WithSparseFieldsets("id", "name", "call")))
+
+	post := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		return w
+	}
+
+	first := post("/products/1?fields=id&rid=a")
+	assert.Equal(t, `{"data":{"id":1},"status":"success"}`, first.Body.String())
+	assert.NotEmpty(t, first.Header().Get("Set-Cookie"))
+
+	// The query is part of the key, including parameters that are not bound
+	assert.Contains(t, post("/products/1").Body.String(), `"name":"pen"`)
+	assert.Contains(t, post("/products/1?fields=name&rid=a").Body.String(), `{"name":"pen"}`)
+	assert.Equal(t, 3, calls)
+
+	hit := post("/products/1?rid=a&fields=id")
+	assert.Equal(t, first.Body.String(), hit.Body.String())
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, "/products/1", hit.Header().Get("Location"))
+	assert.Equal(t, first.Header().Get("Content-Type"), hit.Header().Get("Content-Type"))
+	assert.Equal(t, "a", hit.Header().Get("X-Request-Id"))
+	assert.Empty(t, hit.Header().Get("Set-Cookie"))
+}
+
+func TestWithCacheBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	type query struct {
+		Lang string `form:"lang"`
+	}
+	type body struct {
+		Text string `json:"text"`
+	}
+
+	router := gin.New()
+	router.POST("/translate", builder.MustFormBindingGinHandlerFunc(func(q query, b body) (any, error) {
+		calls++
+		return gin.H{"lang": q.Lang, "text": b.Text, "call": calls}, nil
+	}, WithCache(time.Minute, nil, nil)))
+
+	post := func(text string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/translate?lang=de", strings.NewReader(`{"text":"`+text+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Contains(t, post("hello").Body.String(), `"text":"hello"`)
+	assert.Contains(t, post("bye").Body.String(), `"text":"bye"`)
+	assert.Contains(t, post("hello").Body.String(), `"call":1`)
+	assert.Equal(t, 2, calls)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(q query, items Stream[body]) error {
+		return nil
+	}, WithCache(time.Minute, nil, nil))
+	assert.EqualError(t, err, "WithCache cannot cache handlers reading a Stream")
+}
+
+func TestWithCacheNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithETag(func(data any) string {
+		return "v1"
+	}))
+
+	router := gin.New()
+	router.GET("/products/:id", builder.MustFormBindingGinHandlerFunc(func(req struct {
+		ID int `uri:"id"`
+	}) (any, error) {
+		calls++
+		return gin.H{"id": req.ID}, nil
+	}, WithCache(time.Minute, nil, nil)))
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/products/1", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, `"v1"`, first.Header().Get("ETag"))
+
+	hit := get(`"v1"`)
+	assert.Equal(t, http.StatusNotModified, hit.Code)
+	assert.Equal(t, `"v1"`, hit.Header().Get("ETag"))
+	assert.Empty(t, hit.Body.String())
+
+	stale := get(`"v0"`)
+	assert.Equal(t, http.StatusOK, stale.Code)
+	assert.Equal(t, first.Body.String(), stale.Body.String())
+	assert.Equal(t, 1, calls)
+}
+
+func TestMemoryCacheStoreSweep(t *testing.T) {
+	store := NewMemoryCacheStore()
+
+	for _, key := range []string{"a", "b", "c"} {
+		store.Set(key, []byte(key), time.Millisecond)
+	}
+	time.Sleep(2 * time.Millisecond)
+	// Expired entries are dropped once per ttl, without being read
+	store.Set("d", []byte("d"), time.Millisecond)
+
+	assert.Len(t, store.entries, 1)
+}
+
+func TestMemoryCacheStore(t *testing.T) {
+	store := NewMemoryCacheStore()
+
+	store.Set("a", []byte("1"), time.Minute)
+	store.Set("b", []byte("2"), -time.Second)
+
+	value, ok := store.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+
+	_, ok = store.Get("b")
+	assert.False(t, ok)
+	_, ok = store.Get("c")
+	assert.False(t, ok)
+}
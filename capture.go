@@ -0,0 +1,179 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Capture is a bound request recorded by WithCapture. It is stored as JSON.
+type Capture struct {
+	// Method and Route are the HTTP method and gin route pattern of the
+	// request, e.g. GET and /users/:id
+	Method string `json:"method"`
+	Route  string `json:"route"`
+	// Time is when the request was captured
+	Time time.Time `json:"time"`
+	// CapturedStruct is the bound request struct
+	CapturedStruct
+	// Body is the bound body struct of handlers taking the body in a
+	// separate parameter, or nil for other handlers
+	Body *CapturedStruct `json:"body,omitempty"`
+}
+
+// CapturedStruct is a bound struct recorded in a Capture
+type CapturedStruct struct {
+	// Type is the Go type of the struct, e.g. api.GetUserRequest
+	Type string `json:"type"`
+	// Fields holds the JSON encoding of each field of the bound struct by Go
+	// field name, including the fields whose JSON encoding is skipped with a
+	// json:"-" tag
+	Fields map[string]json.RawMessage `json:"fields"`
+	// Sources holds the source and key each field was bound from by Go field
+	// name, e.g. path:id, query:page or body:name
+	Sources map[string]string `json:"sources"`
+}
+
+// WithCapture records every bound request to a JSON file in dir, to replay
+// real traffic against handlers in regression tests with
+// ginbindingtest.Replay, or ginbindingtest.ReplaySplit for handlers taking
+// the body in a separate struct. Requests are recorded after binding and
+// validation succeeded and before the handler runs. The file is written in
+// the background, so that serving the request does not wait for the disk;
+// failing writes are logged to slog.Default, and fields that cannot be
+// encoded are added to the gin errors of the request, which is served
+// regardless. The contents of uploaded files are not captured.
+//
+//	r.POST("/orders", builder.MustFormBindingGinHandlerFunc(createOrder,
+//		ginbinding.WithCapture("testdata/captures")))
+//
+// Captures contain the request as sent, so enable the option only where
+// recording it is allowed, e.g. in staging.
+func WithCapture(dir string) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.captureDir = dir
+	}
+}
+
+// capture records the bound request req and body of ctx, and writes them to
+// a new file in dir in the background. body is invalid for handlers without a
+// separate body struct.
+func capture(ctx *gin.Context, dir string, req, body reflect.Value) error {
+	val := reflect.Indirect(req)
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	c := Capture{
+		Method: ctx.Request.Method,
+		Route:  ctx.FullPath(),
+		Time:   time.Now(),
+	}
+	var err error
+	if c.CapturedStruct, err = captureStruct(val); err != nil {
+		return err
+	}
+	if val := reflect.Indirect(body); val.Kind() == reflect.Struct {
+		s, err := captureStruct(val)
+		if err != nil {
+			return err
+		}
+		c.Body = &s
+	}
+
+	// the request is encoded before returning, as its values may be reused
+	// once the handler ran
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ginbinding: capture: %w", err)
+	}
+	go func() {
+		if err := writeCapture(dir, data); err != nil {
+			slog.Default().Error("ginbinding: capture", "route", c.Route, "error", err)
+		}
+	}()
+	return nil
+}
+
+// captureStruct records the exported fields of the struct val
+func captureStruct(val reflect.Value) (CapturedStruct, error) {
+	s := CapturedStruct{
+		Type:    val.Type().String(),
+		Fields:  map[string]json.RawMessage{},
+		Sources: map[string]string{},
+	}
+	for i := 0; i < val.NumField(); i++ {
+		sf := val.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		data, err := json.Marshal(val.Field(i).Interface())
+		if err != nil {
+			return s, fmt.Errorf("ginbinding: capture field %s: %w", sf.Name, err)
+		}
+		s.Fields[sf.Name] = data
+		s.Sources[sf.Name] = fieldSource(sf)
+	}
+	return s, nil
+}
+
+// writeCapture writes the encoded capture data to a new file in dir. The
+// file is renamed to its .json name once written, so that readers globbing
+// for captures never see it partially written.
+func writeCapture(dir string, data []byte) error {
+	f, err := os.CreateTemp(dir, "capture-*.json.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), strings.TrimSuffix(f.Name(), ".tmp"))
+}
+
+// ReadCapture reads the capture WithCapture wrote to the file name
+func ReadCapture(name string) (*Capture, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var c Capture
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("ginbinding: capture %s: %w", name, err)
+	}
+	return &c, nil
+}
+
+// Decode sets the fields of req, a pointer to the struct the capture was
+// recorded from, to their captured values. Captured fields req does not have
+// are ignored.
+func (c *CapturedStruct) Decode(req any) error {
+	val := reflect.ValueOf(req)
+	if val.Kind() != reflect.Pointer || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ginbinding: capture must be decoded into a pointer to struct, got %T", req)
+	}
+	val = val.Elem()
+
+	for name, data := range c.Fields {
+		sf, ok := val.Type().FieldByName(name)
+		if !ok || !sf.IsExported() || len(sf.Index) != 1 {
+			continue
+		}
+		if err := json.Unmarshal(data, val.Field(sf.Index[0]).Addr().Interface()); err != nil {
+			return fmt.Errorf("ginbinding: capture field %s: %w", name, err)
+		}
+	}
+	return nil
+}
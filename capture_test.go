@@ -0,0 +1,113 @@
+package ginbinding
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type captureRequest struct {
+	ID     int    `path:"id"`
+	Page   int    `form:"page" default:"1"`
+	Token  string `header:"Authorization" json:"-"`
+	Name   string `json:"name"`
+	secret string
+}
+
+func TestWithCapture(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dir := t.TempDir()
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.PUT("/items/:id", builder.MustFormBindingGinHandlerFunc(func(req captureRequest) error {
+		return nil
+	}, WithCapture(dir)))
+	router.PUT("/broken/:id", builder.MustFormBindingGinHandlerFunc(func(req captureRequest) error {
+		return nil
+	}, WithCapture(filepath.Join(dir, "missing"))))
+
+	send := func(target, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer t")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Failing requests are not captured
+	assert.Equal(t, http.StatusBadRequest, send("/items/x", `{}`).Code)
+	assert.Equal(t, http.StatusOK, send("/items/7", `{"name":"pen"}`).Code)
+
+	var names []string
+	require.Eventually(t, func() bool {
+		names, _ = filepath.Glob(filepath.Join(dir, "*.json"))
+		return len(names) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	c, err := ReadCapture(names[0])
+	require.NoError(t, err)
+	assert.Equal(t, "PUT", c.Method)
+	assert.Equal(t, "/items/:id", c.Route)
+	assert.Equal(t, "ginbinding.captureRequest", c.Type)
+	assert.Equal(t, map[string]json.RawMessage{
+		"ID":    json.RawMessage(`7`),
+		"Page":  json.RawMessage(`1`),
+		"Token": json.RawMessage(`"Bearer t"`),
+		"Name":  json.RawMessage(`"pen"`),
+	}, c.Fields)
+	assert.Equal(t, map[string]string{
+		"ID":    "path:id",
+		"Page":  "query:page",
+		"Token": "header:Authorization",
+		"Name":  "body:name",
+	}, c.Sources)
+
+	var req captureRequest
+	require.NoError(t, c.Decode(&req))
+	assert.Equal(t, captureRequest{ID: 7, Page: 1, Token: "Bearer t", Name: "pen"}, req)
+	assert.Error(t, c.Decode(req))
+
+	assert.Nil(t, c.Body)
+
+	t.Run("write error", func(t *testing.T) {
+		logs := make(chan string, 1)
+		defer slog.SetDefault(slog.Default())
+		slog.SetDefault(slog.New(captureLogHandler{logs}))
+
+		assert.Equal(t, http.StatusOK, send("/broken/7", `{}`).Code)
+		select {
+		case msg := <-logs:
+			assert.Equal(t, "ginbinding: capture", msg)
+		case <-time.After(time.Second):
+			t.Fatal("failed write not logged")
+		}
+	})
+}
+
+// captureLogHandler sends the messages of records to a channel
+type captureLogHandler struct {
+	logs chan<- string
+}
+
+func (h captureLogHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h captureLogHandler) Handle(_ context.Context, r slog.Record) error {
+	h.logs <- r.Message
+	return nil
+}
+
+func (h captureLogHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h captureLogHandler) WithGroup(string) slog.Handler { return h }
@@ -0,0 +1,124 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// KeyCase selects how DefaultResponseHandler rewrites the keys of JSON
+// responses
+type KeyCase int
+
+const (
+	// KeyCaseDefault keeps keys as they are serialized
+	KeyCaseDefault KeyCase = iota
+	// KeyCaseCamel rewrites keys to camelCase, e.g. user_id to userId
+	KeyCaseCamel
+	// KeyCaseSnake rewrites keys to snake_case, e.g. userID to user_id
+	KeyCaseSnake
+)
+
+// convert returns s in the case c
+func (c KeyCase) convert(s string) string {
+	switch c {
+	case KeyCaseCamel:
+		return camelCase(s)
+	case KeyCaseSnake:
+		return snakeCase(s)
+	}
+	return s
+}
+
+// transformKeys returns the JSON representation of body with the keys of
+// every object rewritten to the case c
+func (c KeyCase) transformKeys(body any) (any, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return c.transformValue(v), nil
+}
+
+func (c KeyCase) transformValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		transformed := make(map[string]any, len(v))
+		for key, field := range v {
+			transformed[c.convert(key)] = c.transformValue(field)
+		}
+		return transformed
+	case []any:
+		for i := range v {
+			v[i] = c.transformValue(v[i])
+		}
+		return v
+	}
+	return v
+}
+
+// camelCase converts snake_case, kebab-case and PascalCase keys to camelCase.
+// Leading initialisms are lower-cased: ID becomes id, HTTPStatus httpStatus.
+func camelCase(s string) string {
+	runes := []rune(s)
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	// Keep the first letter of the word following an initialism
+	if n > 1 && n < len(runes) && unicode.IsLower(runes[n]) {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	upper := false
+	for _, r := range runes {
+		if r == '_' || r == '-' {
+			upper = b.Len() > 0
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// snakeCase converts camelCase, PascalCase and kebab-case keys to snake_case,
+// keeping initialisms together: userID becomes user_id, HTTPStatus
+// http_status
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s) + 4)
+	for i, r := range runes {
+		if r == '-' {
+			r = '_'
+		}
+		if unicode.IsUpper(r) {
+			// A word starts at an upper case letter after a lower case
+			// letter or digit, or before a lower case letter ending an
+			// initialism
+			if i > 0 && runes[i-1] != '_' && runes[i-1] != '-' &&
+				(!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyCaseConvert(t *testing.T) {
+	tests := []struct {
+		in    string
+		camel string
+		snake string
+	}{
+		{in: "user_id", camel: "userId", snake: "user_id"},
+		{in: "userID", camel: "userID", snake: "user_id"},
+		{in: "UserName", camel: "userName", snake: "user_name"},
+		{in: "HTTPStatus", camel: "httpStatus", snake: "http_status"},
+		{in: "ID", camel: "id", snake: "id"},
+		{in: "error-id", camel: "errorId", snake: "error_id"},
+		{in: "address2_line", camel: "address2Line", snake: "address2_line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.camel, KeyCaseCamel.convert(tt.in))
+			assert.Equal(t, tt.snake, KeyCaseSnake.convert(tt.in))
+			assert.Equal(t, tt.in, KeyCaseDefault.convert(tt.in))
+		})
+	}
+}
+
+func TestDefaultResponseHandler_KeyCase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type order struct {
+		OrderID   int64    `json:"order_id"`
+		LineItems []string `json:"line_items"`
+		Shipping  struct {
+			PostalCode string `json:"postal_code"`
+		} `json:"shipping_address"`
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, &DefaultResponseHandler{KeyCase: KeyCaseCamel})
+
+	router := gin.New()
+	router.GET("/order", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (order, error) {
+		o := order{OrderID: 9007199254740993, LineItems: []string{"a_b"}}
+		o.Shipping.PostalCode = "10115"
+		return o, nil
+	}))
+	router.GET("/missing", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return nil, ErrNotFound
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/order", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"success","data":{"orderId":9007199254740993,"lineItems":["a_b"],"shippingAddress":{"postalCode":"10115"}}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"status":"error","code":"NOT_FOUND","message":"record not found"}`, w.Body.String())
+}
@@ -0,0 +1,291 @@
+// Package clientgen generates typed TypeScript and Go clients for the routes
+// registered through the routers of a ginbinding builder.
+//
+// The routes carry the struct tags the binder uses, so each field of a
+// request is sent where the handler binds it from: path parameters in the
+// URL, form and query fields in the query string, header fields as headers
+// and the other fields in the JSON body. Generation typically runs in a small
+// program or test that registers the routes without serving them:
+//
+//	r := ginbinding.NewRouter(gin.New(), builder)
+//	api.Register(r)
+//
+//	var ts bytes.Buffer
+//	err := clientgen.TypeScript(&ts, builder.Routes())
+//
+// The clients expect the envelope of ginbinding.DefaultResponseHandler: they
+// return the "data" of success responses and turn error responses into
+// errors carrying their code and message.
+//
+// Routes with multipart file or part fields are left out of the clients,
+// with a comment. The host, TLS and trailer fields of requests, which clients
+// do not set, are left out of the requests, and so are the map fields bound
+// from the query or from headers with a wildcard name. GET and HEAD requests
+// are sent without body.
+package clientgen
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	ginbinding "github.com/zgs225/gin-form-binding"
+)
+
+var (
+	timeTy          = reflect.TypeOf(time.Time{})
+	durationTy      = reflect.TypeOf(time.Duration(0))
+	rawMessageTy    = reflect.TypeOf(json.RawMessage(nil))
+	numberTy        = reflect.TypeOf(json.Number(""))
+	stringerTy      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	textMarshalerTy = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerTy = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// operation is a route of the generated clients
+type operation struct {
+	route ginbinding.RouteInfo
+	// name is the exported name of the operation, e.g. GetUser
+	name string
+	// params are the fields of the request sent by the clients
+	params []param
+	// skipped explains why the route is left out of the clients, or is empty
+	skipped string
+}
+
+// param is a request field sent by the clients
+type param struct {
+	ginbinding.RouteField
+	// goName is the name of the field in the Go request struct and tsName the
+	// property in the TypeScript request interface, both unique within the
+	// request
+	goName string
+	tsName string
+}
+
+// hasBody reports whether any param of op is sent in the body
+func (op *operation) hasBody() bool {
+	for _, p := range op.params {
+		if p.Source == ginbinding.SourceBody {
+			return true
+		}
+	}
+	return false
+}
+
+// operations returns the operations of routes, with unique names
+func operations(routes []ginbinding.RouteInfo) []*operation {
+	ops := make([]*operation, 0, len(routes))
+	names := map[string]bool{}
+
+	for _, rt := range routes {
+		op := &operation{route: rt}
+		op.name = uniqueName(names, operationName(rt))
+		names[op.name] = true
+
+		goNames := map[string]bool{}
+		for _, f := range rt.Fields {
+			if _, ok := f.Tag.Lookup("file"); ok {
+				op.skipped = "multipart file field " + f.Field
+				break
+			}
+			if _, ok := f.Tag.Lookup("part"); ok {
+				op.skipped = "multipart part field " + f.Field
+				break
+			}
+			if !sentByClients(rt.Method, f) {
+				continue
+			}
+
+			leaf := f.Field[strings.LastIndexByte(f.Field, '.')+1:]
+			goName := uniqueName(goNames, leaf)
+			goNames[goName] = true
+			op.params = append(op.params, param{RouteField: f, goName: goName, tsName: lowerFirst(goName)})
+		}
+
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// sentByClients reports whether the clients send the field f of a request
+// with method
+func sentByClients(method string, f ginbinding.RouteField) bool {
+	ty := f.Type
+	for ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+
+	switch f.Source {
+	case ginbinding.SourcePath:
+		return true
+	case ginbinding.SourceQuery:
+		return ty.Kind() != reflect.Map
+	case ginbinding.SourceHeader:
+		// Pseudo-headers are derived from the request itself
+		return !strings.HasPrefix(f.Name, ":") && !strings.HasSuffix(f.Name, "*") && ty.Kind() != reflect.Map
+	case ginbinding.SourceBody:
+		return method != http.MethodGet && method != http.MethodHead
+	}
+	return false
+}
+
+// operationName names the operation of rt after its handler function, or
+// after its method and path when the handler is a function literal
+func operationName(rt ginbinding.RouteInfo) string {
+	if name := handlerName(rt.Handler); name != "" {
+		return name
+	}
+
+	var b strings.Builder
+	b.WriteString(upperFirst(strings.ToLower(rt.Method)))
+	for _, segment := range strings.Split(rt.Path, "/") {
+		if segment == "" {
+			continue
+		}
+		if segment[0] == ':' || segment[0] == '*' {
+			b.WriteString("By")
+			segment = segment[1:]
+		}
+		b.WriteString(identifier(segment))
+	}
+	return b.String()
+}
+
+// handlerName turns the runtime name of a handler function, such as
+// example.com/app.getUser or example.com/app.(*Users).Get-fm, into an
+// exported name like GetUser or UsersGet. It returns "" for function
+// literals.
+func handlerName(handler string) string {
+	name := handler[strings.LastIndexByte(handler, '/')+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	name = strings.ReplaceAll(name, "[...]", "")
+
+	// Drop the package name
+	_, name, ok := strings.Cut(name, ".")
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, part := range strings.Split(name, ".") {
+		part = strings.Trim(part, "(*)")
+		if part == "" || isClosureName(part) {
+			return ""
+		}
+		b.WriteString(identifier(part))
+	}
+	return b.String()
+}
+
+// isClosureName reports whether part of a function name, like func1 or 2,
+// names a function literal
+func isClosureName(part string) bool {
+	digits := strings.TrimPrefix(part, "func")
+	if digits == "" {
+		return false
+	}
+	for _, r := range digits {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// initialisms are the words identifier writes in upper case, as Go names do
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "IP": true, "JSON": true,
+	"SQL": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// identifier turns s into an exported identifier, dropping the characters
+// that cannot be part of one and capitalizing the words they separated, or
+// upper casing them when they are initialisms
+func identifier(s string) string {
+	var b strings.Builder
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(upperFirst(word))
+	}
+	return b.String()
+}
+
+// uniqueName returns name, or name followed by the first number making it
+// absent from used
+func uniqueName(used map[string]bool, name string) string {
+	if !used[name] {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := name + strconv.Itoa(i)
+		if !used[candidate] {
+			return candidate
+		}
+	}
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// lowerFirst lowers the leading initialism or letter of an exported Go name,
+// e.g. ID to id, OrgID to orgID and URLPath to urlPath
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	// Keep the last capital of an initialism followed by a lower case word
+	if n > 1 && n < len(runes) && unicode.IsLower(runes[n]) {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// jsonName returns the JSON key of the struct field sf and whether it is
+// omitted when empty, or "" if it is not marshaled
+func jsonName(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = sf.Name
+	}
+	return name, strings.Contains(","+opts+",", ",omitempty,")
+}
+
+// isStringParam reports whether values of ty are sent as their string form
+// in the URL or headers, like ginbinding.Sort and ginbinding.Filter, rather
+// than as the Go type they hold
+func isStringParam(ty reflect.Type) bool {
+	if ty.Kind() <= reflect.Complex128 || ty.Kind() == reflect.String {
+		return false
+	}
+	return implements(ty, stringerTy) || implements(ty, textMarshalerTy)
+}
+
+func implements(ty, iface reflect.Type) bool {
+	return ty.Implements(iface) || reflect.PointerTo(ty).Implements(iface)
+}
@@ -0,0 +1,145 @@
+package clientgen
+
+import (
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	ginbinding "github.com/zgs225/gin-form-binding"
+)
+
+type User struct {
+	ID      int       `json:"id"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+	Manager *User     `json:"manager,omitempty"`
+}
+
+type getUserRequest struct {
+	ID     int    `path:"id" binding:"required"`
+	Locale string `form:"locale" default:"en" example:"fr" doc:"Language of the names"`
+	Tenant string `header:"X-Tenant" deprecated:"true"`
+	Host   string `host:"subdomain"`
+}
+
+type createUserRequest struct {
+	OrgID string   `path:"org_id"`
+	Name  string   `json:"name" binding:"required"`
+	Tags  []string `json:"tags,omitempty" deprecated:"use labels"`
+}
+
+type listUsersRequest struct {
+	ginbinding.Pagination
+	Sort ginbinding.Sort   `form:"sort"`
+	Tags []string          `form:"tag"`
+	Meta map[string]string `header:"X-Meta-*"`
+}
+
+type uploadRequest struct {
+	File *multipart.FileHeader `file:"file"`
+}
+
+func getUser(ctx context.Context, req getUserRequest) (User, error) {
+	return User{ID: req.ID}, nil
+}
+
+func createUser(ctx context.Context, req createUserRequest) (*User, error) {
+	return &User{Name: req.Name}, nil
+}
+
+func listUsers(ctx context.Context, req listUsersRequest) ([]User, error) {
+	return nil, nil
+}
+
+func upload(ctx context.Context, req uploadRequest) (any, error) {
+	return nil, nil
+}
+
+// testRoutes registers routes covering every placement of request fields
+func testRoutes(t *testing.T) []ginbinding.RouteInfo {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	r := ginbinding.NewRouter(gin.New(), builder).Group("/api")
+	r.GET("/users/:id", getUser)
+	r.POST("/orgs/:org_id/users", createUser)
+	r.GET("/users", listUsers)
+	r.DELETE("/users/:id", func(ctx context.Context, req struct {
+		ID int `path:"id"`
+	}) error {
+		return nil
+	})
+	r.POST("/files", upload)
+	return builder.Routes()
+}
+
+func TestOperations(t *testing.T) {
+	ops := operations(testRoutes(t))
+	assert.Len(t, ops, 5)
+
+	names := make([]string, 0, len(ops))
+	for _, op := range ops {
+		names = append(names, op.name)
+	}
+	assert.Equal(t, []string{"GetUser", "CreateUser", "ListUsers", "DeleteAPIUsersByID", "Upload"}, names)
+
+	sources := func(op *operation) map[string]string {
+		out := map[string]string{}
+		for _, p := range op.params {
+			out[p.goName] = p.Source + ":" + p.Name
+		}
+		return out
+	}
+
+	t.Run("placement", func(t *testing.T) {
+		assert.Equal(t, map[string]string{"ID": "path:id", "Locale": "query:locale", "Tenant": "header:X-Tenant"}, sources(ops[0]))
+		assert.Equal(t, map[string]string{"OrgID": "path:org_id", "Name": "body:name", "Tags": "body:tags"}, sources(ops[1]))
+		assert.False(t, ops[0].hasBody())
+		assert.True(t, ops[1].hasBody())
+	})
+
+	t.Run("embedded and maps", func(t *testing.T) {
+		params := sources(ops[2])
+		assert.Equal(t, "query:sort", params["Sort"])
+		assert.Contains(t, params, "Page")
+		assert.NotContains(t, params, "Meta")
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		assert.Empty(t, ops[0].skipped)
+		assert.Equal(t, "multipart file field File", ops[4].skipped)
+	})
+}
+
+func TestNames(t *testing.T) {
+	for handler, want := range map[string]string{
+		"example.com/app.getUser":                "GetUser",
+		"example.com/app.(*Users).Get-fm":        "UsersGet",
+		"example.com/app.list[...]":              "List",
+		"example.com/app.TestRoutes.func1":       "",
+		"example.com/app.register.func2.1":       "",
+		"example.com/app/v2.(*userAPI).funcs-fm": "UserAPIFuncs",
+		"main":                                   "",
+	} {
+		assert.Equal(t, want, handlerName(handler), handler)
+	}
+
+	for name, want := range map[string]string{
+		"ID":      "id",
+		"OrgID":   "orgID",
+		"URLPath": "urlPath",
+		"Name":    "name",
+		"X":       "x",
+	} {
+		assert.Equal(t, want, lowerFirst(name), name)
+	}
+
+	assert.Equal(t, "GetAPIUsersByID", operationName(ginbinding.RouteInfo{Method: http.MethodGet, Path: "/api/users/:id"}))
+	assert.Equal(t, "GetFilesByFilepath", operationName(ginbinding.RouteInfo{Method: http.MethodGet, Path: "/files/*filepath"}))
+	assert.Equal(t, "GetUser2", uniqueName(map[string]bool{"GetUser": true}, "GetUser"))
+}
@@ -0,0 +1,482 @@
+package clientgen
+
+import (
+	"fmt"
+	"go/format"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	ginbinding "github.com/zgs225/gin-form-binding"
+)
+
+// goPrelude declares the client, its error type and the helpers of the
+// methods of Go clients, after the package clause and imports
+const goPrelude = `
+// Client calls the routes of the API
+type Client struct {
+	// BaseURL is prepended to the paths of the routes, e.g.
+	// https://api.example.com
+	BaseURL string
+	// HTTPClient sends the requests, or http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client calling the API at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL}
+}
+
+// Error is returned for the error responses of the API
+type Error struct {
+	StatusCode int    ` + "`json:\"-\"`" + `
+	Code       string ` + "`json:\"code\"`" + `
+	Message    string ` + "`json:\"message\"`" + `
+	Source     string ` + "`json:\"source\"`" + `
+	Field      string ` + "`json:\"field\"`" + `
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// do sends a request and decodes the data of the response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	target := strings.TrimSuffix(c.BaseURL, "/") + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var envelope struct {
+		Status string          ` + "`json:\"status\"`" + `
+		Data   json.RawMessage ` + "`json:\"data\"`" + `
+		Error
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &envelope); err != nil && resp.StatusCode < http.StatusBadRequest {
+			return err
+		}
+	}
+	if resp.StatusCode >= http.StatusBadRequest || envelope.Status == "error" {
+		e := envelope.Error
+		e.StatusCode = resp.StatusCode
+		if e.Message == "" {
+			e.Message = http.StatusText(resp.StatusCode)
+		}
+		return &e
+	}
+	if out == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
+
+// addValue adds the string forms of v to a query or header under key, one
+// per element of slices. Nil pointers are skipped, and so are zero values
+// unless keepZero is set.
+func addValue(add func(key, value string), key string, v any, keepZero bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return
+		}
+		rv, keepZero = rv.Elem(), true
+	}
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < rv.Len(); i++ {
+			addValue(add, key, rv.Index(i).Interface(), true)
+		}
+		return
+	}
+	if !rv.IsValid() || (!keepZero && rv.IsZero()) {
+		return
+	}
+	add(key, formatValue(rv.Interface()))
+}
+
+// formatValue returns the string form of v, preferring its text encoding
+func formatValue(v any) string {
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return fmt.Sprint(v)
+}
+`
+
+// goImports are the packages used by goPrelude
+var goImports = []string{"bytes", "context", "encoding", "encoding/json", "fmt", "io", "net/http", "net/url", "reflect", "strings"}
+
+// Go writes the source of a Go package named pkg with a Client type calling
+// routes, with a method per route, and the types of their requests and
+// responses. Struct types are declared under their Go names, numbered when
+// names collide, with the json tags of the original fields. The source is
+// formatted like gofmt.
+func Go(w io.Writer, pkg string, routes []ginbinding.RouteInfo) error {
+	ops := operations(routes)
+	types := &goTypes{names: map[reflect.Type]string{}, used: map[string]bool{"Client": true, "Error": true, "NewClient": true}}
+
+	// Request types are named after their operation, ahead of the types they
+	// refer to
+	requestNames := make(map[*operation]string, len(ops))
+	for _, op := range ops {
+		if op.skipped == "" && len(op.params) > 0 {
+			requestNames[op] = types.reserve(op.name + "Request")
+		}
+	}
+
+	var methods strings.Builder
+	var requests strings.Builder
+	for _, op := range ops {
+		if op.skipped != "" {
+			fmt.Fprintf(&methods, "\n// %s %s is not generated: %s\n", op.route.Method, op.route.Path, op.skipped)
+			continue
+		}
+
+		reqName := requestNames[op]
+		args := "ctx context.Context"
+		if reqName != "" {
+			fmt.Fprintf(&requests, "\n// %s is the request of %s %s\ntype %s struct {\n", reqName, op.route.Method, op.route.Path, reqName)
+			for _, p := range op.params {
+				tag := "-"
+				if p.Source == ginbinding.SourceBody {
+					tag = p.Name
+					if _, omitEmpty := jsonName(reflect.StructField{Name: p.goName, Tag: p.Tag}); omitEmpty {
+						tag += ",omitempty"
+					}
+				}
+				requests.WriteString(goDoc(p))
+				fmt.Fprintf(&requests, "\t%s %s `json:%s` // %s %s\n", p.goName, types.renderParam(p), strconv.Quote(tag), p.Source, p.Name)
+			}
+			requests.WriteString("}\n")
+			args += ", req " + reqName
+		}
+
+		var resp string
+		if op.route.ResponseType != nil {
+			resp = types.render(op.route.ResponseType)
+		}
+
+		fmt.Fprintf(&methods, "\n// %s calls %s %s\n", op.name, op.route.Method, op.route.Path)
+		if resp == "" {
+			fmt.Fprintf(&methods, "func (c *Client) %s(%s) error {\n", op.name, args)
+		} else {
+			fmt.Fprintf(&methods, "func (c *Client) %s(%s) (%s, error) {\n", op.name, args, resp)
+		}
+
+		query, header := "nil", "nil"
+		for _, source := range []string{ginbinding.SourceQuery, ginbinding.SourceHeader} {
+			first := true
+			for _, p := range op.params {
+				if p.Source != source {
+					continue
+				}
+				if first {
+					if source == ginbinding.SourceQuery {
+						methods.WriteString("\tquery := url.Values{}\n")
+						query = "query"
+					} else {
+						methods.WriteString("\theader := http.Header{}\n")
+						header = "header"
+					}
+					first = false
+				}
+				fmt.Fprintf(&methods, "\taddValue(%s.Add, %q, req.%s, %t)\n", source, p.Name, p.goName, p.Required)
+			}
+		}
+
+		body := "nil"
+		if op.hasBody() {
+			body = "req"
+		}
+		call := fmt.Sprintf("c.do(ctx, %q, %s, %s, %s, %s", op.route.Method, goPath(op), query, header, body)
+		if resp == "" {
+			fmt.Fprintf(&methods, "\treturn %s, nil)\n}\n", call)
+		} else {
+			fmt.Fprintf(&methods, "\tvar out %s\n\terr := %s, &out)\n\treturn out, err\n}\n", resp, call)
+		}
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "// Code generated by clientgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	imports := goImports
+	if types.usesTime {
+		imports = append(imports[:len(imports):len(imports)], "time")
+	}
+	for _, path := range imports {
+		fmt.Fprintf(&out, "\t%q\n", path)
+	}
+	out.WriteString(")\n")
+	out.WriteString(goPrelude)
+	out.WriteString(methods.String())
+	out.WriteString(requests.String())
+	for _, decl := range types.decls {
+		out.WriteString("\n" + decl)
+	}
+
+	src, err := format.Source([]byte(out.String()))
+	if err != nil {
+		return fmt.Errorf("clientgen: format Go client: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// goDoc returns the doc comment of a request field from the doc, example and
+// deprecated tags of p, or ""
+func goDoc(p param) string {
+	var paragraphs []string
+	doc := p.Doc
+	if p.Example != "" {
+		if doc != "" {
+			doc += ", e.g. "
+		} else {
+			doc = "For example "
+		}
+		doc += p.Example
+	}
+	if doc != "" {
+		paragraphs = append(paragraphs, doc)
+	}
+	if p.Deprecated {
+		note := p.DeprecationNote
+		if note == "" {
+			note = p.goName + " is deprecated by the API."
+		}
+		paragraphs = append(paragraphs, "Deprecated: "+note)
+	}
+	if len(paragraphs) == 0 {
+		return ""
+	}
+	return "\t// " + strings.Join(paragraphs, "\n\t//\n\t// ") + "\n"
+}
+
+// goPath returns the Go expression of the path of op
+func goPath(op *operation) string {
+	var parts []string
+	literal := ""
+	for _, segment := range strings.Split(op.route.Path, "/")[1:] {
+		literal += "/"
+		if segment == "" || (segment[0] != ':' && segment[0] != '*') {
+			literal += segment
+			continue
+		}
+		for _, p := range op.params {
+			if p.Source != ginbinding.SourcePath || p.Name != segment[1:] {
+				continue
+			}
+			parts = append(parts, strconv.Quote(literal))
+			literal = ""
+			if segment[0] == '*' {
+				parts = append(parts, fmt.Sprintf("strings.TrimPrefix(formatValue(req.%s), \"/\")", p.goName))
+			} else {
+				parts = append(parts, fmt.Sprintf("url.PathEscape(formatValue(req.%s))", p.goName))
+			}
+			break
+		}
+	}
+	if literal != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// goTypes renders the types of routes as Go types of the client package,
+// declaring a type for every named struct type
+type goTypes struct {
+	names    map[reflect.Type]string
+	used     map[string]bool
+	decls    []string
+	usesTime bool
+}
+
+// reserve returns a unique type name based on name and marks it as used
+func (t *goTypes) reserve(name string) string {
+	name = uniqueName(t.used, name)
+	t.used[name] = true
+	return name
+}
+
+// renderParam renders the type of a request param. Types with a string form,
+// such as ginbinding.Sort, are sent as strings outside the body, and the
+// params with a default, outside the path, are pointers so that their zero
+// values can be sent.
+func (t *goTypes) renderParam(p param) string {
+	if p.Source == ginbinding.SourceBody {
+		return t.render(p.Type)
+	}
+
+	ty := p.Type
+	pointer := p.Default != "" && !p.Required
+	for ty.Kind() == reflect.Pointer {
+		ty, pointer = ty.Elem(), true
+	}
+	var rendered string
+	if ty != timeTy && isStringParam(ty) {
+		rendered = "string"
+	} else if ty.Kind() != reflect.Slice || ty.Elem().Kind() == reflect.Uint8 {
+		rendered = t.render(ty)
+	} else {
+		pointer = false
+		elem := ty.Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if elem != timeTy && isStringParam(elem) {
+			rendered = "[]string"
+		} else {
+			rendered = "[]" + t.render(elem)
+		}
+	}
+	if rendered == "" {
+		rendered = "string"
+	}
+	if pointer && p.Source != ginbinding.SourcePath {
+		return "*" + rendered
+	}
+	return rendered
+}
+
+// render returns the Go type of the client for ty, or "" if ty is not
+// encoded in JSON
+func (t *goTypes) render(ty reflect.Type) string {
+	switch ty {
+	case timeTy:
+		t.usesTime = true
+		return "time.Time"
+	case durationTy:
+		t.usesTime = true
+		return "time.Duration"
+	case rawMessageTy:
+		return "json.RawMessage"
+	case numberTy:
+		return "json.Number"
+	}
+	if ty.Kind() != reflect.Pointer && ty.Kind() != reflect.Interface {
+		if implements(ty, jsonMarshalerTy) {
+			return "json.RawMessage"
+		}
+		if implements(ty, textMarshalerTy) {
+			return "string"
+		}
+	}
+
+	switch ty.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return ty.Kind().String()
+	case reflect.Interface:
+		return "any"
+	case reflect.Pointer:
+		if elem := t.render(ty.Elem()); elem != "" {
+			return "*" + elem
+		}
+	case reflect.Slice:
+		if elem := t.render(ty.Elem()); elem != "" {
+			return "[]" + elem
+		}
+	case reflect.Array:
+		if elem := t.render(ty.Elem()); elem != "" {
+			return fmt.Sprintf("[%d]%s", ty.Len(), elem)
+		}
+	case reflect.Map:
+		key, elem := t.render(ty.Key()), t.render(ty.Elem())
+		if key != "" && elem != "" {
+			return "map[" + key + "]" + elem
+		}
+	case reflect.Struct:
+		if ty.Name() == "" {
+			return "struct {\n" + t.fields(ty) + "}"
+		}
+		if name, ok := t.names[ty]; ok {
+			return name
+		}
+		name := t.reserve(identifier(ty.Name()))
+		t.names[ty] = name
+		t.decls = append(t.decls, fmt.Sprintf("// %s is the JSON encoding of %s\ntype %s struct {\n%s}\n", name, ty, name, t.fields(ty)))
+		return name
+	}
+	return ""
+}
+
+// fields returns the field declarations of the JSON encoding of the struct
+// type ty, keeping its embedded structs embedded
+func (t *goTypes) fields(ty reflect.Type) string {
+	var b strings.Builder
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if name, _ := jsonName(sf); name == "" {
+			continue
+		}
+
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && embedded.Name() != "" {
+				// Only plain structs promote their fields in JSON
+				if rendered := t.render(embedded); rendered == t.names[embedded] {
+					if sf.Type.Kind() == reflect.Pointer {
+						rendered = "*" + rendered
+					}
+					b.WriteString("\t" + rendered + "\n")
+					continue
+				}
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		rendered := t.render(sf.Type)
+		if rendered == "" {
+			continue
+		}
+		b.WriteString("\t" + sf.Name + " " + rendered)
+		if tag, ok := sf.Tag.Lookup("json"); ok {
+			b.WriteString(" `json:" + strconv.Quote(tag) + "`")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
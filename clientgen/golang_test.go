@@ -0,0 +1,57 @@
+package clientgen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGo(t *testing.T) {
+	var out strings.Builder
+	assert.NoError(t, Go(&out, "api", testRoutes(t)))
+	src := out.String()
+
+	t.Run("type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "client.go", src, parser.ParseComments)
+		if !assert.NoError(t, err) {
+			return
+		}
+		conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+		_, err = conf.Check("api", fset, []*ast.File{file}, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		assert.Contains(t, src, "func (c *Client) GetUser(ctx context.Context, req GetUserRequest) (User, error) {")
+		assert.Contains(t, src, `err := c.do(ctx, "GET", "/api/users/"+url.PathEscape(formatValue(req.ID)), query, header, nil, &out)`)
+		assert.Contains(t, src, `addValue(header.Add, "X-Tenant", req.Tenant, false)`)
+		assert.Contains(t, src, `c.do(ctx, "POST", "/api/orgs/"+url.PathEscape(formatValue(req.OrgID))+"/users", nil, nil, req, &out)`)
+		assert.Contains(t, src, "func (c *Client) DeleteAPIUsersByID(ctx context.Context, req DeleteAPIUsersByIDRequest) error {")
+		assert.Contains(t, src, "// POST /api/files is not generated: multipart file field File")
+	})
+
+	t.Run("requests", func(t *testing.T) {
+		// Params with a default are pointers so that zero values can be sent
+		assert.Contains(t, src, "\t// Language of the names, e.g. fr\n\tLocale *string `json:\"-\"` // query locale")
+		assert.Contains(t, src, "\t// Deprecated: Tenant is deprecated by the API.\n\tTenant string")
+		assert.Contains(t, src, "\t// Deprecated: use labels\n\tTags []string `json:\"tags,omitempty\"` // body tags")
+		assert.Contains(t, src, "Sort     string   `json:\"-\"` // query sort")
+		assert.NotContains(t, src, "Meta")
+		assert.NotContains(t, src, "SortField")
+	})
+
+	t.Run("types", func(t *testing.T) {
+		assert.Contains(t, src, "type User struct {\n\tID      int       `json:\"id\"`\n\tName    string    `json:\"name\"`\n\tCreated time.Time `json:\"created\"`\n\tManager *User     `json:\"manager,omitempty\"`\n}")
+	})
+
+	t.Run("invalid package name", func(t *testing.T) {
+		assert.Error(t, Go(&strings.Builder{}, "not a name", nil))
+	})
+}
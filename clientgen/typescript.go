@@ -0,0 +1,328 @@
+package clientgen
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	ginbinding "github.com/zgs225/gin-form-binding"
+)
+
+// tsPrelude declares the error type and the request helper of TypeScript
+// clients
+const tsPrelude = `// Code generated by clientgen. DO NOT EDIT.
+
+/** ClientError is thrown for the error responses of the API */
+export class ClientError extends Error {
+  constructor(
+    public readonly status: number,
+    public readonly code: string | undefined,
+    message: string,
+    public readonly source?: string,
+    public readonly field?: string,
+  ) {
+    super(message);
+    this.name = "ClientError";
+  }
+}
+
+type Values = Record<string, unknown>;
+
+function append(target: { append(name: string, value: string): void }, values: Values): void {
+  for (const [name, value] of Object.entries(values)) {
+    for (const v of Array.isArray(value) ? value : [value]) {
+      if (v !== undefined && v !== null) {
+        target.append(name, String(v));
+      }
+    }
+  }
+}
+
+/** Client calls the routes of the API */
+export class Client {
+  constructor(
+    private readonly baseURL: string,
+    private readonly fetchFn: typeof fetch = fetch,
+  ) {}
+
+  private async request<T>(method: string, path: string, query: Values, headers: Values, body: Values | undefined, init?: RequestInit): Promise<T> {
+    const params = new URLSearchParams();
+    append(params, query);
+    const h = new Headers(init?.headers);
+    append(h, headers);
+    let payload: string | undefined;
+    if (body !== undefined) {
+      payload = JSON.stringify(body);
+      h.set("Content-Type", "application/json");
+    }
+
+    const qs = params.toString();
+    const res = await this.fetchFn(this.baseURL + path + (qs ? "?" + qs : ""), { ...init, method, headers: h, body: payload });
+    const text = await res.text();
+    const envelope = text ? JSON.parse(text) : {};
+    if (!res.ok || envelope.status === "error") {
+      throw new ClientError(res.status, envelope.code, envelope.message ?? res.statusText, envelope.source, envelope.field);
+    }
+    return envelope.data as T;
+  }
+`
+
+// TypeScript writes a TypeScript module with a Client class calling routes,
+// with an async method per route, and the interfaces of their requests and
+// responses. Struct types are declared under their Go names, numbered when
+// names collide.
+func TypeScript(w io.Writer, routes []ginbinding.RouteInfo) error {
+	ops := operations(routes)
+	types := &tsTypes{names: map[reflect.Type]string{}, used: map[string]bool{"Client": true, "ClientError": true, "Values": true}}
+
+	var methods strings.Builder
+	var requests strings.Builder
+	for _, op := range ops {
+		fmt.Fprintf(&methods, "\n  /** %s %s */\n", op.route.Method, op.route.Path)
+		if op.skipped != "" {
+			fmt.Fprintf(&methods, "  // %s is not generated: %s\n", lowerFirst(op.name), op.skipped)
+			continue
+		}
+
+		resp := "void"
+		if op.route.ResponseType != nil {
+			resp = types.render(op.route.ResponseType)
+		}
+
+		args := "init?: RequestInit"
+		if len(op.params) > 0 {
+			reqName := types.reserve(op.name + "Request")
+			fmt.Fprintf(&requests, "\n/** %s is the request of %s %s */\nexport interface %s {\n", reqName, op.route.Method, op.route.Path, reqName)
+			for _, p := range op.params {
+				optional := "?"
+				if p.Required || p.Source == ginbinding.SourcePath {
+					optional = ""
+				}
+				requests.WriteString(tsDoc(p))
+				fmt.Fprintf(&requests, "  %s%s: %s;\n", p.tsName, optional, types.renderParam(p))
+			}
+			requests.WriteString("}\n")
+			args = "req: " + reqName + ", " + args
+		}
+
+		body := "undefined"
+		if op.hasBody() {
+			body = tsValues(op.params, ginbinding.SourceBody)
+		}
+		fmt.Fprintf(&methods, "  async %s(%s): Promise<%s> {\n", lowerFirst(op.name), args, resp)
+		fmt.Fprintf(&methods, "    return this.request<%s>(%q, %s, %s, %s, %s, init);\n  }\n",
+			resp, op.route.Method, tsPath(op), tsValues(op.params, ginbinding.SourceQuery), tsValues(op.params, ginbinding.SourceHeader), body)
+	}
+
+	var out strings.Builder
+	out.WriteString(tsPrelude)
+	out.WriteString(methods.String())
+	out.WriteString("}\n")
+	out.WriteString(requests.String())
+	for _, decl := range types.decls {
+		out.WriteString("\n" + decl)
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// tsPath returns the TypeScript expression of the path of op
+func tsPath(op *operation) string {
+	var b strings.Builder
+	b.WriteString("`")
+	for _, segment := range strings.Split(op.route.Path, "/")[1:] {
+		b.WriteString("/")
+		if segment == "" || (segment[0] != ':' && segment[0] != '*') {
+			b.WriteString(segment)
+			continue
+		}
+		name := segment[1:]
+		for _, p := range op.params {
+			if p.Source == ginbinding.SourcePath && p.Name == name {
+				if segment[0] == '*' {
+					fmt.Fprintf(&b, "${String(req.%s).replace(/^\\//, \"\")}", p.tsName)
+				} else {
+					fmt.Fprintf(&b, "${encodeURIComponent(String(req.%s))}", p.tsName)
+				}
+				break
+			}
+		}
+	}
+	b.WriteString("`")
+	return b.String()
+}
+
+// tsDoc returns the JSDoc comment of a request property from the doc, example
+// and deprecated tags of p, or ""
+func tsDoc(p param) string {
+	var lines []string
+	if p.Doc != "" {
+		lines = append(lines, p.Doc)
+	}
+	if p.Example != "" {
+		lines = append(lines, "@example "+p.Example)
+	}
+	if p.Deprecated {
+		lines = append(lines, strings.TrimSpace("@deprecated "+p.DeprecationNote))
+	}
+
+	switch len(lines) {
+	case 0:
+		return ""
+	case 1:
+		return "  /** " + lines[0] + " */\n"
+	}
+	return "  /**\n   * " + strings.Join(lines, "\n   * ") + "\n   */\n"
+}
+
+// tsValues returns the TypeScript object literal of the params from source
+func tsValues(params []param, source string) string {
+	var entries []string
+	for _, p := range params {
+		if p.Source == source {
+			entries = append(entries, fmt.Sprintf("%s: req.%s", strconv.Quote(p.Name), p.tsName))
+		}
+	}
+	if len(entries) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(entries, ", ") + " }"
+}
+
+// tsTypes renders Go types as TypeScript types, declaring an interface for
+// every named struct type
+type tsTypes struct {
+	names map[reflect.Type]string
+	used  map[string]bool
+	decls []string
+}
+
+// reserve returns a unique type name based on name and marks it as used
+func (t *tsTypes) reserve(name string) string {
+	name = uniqueName(t.used, name)
+	t.used[name] = true
+	return name
+}
+
+// renderParam renders the type of a request param, sending the types with a
+// string form, such as ginbinding.Sort, as strings outside the body
+func (t *tsTypes) renderParam(p param) string {
+	if p.Source == ginbinding.SourceBody {
+		return t.render(p.Type)
+	}
+	ty := p.Type
+	for ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	if isStringParam(ty) {
+		return "string"
+	}
+	if ty.Kind() == reflect.Slice && ty.Elem().Kind() != reflect.Uint8 {
+		elem := ty.Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if isStringParam(elem) {
+			return "Array<string>"
+		}
+		return "Array<" + t.render(elem) + ">"
+	}
+	return t.render(ty)
+}
+
+// render returns the TypeScript type of the JSON encoding of ty
+func (t *tsTypes) render(ty reflect.Type) string {
+	switch ty {
+	case timeTy:
+		return "string"
+	case durationTy, numberTy:
+		return "number"
+	case rawMessageTy:
+		return "unknown"
+	}
+	if ty.Kind() != reflect.Pointer && ty.Kind() != reflect.Interface {
+		if implements(ty, jsonMarshalerTy) {
+			return "unknown"
+		}
+		if implements(ty, textMarshalerTy) {
+			return "string"
+		}
+	}
+
+	switch ty.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Pointer:
+		return t.render(ty.Elem()) + " | null"
+	case reflect.Slice, reflect.Array:
+		if ty.Kind() == reflect.Slice && ty.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded in base64
+			return "string"
+		}
+		return "Array<" + t.render(ty.Elem()) + ">"
+	case reflect.Map:
+		return "Record<string, " + t.render(ty.Elem()) + ">"
+	case reflect.Struct:
+		if ty.Name() == "" {
+			return "{ " + strings.Join(t.fields(ty), " ") + " }"
+		}
+		if name, ok := t.names[ty]; ok {
+			return name
+		}
+		name := t.reserve(identifier(ty.Name()))
+		t.names[ty] = name
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "/** %s is the JSON encoding of %s */\nexport interface %s {\n", name, ty, name)
+		for _, field := range t.fields(ty) {
+			b.WriteString("  " + field + "\n")
+		}
+		b.WriteString("}\n")
+		t.decls = append(t.decls, b.String())
+		return name
+	}
+	return "unknown"
+}
+
+// fields returns the property declarations of the JSON encoding of the
+// struct type ty, including the fields promoted from embedded structs
+func (t *tsTypes) fields(ty reflect.Type) []string {
+	var fields []string
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		name, omitEmpty := jsonName(sf)
+		if name == "" {
+			continue
+		}
+
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, t.fields(embedded)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		optional := ""
+		if omitEmpty {
+			optional = "?"
+		}
+		fields = append(fields, fmt.Sprintf("%s%s: %s;", strconv.Quote(name), optional, t.render(sf.Type)))
+	}
+	return fields
+}
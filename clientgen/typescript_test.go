@@ -0,0 +1,41 @@
+package clientgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeScript(t *testing.T) {
+	var out strings.Builder
+	assert.NoError(t, TypeScript(&out, testRoutes(t)))
+	src := out.String()
+
+	t.Run("methods", func(t *testing.T) {
+		assert.Contains(t, src, "async getUser(req: GetUserRequest, init?: RequestInit): Promise<User> {")
+		assert.Contains(t, src, "`/api/users/${encodeURIComponent(String(req.id))}`, { \"locale\": req.locale }, { \"X-Tenant\": req.tenant }, undefined, init);")
+		assert.Contains(t, src, "`/api/orgs/${encodeURIComponent(String(req.orgID))}/users`, {}, {}, { \"name\": req.name, \"tags\": req.tags }, init);")
+		assert.Contains(t, src, "async deleteAPIUsersByID(req: DeleteAPIUsersByIDRequest, init?: RequestInit): Promise<void> {")
+	})
+
+	t.Run("requests", func(t *testing.T) {
+		assert.Contains(t, src, "export interface GetUserRequest {\n  id: number;\n"+
+			"  /**\n   * Language of the names\n   * @example fr\n   */\n  locale?: string;\n"+
+			"  /** @deprecated */\n  tenant?: string;\n}")
+		assert.Contains(t, src, "  name: string;\n  /** @deprecated use labels */\n  tags?: Array<string>;\n")
+		assert.Contains(t, src, "  sort?: string;\n  tags?: Array<string>;\n")
+		assert.NotContains(t, src, "meta")
+	})
+
+	t.Run("types", func(t *testing.T) {
+		assert.Contains(t, src, "export interface User {\n  \"id\": number;\n  \"name\": string;\n  \"created\": string;\n  \"manager\"?: User | null;\n}")
+		assert.Equal(t, 1, strings.Count(src, "export interface User {"))
+		assert.Contains(t, src, "Promise<User | null>")
+		assert.Contains(t, src, "Promise<Array<User>>")
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		assert.Contains(t, src, "// upload is not generated: multipart file field File")
+	})
+}
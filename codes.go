@@ -0,0 +1,116 @@
+package ginbinding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Machine-readable error codes of error responses, stable across releases so
+// that clients can branch on them instead of parsing messages
+const (
+	// CodeBindingMissingField reports a required path, query or header input
+	// that is missing from the request
+	CodeBindingMissingField = "BINDING_MISSING_FIELD"
+	// CodeBindingInvalidType reports an input that cannot be converted to the
+	// type of its field, such as "abc" for an int
+	CodeBindingInvalidType = "BINDING_INVALID_TYPE"
+	// CodeBindingInvalidValue reports an input that is not allowed for its
+	// field, such as a value outside of an enum tag
+	CodeBindingInvalidValue = "BINDING_INVALID_VALUE"
+	// CodeBindingFailed reports any other binding failure, such as a
+	// malformed body
+	CodeBindingFailed = "BINDING_FAILED"
+	// CodeValidationFailed reports a request rejected by the validator
+	CodeValidationFailed = "VALIDATION_FAILED"
+
+	CodeNotFound              = "NOT_FOUND"
+	CodeUnauthorized          = "UNAUTHORIZED"
+	CodeForbidden             = "FORBIDDEN"
+	CodeConflict              = "CONFLICT"
+	CodeTooManyRequests       = "TOO_MANY_REQUESTS"
+	CodePreconditionFailed    = "PRECONDITION_FAILED"
+	CodeUnsupportedMediaType  = "UNSUPPORTED_MEDIA_TYPE"
+	CodeMethodNotAllowed      = "METHOD_NOT_ALLOWED"
+	CodeRequestEntityTooLarge = "REQUEST_ENTITY_TOO_LARGE"
+	CodeURITooLong            = "URI_TOO_LONG"
+	CodeResponseTooLarge      = "RESPONSE_TOO_LARGE"
+	CodeTimeout               = "TIMEOUT"
+	CodeCanceled              = "CANCELED"
+
+	// CodeHandlerError reports any other error returned by the handler
+	CodeHandlerError = "HANDLER_ERROR"
+)
+
+// sentinelErrorCodes maps the sentinel and context errors to their codes
+var sentinelErrorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrNotFound, CodeNotFound},
+	{ErrUnauthorized, CodeUnauthorized},
+	{ErrForbidden, CodeForbidden},
+	{ErrConflict, CodeConflict},
+	{ErrTooManyRequests, CodeTooManyRequests},
+	{ErrPreconditionFailed, CodePreconditionFailed},
+	{ErrUnsupportedMediaType, CodeUnsupportedMediaType},
+	{ErrMethodNotAllowed, CodeMethodNotAllowed},
+	{ErrRequestEntityTooLarge, CodeRequestEntityTooLarge},
+	{ErrURITooLong, CodeURITooLong},
+	{ErrResponseTooLarge, CodeResponseTooLarge},
+	{context.DeadlineExceeded, CodeTimeout},
+	{context.Canceled, CodeCanceled},
+}
+
+// ErrorCode returns the machine-readable code of err for error responses.
+// Errors anywhere in the chain implementing ErrorCoder choose their own code;
+// otherwise the code is derived from the kind of binding or validation
+// failure, or from the sentinel error err wraps, and is CodeHandlerError for
+// any other error.
+func ErrorCode(err error) string {
+	var coder ErrorCoder
+	if errors.As(err, &coder) {
+		return coder.ErrorCode()
+	}
+
+	var validationErrs validator.ValidationErrors
+	var ve *ValidationError
+	if errors.As(err, &validationErrs) || errors.As(err, &ve) {
+		return CodeValidationFailed
+	}
+
+	var bindingErr *BindingError
+	if errors.As(err, &bindingErr) {
+		return bindingErrorCode(bindingErr)
+	}
+
+	for _, s := range sentinelErrorCodes {
+		if errors.Is(err, s.err) {
+			return s.code
+		}
+	}
+	return CodeHandlerError
+}
+
+// bindingErrorCode classifies a binding failure
+func bindingErrorCode(err *BindingError) string {
+	var numErr *strconv.NumError
+	var typeErr *json.UnmarshalTypeError
+	var timeErr *time.ParseError
+	if errors.As(err, &numErr) || errors.As(err, &typeErr) || errors.As(err, &timeErr) {
+		return CodeBindingInvalidType
+	}
+
+	var fe *fieldError
+	if errors.As(err, &fe) {
+		if fe.missing {
+			return CodeBindingMissingField
+		}
+		return CodeBindingInvalidValue
+	}
+	return CodeBindingFailed
+}
@@ -0,0 +1,88 @@
+package ginbinding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type quotaCodeError struct{}
+
+func (quotaCodeError) Error() string     { return "quota exceeded" }
+func (quotaCodeError) ErrorCode() string { return "QUOTA_EXCEEDED" }
+
+func TestErrorCode(t *testing.T) {
+	_, numErr := strconv.Atoi("abc")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "handler error", err: errors.New("boom"), expected: CodeHandlerError},
+		{name: "sentinel", err: fmt.Errorf("user 1: %w", ErrNotFound), expected: CodeNotFound},
+		{name: "timeout", err: fmt.Errorf("handler timed out: %w", context.DeadlineExceeded), expected: CodeTimeout},
+		{name: "custom code", err: fmt.Errorf("wrapped: %w", quotaCodeError{}), expected: "QUOTA_EXCEEDED"},
+		{name: "invalid type", err: &BindingError{Err: numErr}, expected: CodeBindingInvalidType},
+		{name: "missing field", err: &BindingError{Err: &fieldError{path: "ID", err: errors.New("missing"), missing: true}}, expected: CodeBindingMissingField},
+		{name: "invalid value", err: &BindingError{Err: &fieldError{path: "Status", err: errors.New("not allowed")}}, expected: CodeBindingInvalidValue},
+		{name: "binding failed", err: &BindingError{Err: errors.New("unexpected EOF")}, expected: CodeBindingFailed},
+		{name: "validator", err: &ValidationError{Err: errors.New("validation failed")}, expected: CodeValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ErrorCode(tt.err))
+		})
+	}
+}
+
+func TestErrorCode_Response(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	router := gin.New()
+	router.POST("/items", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Name string `json:"name" binding:"required"`
+	}) error {
+		return nil
+	}))
+	router.GET("/items", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Status string `form:"status" enum:"active,archived"`
+	}) error {
+		return nil
+	}))
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		body         string
+		expectedCode string
+	}{
+		{name: "validation", method: "POST", target: "/items", body: `{}`, expectedCode: CodeValidationFailed},
+		{name: "malformed body", method: "POST", target: "/items", body: `{"name":`, expectedCode: CodeBindingFailed},
+		{name: "enum", method: "GET", target: "/items?status=deleted", expectedCode: CodeBindingInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), `"code":"`+tt.expectedCode+`"`)
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package ginbinding
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultCompressionMinSize is the size in bytes from which WithCompression
+// compresses responses when CompressionConfig.MinSize is zero
+const DefaultCompressionMinSize = 1024
+
+// CompressionConfig configures WithCompression
+type CompressionConfig struct {
+	// MinSize is the response size in bytes from which responses are
+	// compressed, DefaultCompressionMinSize when zero
+	MinSize int
+	// Level is the level of the built-in gzip encoder, as defined by
+	// compress/gzip; zero means gzip.DefaultCompression
+	Level int
+	// Encoders are content codings offered in addition to the built-in gzip,
+	// in order of server preference. gzip comes after them unless one of them
+	// is named gzip, which then replaces the built-in encoder.
+	Encoders []ContentEncoder
+}
+
+// ContentEncoder is a content coding which WithCompression can apply to
+// responses, such as br
+type ContentEncoder struct {
+	// Name is the coding as listed in the Accept-Encoding and
+	// Content-Encoding headers
+	Name string
+	// NewWriter returns a writer compressing to w. Closing it must flush the
+	// remaining data without closing w.
+	NewWriter func(w io.Writer) io.WriteCloser
+}
+
+// WithCompression compresses success responses of at least config.MinSize
+// bytes with the coding the client prefers in its Accept-Encoding header.
+// Responses are buffered up to MinSize, so smaller responses are sent as is;
+// responses flushed before reaching it, such as streamed responses, are
+// compressed since their final size is unknown. Error responses, responses
+// which already have a Content-Encoding and 204 and 206 responses are never
+// compressed. Every response carries Vary: Accept-Encoding, and the ETag of
+// compressed responses is made weak.
+//
+//	r.GET("/reports", builder.MustFormBindingGinHandlerFunc(listReports,
+//		ginbinding.WithCompression(ginbinding.CompressionConfig{MinSize: 4096})))
+func WithCompression(config CompressionConfig) Option {
+	level := config.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+		panic(fmt.Sprintf("ginbinding: WithCompression: %v", err))
+	}
+
+	c := &compression{minSize: config.MinSize}
+	if c.minSize <= 0 {
+		c.minSize = DefaultCompressionMinSize
+	}
+	c.gzipPool.New = func() any {
+		w, _ := gzip.NewWriterLevel(nil, level)
+		return w
+	}
+
+	builtin := true
+	for _, encoder := range config.Encoders {
+		if encoder.Name == "" || encoder.NewWriter == nil {
+			panic("ginbinding: WithCompression needs a name and a NewWriter for every encoder")
+		}
+		if strings.EqualFold(encoder.Name, "gzip") {
+			builtin = false
+		}
+		c.encoders = append(c.encoders, encoder)
+	}
+	if builtin {
+		c.encoders = append(c.encoders, ContentEncoder{Name: "gzip", NewWriter: c.newGzipWriter})
+	}
+
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.compression = c
+	}
+}
+
+// compression is the configuration of WithCompression
+type compression struct {
+	minSize  int
+	encoders []ContentEncoder
+	gzipPool sync.Pool
+}
+
+// newGzipWriter returns a pooled gzip writer compressing to w
+func (c *compression) newGzipWriter(w io.Writer) io.WriteCloser {
+	gz := c.gzipPool.Get().(*gzip.Writer)
+	gz.Reset(w)
+	return &pooledGzipWriter{Writer: gz, pool: &c.gzipPool}
+}
+
+// pooledGzipWriter returns its gzip writer to the pool once closed
+type pooledGzipWriter struct {
+	*gzip.Writer
+	pool *sync.Pool
+}
+
+func (w *pooledGzipWriter) Close() error {
+	err := w.Writer.Close()
+	w.pool.Put(w.Writer)
+	return err
+}
+
+// wrap installs a compressing writer on ctx when the client accepts one of
+// the configured codings. The returned writer must be finished once the
+// response has been written; it is nil when responses are not compressed.
+func (c *compression) wrap(ctx *gin.Context) *compressWriter {
+	ctx.Writer.Header().Add("Vary", "Accept-Encoding")
+	encoder, ok := negotiateEncoding(ctx.GetHeader("Accept-Encoding"), c.encoders)
+	if !ok {
+		return nil
+	}
+
+	w := &compressWriter{ResponseWriter: ctx.Writer, minSize: c.minSize, encoder: encoder}
+	ctx.Writer = w
+	return w
+}
+
+// negotiateEncoding returns the encoder with the highest quality value in the
+// Accept-Encoding header, preferring earlier encoders on ties. It reports
+// false when the client accepts none of them.
+func negotiateEncoding(header string, encoders []ContentEncoder) (ContentEncoder, bool) {
+	if header == "" {
+		return ContentEncoder{}, false
+	}
+
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(param, "=")
+			if strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = v
+			}
+		}
+		qualities[coding] = q
+	}
+
+	var best ContentEncoder
+	bestQ := 0.0
+	for _, encoder := range encoders {
+		q, ok := qualities[strings.ToLower(encoder.Name)]
+		if !ok {
+			q = qualities["*"]
+		}
+		if q > bestQ {
+			best, bestQ = encoder, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// compressWriter buffers the response until minSize bytes have been written,
+// then decides whether to send it compressed. Headers are held back until the
+// decision, since compressing changes them.
+type compressWriter struct {
+	gin.ResponseWriter
+
+	minSize   int
+	encoder   ContentEncoder
+	buf       []byte
+	headerNow bool
+	decided   bool
+	// enc is nil while undecided and when the response is not compressed
+	enc io.WriteCloser
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf = append(w.buf, data...)
+	if len(w.buf) >= w.minSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	w.headerNow = true
+}
+
+func (w *compressWriter) Written() bool {
+	if w.decided {
+		return w.ResponseWriter.Written()
+	}
+	return w.headerNow || len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+func (w *compressWriter) Size() int {
+	if w.decided || len(w.buf) == 0 {
+		return w.ResponseWriter.Size()
+	}
+	return len(w.buf)
+}
+
+// Flush sends the response written so far, compressing it since its final
+// size is unknown
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(true)
+	}
+	if flusher, ok := w.enc.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide sets the headers of the response, compressed if compress is set and
+// the response qualifies, and writes the buffered data
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+	header := w.ResponseWriter.Header()
+	status := w.ResponseWriter.Status()
+	if compress && status >= http.StatusOK && status < http.StatusMultipleChoices &&
+		status != http.StatusNoContent && status != http.StatusPartialContent &&
+		header.Get("Content-Encoding") == "" {
+		header.Set("Content-Encoding", w.encoder.Name)
+		header.Del("Content-Length")
+		if etag := header.Get("ETag"); strings.HasPrefix(etag, `"`) {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.enc = w.encoder.NewWriter(w.ResponseWriter)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		if w.headerNow {
+			w.ResponseWriter.WriteHeaderNow()
+		}
+		return nil
+	}
+	var err error
+	if w.enc != nil {
+		_, err = w.enc.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// finish sends what is left of the response and restores the writer it
+// wrapped on ctx
+func (w *compressWriter) finish(ctx *gin.Context) {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.enc != nil {
+		if err := w.enc.Close(); err != nil {
+			_ = ctx.Error(err)
+		}
+	}
+	ctx.Writer = w.ResponseWriter
+}
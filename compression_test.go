@@ -0,0 +1,205 @@
+package ginbinding
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCompression(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	large := strings.Repeat("compressible ", 200)
+	deflate := ContentEncoder{Name: "deflate", NewWriter: func(w io.Writer) io.WriteCloser {
+		fw, _ := flate.NewWriter(w, flate.BestSpeed)
+		return fw
+	}}
+
+	calls := 0
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.GET("/large", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return large, nil
+	}, WithCompression(CompressionConfig{})))
+	router.GET("/small", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return "small", nil
+	}, WithCompression(CompressionConfig{})))
+	router.GET("/deflate", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return large, nil
+	}, WithCompression(CompressionConfig{MinSize: 10, Encoders: []ContentEncoder{deflate}})))
+	router.GET("/error", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return nil, errors.New(large)
+	}, WithCompression(CompressionConfig{MinSize: 10})))
+	router.GET("/etag", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return large, nil
+	}, WithCompression(CompressionConfig{}), WithETag(func(data any) string { return "v1" })))
+	router.GET("/cached", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		calls++
+		return large, nil
+	}, WithCompression(CompressionConfig{}), WithCache(time.Minute, nil, nil)))
+	router.GET("/stream", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (iter.Seq[int], error) {
+		return func(yield func(int) bool) {
+			for i := 1; i <= 3; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}, nil
+	}, WithCompression(CompressionConfig{}), WithStreamFlushEvery(1)))
+
+	get := func(path, acceptEncoding string, header ...string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+	gunzip := func(t *testing.T, body io.Reader) map[string]interface{} {
+		r, err := gzip.NewReader(body)
+		require.NoError(t, err)
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(r).Decode(&response))
+		return response
+	}
+
+	t.Run("large response", func(t *testing.T) {
+		w := get("/large", "br;q=1, gzip;q=0.8")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		assert.Less(t, w.Body.Len(), len(large))
+		assert.Equal(t, large, gunzip(t, w.Body)["data"])
+	})
+
+	t.Run("small response", func(t *testing.T) {
+		w := get("/small", "gzip")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		assert.Contains(t, w.Body.String(), `"small"`)
+	})
+
+	t.Run("not accepted", func(t *testing.T) {
+		for _, accept := range []string{"", "identity", "gzip;q=0", "br", "*;q=0"} {
+			w := get("/large", accept)
+
+			assert.Equal(t, http.StatusOK, w.Code, accept)
+			assert.Empty(t, w.Header().Get("Content-Encoding"), accept)
+			assert.Contains(t, w.Body.String(), large, accept)
+		}
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		w := get("/large", "*")
+
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, gunzip(t, w.Body)["data"])
+	})
+
+	t.Run("custom encoder", func(t *testing.T) {
+		w := get("/deflate", "gzip, deflate")
+		assert.Equal(t, "deflate", w.Header().Get("Content-Encoding"))
+
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(flate.NewReader(w.Body)).Decode(&response))
+		assert.Equal(t, large, response["data"])
+
+		w = get("/deflate", "gzip, deflate;q=0.5")
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		w := get("/error", "gzip")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Contains(t, w.Body.String(), "compressible")
+	})
+
+	t.Run("etag", func(t *testing.T) {
+		w := get("/etag", "gzip")
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, `W/"v1"`, w.Header().Get("ETag"))
+
+		w = get("/etag", "gzip", "If-None-Match", `W/"v1"`)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("streamed response", func(t *testing.T) {
+		w := get("/stream", "gzip")
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+
+		r, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "[1,2,3]", string(body))
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		w := get("/cached", "gzip")
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, gunzip(t, w.Body)["data"])
+
+		w = get("/cached", "")
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Contains(t, w.Body.String(), large)
+
+		w = get("/cached", "gzip")
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, gunzip(t, w.Body)["data"])
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	encoders := []ContentEncoder{{Name: "br"}, {Name: "gzip"}}
+
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"GZIP", "gzip"},
+		{"gzip, br", "br"},
+		{"gzip;q=1, br;q=0.5", "gzip"},
+		{"br;q=0, *", "gzip"},
+		{"*;q=0.1, gzip;q=0.2", "gzip"},
+		{"deflate, identity", ""},
+		{"gzip; q=0", ""},
+	}
+	for _, tt := range tests {
+		encoder, ok := negotiateEncoding(tt.header, encoders)
+		assert.Equal(t, tt.want != "", ok, tt.header)
+		assert.Equal(t, tt.want, encoder.Name, tt.header)
+	}
+}
+
+func TestWithCompressionInvalidConfig(t *testing.T) {
+	assert.Panics(t, func() { WithCompression(CompressionConfig{Level: 42}) })
+	assert.Panics(t, func() {
+		WithCompression(CompressionConfig{Encoders: []ContentEncoder{{Name: "br"}}})
+	})
+}
@@ -0,0 +1,65 @@
+package ginbinding
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// concurrencyRetryAfter is the Retry-After value, in seconds, sent when a
+// handler rejects a request because it is saturated
+const concurrencyRetryAfter = 1
+
+// WithMaxConcurrency limits the number of requests a handler serves at the
+// same time to n. Requests beyond the limit are rejected immediately with an
+// error wrapping ErrTooManyRequests (429 with the default response handler)
+// and a Retry-After header. Each handler built with the option gets its own
+// limit. A zero or negative n disables the limit.
+//
+// With WithTimeout, a request whose function is still running after the
+// timeout keeps its slot until the function returns.
+func WithMaxConcurrency(n int) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.maxConcurrency = n
+	}
+}
+
+// concurrencyLimiter is a non-blocking semaphore guarding a single handler
+type concurrencyLimiter struct {
+	sem chan struct{}
+}
+
+// newConcurrencyLimiter returns a limiter admitting n concurrent requests, or
+// nil when n disables the limit
+func newConcurrencyLimiter(n int) *concurrencyLimiter {
+	if n <= 0 {
+		return nil
+	}
+	return &concurrencyLimiter{sem: make(chan struct{}, n)}
+}
+
+// acquire takes a slot, or sets Retry-After on the response and returns an
+// error when none is free
+func (l *concurrencyLimiter) acquire(ctx *gin.Context) error {
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	default:
+		ctx.Header("Retry-After", strconv.Itoa(concurrencyRetryAfter))
+		return fmt.Errorf("handler is at its concurrency limit of %d: %w", cap(l.sem), ErrTooManyRequests)
+	}
+}
+
+// releaseAfter frees the slot, once running is closed if the handler function
+// outlived its timeout and is still running
+func (l *concurrencyLimiter) releaseAfter(running <-chan struct{}) {
+	if running == nil {
+		<-l.sem
+		return
+	}
+	go func() {
+		<-running
+		<-l.sem
+	}()
+}
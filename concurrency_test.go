@@ -0,0 +1,141 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMaxConcurrency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	handler := func(c *gin.Context) (interface{}, error) {
+		started <- struct{}{}
+		<-unblock
+		return gin.H{"report": "ready"}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler, WithMaxConcurrency(1))
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/report", ginHandler)
+
+	first := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req, _ := http.NewRequest("GET", "/report", nil)
+		router.ServeHTTP(first, req)
+	}()
+	<-started
+
+	// The only slot is taken, so the second request is shed
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/report", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "handler is at its concurrency limit of 1: too many requests", response["message"])
+
+	close(unblock)
+	<-done
+	assert.Equal(t, http.StatusOK, first.Code)
+
+	// The slot is released once the first request completes
+	go func() { <-started }()
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/report", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestWithMaxConcurrencyTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	unblock := make(chan struct{})
+	returned := make(chan struct{}, 100)
+	handler := func(c *gin.Context) (interface{}, error) {
+		defer func() { returned <- struct{}{} }()
+		<-unblock
+		return nil, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithMaxConcurrency(1), WithTimeout(10*time.Millisecond))
+	router := gin.New()
+	router.GET("/report", builder.MustFormBindingGinHandlerFunc(handler))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/report", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+
+	// The timed out function still runs and holds the only slot
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/report", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// The slot is released once the function returns
+	unblock <- struct{}{}
+	<-returned
+	close(unblock)
+	assert.Eventually(t, func() bool {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/report", nil)
+		router.ServeHTTP(w, req)
+		return w.Code == http.StatusOK
+	}, time.Second, time.Millisecond)
+}
+
+func TestWithMaxConcurrencyPerHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithMaxConcurrency(1))
+
+	unblock := make(chan struct{})
+	slow, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context) (interface{}, error) {
+		<-unblock
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	fast, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context) (interface{}, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/slow", slow)
+	router.GET("/fast", fast)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req, _ := http.NewRequest("GET", "/slow", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+
+	// A saturated handler does not affect other handlers of the same builder
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/fast", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	close(unblock)
+	<-done
+}
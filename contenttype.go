@@ -0,0 +1,45 @@
+package ginbinding
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithAllowedContentTypes restricts the media types of request bodies, e.g.
+// to "application/json", instead of letting gin pick a binding for any
+// Content-Type. Wildcard subtypes such as "multipart/*" are supported.
+// Requests with a body of any other type are rejected with
+// ErrUnsupportedMediaType (415) through the response handler. Requests
+// without a body are not checked.
+func WithAllowedContentTypes(types ...string) Option {
+	allowed := make([]string, len(types))
+	for i, t := range types {
+		allowed[i] = strings.ToLower(strings.TrimSpace(t))
+	}
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.allowedContentTypes = allowed
+	}
+}
+
+// checkContentType fails when the request has a body whose media type is not
+// allowed by WithAllowedContentTypes
+func (builder *BasicFormBindingGinHandlerBuilder) checkContentType(ctx *gin.Context) error {
+	if builder.allowedContentTypes == nil || ctx.Request.ContentLength == 0 {
+		return nil
+	}
+
+	contentType := strings.ToLower(ctx.ContentType())
+	if slices.ContainsFunc(builder.allowedContentTypes, func(allowed string) bool {
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok {
+			return strings.HasPrefix(contentType, prefix+"/")
+		}
+		return contentType == allowed
+	}) {
+		return nil
+	}
+
+	return fmt.Errorf("content type %q is not one of [%s]: %w", contentType, strings.Join(builder.allowedContentTypes, ", "), ErrUnsupportedMediaType)
+}
@@ -0,0 +1,54 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAllowedContentTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	router := gin.New()
+	router.POST("/items", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Name string `json:"name" form:"name"`
+	}) error {
+		return nil
+	}, WithAllowedContentTypes("application/json", "multipart/*")))
+
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{name: "allowed", contentType: "application/json", body: `{"name":"box"}`, expectedStatus: http.StatusOK},
+		{name: "parameters and case", contentType: "Application/JSON; charset=utf-8", body: `{"name":"box"}`, expectedStatus: http.StatusOK},
+		{name: "wildcard", contentType: "multipart/form-data; boundary=x", body: "--x\r\nContent-Disposition: form-data; name=\"name\"\r\n\r\nbox\r\n--x--\r\n", expectedStatus: http.StatusOK},
+		{name: "not allowed", contentType: "application/x-www-form-urlencoded", body: "name=box", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "missing", body: `{"name":"box"}`, expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "no body", contentType: "text/plain", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/items", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus == http.StatusUnsupportedMediaType {
+				assert.Contains(t, w.Body.String(), `"code":"UNSUPPORTED_MEDIA_TYPE"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package ginbinding
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextTestKey struct{}
+
+func TestContextFirstHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(ctx context.Context, req struct {
+		ID int `path:"id"`
+	}) (interface{}, error) {
+		return gin.H{"id": req.ID, "trace": ctx.Value(contextTestKey{})}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/users/:id", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/5", nil)
+	req = req.WithContext(context.WithValue(req.Context(), contextTestKey{}, "abc"))
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": float64(5), "trace": "abc"}, response["data"])
+}
+
+func TestContextFirstHandlerWithTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler, WithTimeout(10*time.Millisecond))
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/slow", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/slow", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+}
+
+func TestContextFreeHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type greetRequest struct {
+		Name string `form:"name" default:"world"`
+	}
+	greet := func(req greetRequest) (string, error) {
+		return "hello " + req.Name, nil
+	}
+
+	// Pure handlers can be called directly
+	msg, err := greet(greetRequest{Name: "go"})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello go", msg)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(greet)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/greet", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/greet", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", response["data"])
+}
+
+func TestContextFreeHandlerInvalid(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(name string) error { return nil })
+	assert.EqualError(t, err, "first parameter must be *gin.Context, context.Context or a struct or pointer to struct")
+
+	_, err = builder.FormBindingGinHandlerFunc(func(req struct{}, body struct{}, extra struct{}) error { return nil })
+	assert.EqualError(t, err, "parameter 3: no provider registered for struct {}")
+}
@@ -0,0 +1,107 @@
+package ginbinding
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig configures the CORS headers Router adds with WithCORS
+type CORSConfig struct {
+	// AllowOrigins lists the origins allowed to call the routes. "*" allows
+	// any origin, and cannot be combined with AllowCredentials.
+	AllowOrigins []string
+	// AllowHeaders lists the request headers allowed in preflight responses.
+	// When empty, the headers requested by the preflight request are allowed.
+	AllowHeaders []string
+	// ExposeHeaders lists the response headers readable by the browser
+	ExposeHeaders []string
+	// AllowCredentials allows requests with cookies and HTTP authentication
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache preflight responses, if not zero
+	MaxAge time.Duration
+}
+
+// WithCORS makes Router answer CORS preflight requests to the paths it
+// registers and add CORS headers to the responses of their handlers, so that
+// routes of typed handlers need no separate CORS middleware. Preflight
+// responses allow the methods registered for the requested path. Requests
+// from origins not in AllowOrigins get no CORS headers, which makes browsers
+// reject them. WithCORS panics when AllowOrigins contains "*" and
+// AllowCredentials is set, which would let any site make credentialed
+// requests; list the trusted origins instead. Passed to a single Router
+// registration, the configuration applies to the requests of that method and
+// to the preflight requests asking for it.
+//
+//	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil, ginbinding.WithCORS(ginbinding.CORSConfig{
+//		AllowOrigins: []string{"https://app.example.com"},
+//		MaxAge:       time.Hour,
+//	}))
+//	r := ginbinding.NewRouter(engine.Group("/api"), builder)
+func WithCORS(config CORSConfig) Option {
+	if config.AllowCredentials && slices.Contains(config.AllowOrigins, "*") {
+		panic(`ginbinding: WithCORS cannot allow credentials from any origin "*"`)
+	}
+	config.AllowOrigins = slices.Clone(config.AllowOrigins)
+	config.AllowHeaders = slices.Clone(config.AllowHeaders)
+	config.ExposeHeaders = slices.Clone(config.ExposeHeaders)
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.cors = &config
+	}
+}
+
+// isPreflight reports whether the request of ctx is a CORS preflight request
+func isPreflight(ctx *gin.Context) bool {
+	return ctx.Request.Method == http.MethodOptions &&
+		ctx.GetHeader("Origin") != "" &&
+		ctx.GetHeader("Access-Control-Request-Method") != ""
+}
+
+// setHeaders adds the CORS headers for the request of ctx to a path allowing
+// methods. It reports whether the origin of the request is allowed.
+func (c *CORSConfig) setHeaders(ctx *gin.Context, methods string, preflight bool) bool {
+	origin := ctx.GetHeader("Origin")
+	if origin == "" {
+		return false
+	}
+
+	anyOrigin := slices.Contains(c.AllowOrigins, "*")
+	if !anyOrigin && !slices.Contains(c.AllowOrigins, origin) {
+		return false
+	}
+
+	// Credentialed requests need the origin itself, and are never allowed
+	// from any origin
+	if anyOrigin {
+		ctx.Header("Access-Control-Allow-Origin", "*")
+	} else {
+		ctx.Header("Access-Control-Allow-Origin", origin)
+		ctx.Writer.Header().Add("Vary", "Origin")
+	}
+	if c.AllowCredentials && !anyOrigin {
+		ctx.Header("Access-Control-Allow-Credentials", "true")
+	}
+
+	if !preflight {
+		if len(c.ExposeHeaders) > 0 {
+			ctx.Header("Access-Control-Expose-Headers", strings.Join(c.ExposeHeaders, ", "))
+		}
+		return true
+	}
+
+	ctx.Header("Access-Control-Allow-Methods", methods)
+	if len(c.AllowHeaders) > 0 {
+		ctx.Header("Access-Control-Allow-Headers", strings.Join(c.AllowHeaders, ", "))
+	} else if requested := ctx.GetHeader("Access-Control-Request-Headers"); requested != "" {
+		ctx.Header("Access-Control-Allow-Headers", requested)
+		ctx.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+	}
+	if c.MaxAge > 0 {
+		ctx.Header("Access-Control-Max-Age", strconv.Itoa(int(c.MaxAge/time.Second)))
+	}
+	return true
+}
@@ -0,0 +1,138 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithCORS(CORSConfig{
+		AllowOrigins:     []string{"https://app.example.com"},
+		ExposeHeaders:    []string{"X-Request-Id"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	}))
+
+	engine := gin.New()
+	r := NewRouter(engine.Group("/api"), builder)
+	r.GET("/items", func(c *gin.Context) (string, error) { return "ok", nil })
+	r.POST("/items", func(c *gin.Context) error { return nil })
+
+	t.Run("preflight", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodOptions, "/api/items", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "Content-Type")
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, OPTIONS, POST", w.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "3600", w.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("actual request", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "X-Request-Id", w.Header().Get("Access-Control-Expose-Headers"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("origin not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodOptions, "/api/items", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("any origin", func(t *testing.T) {
+		engine := gin.New()
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithCORS(CORSConfig{AllowOrigins: []string{"*"}}))
+		NewRouter(engine, builder).GET("/items", func(c *gin.Context) (string, error) { return "ok", nil })
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		req.Header.Set("Origin", "https://other.example.com")
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestWithCORSAnyOriginCredentials(t *testing.T) {
+	assert.Panics(t, func() {
+		WithCORS(CORSConfig{AllowOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true})
+	})
+	assert.NotPanics(t, func() {
+		WithCORS(CORSConfig{AllowOrigins: []string{"*"}})
+	})
+
+	// Credentials are never sent to any origin either way
+	config := &CORSConfig{AllowOrigins: []string{"*"}, AllowCredentials: true}
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/items", nil)
+	ctx.Request.Header.Set("Origin", "https://evil.example.com")
+
+	assert.True(t, config.setHeaders(ctx, "GET", false))
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestWithCORSRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	r := NewRouter(engine, NewBasicFormBindingGinHandlerBuilder(nil, nil))
+	r.GET("/items", func(c *gin.Context) (string, error) { return "ok", nil },
+		WithCORS(CORSConfig{AllowOrigins: []string{"https://app.example.com"}}))
+	r.DELETE("/items", func(c *gin.Context) error { return nil })
+
+	send := func(method, requestMethod string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/items", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		if requestMethod != "" {
+			req.Header.Set("Access-Control-Request-Method", requestMethod)
+		}
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodOptions, http.MethodGet)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "DELETE, GET, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
+
+	w = send(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+
+	// The DELETE handler was registered without WithCORS
+	w = send(http.MethodOptions, http.MethodDelete)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = send(http.MethodDelete, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
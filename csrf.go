@@ -0,0 +1,114 @@
+package ginbinding
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CSRFCookieName is the cookie holding the random value CSRF tokens are
+// derived from
+const CSRFCookieName = "csrf"
+
+// CSRFTokenSource extracts the CSRF token sent with a request
+type CSRFTokenSource func(ctx *gin.Context) string
+
+// CSRFHeader reads CSRF tokens from the header name, for requests sent by
+// scripts
+func CSRFHeader(name string) CSRFTokenSource {
+	return func(ctx *gin.Context) string {
+		return ctx.GetHeader(name)
+	}
+}
+
+// CSRFFormField reads CSRF tokens from the form field name of urlencoded and
+// multipart bodies, for HTML forms
+func CSRFFormField(name string) CSRFTokenSource {
+	return func(ctx *gin.Context) string {
+		return ctx.PostForm(name)
+	}
+}
+
+// WithCSRF protects form handlers against cross-site request forgery. The
+// token read by source from requests with other methods than GET, HEAD and
+// OPTIONS must be the one CSRFToken issued with secret for the CSRF cookie of
+// the client; requests without a valid token are rejected with ErrForbidden
+// (403) through the response handler before the request is bound:
+//
+//	csrf := ginbinding.WithCSRF(ginbinding.CSRFFormField("csrf_token"), secret)
+//	r.GET("/profile", func(c *gin.Context) {
+//		c.HTML(http.StatusOK, "profile.html", gin.H{"csrf": ginbinding.CSRFToken(c, secret)})
+//	})
+//	r.POST("/profile", builder.MustFormBindingGinHandlerFunc(updateProfile, csrf))
+//
+// It panics if secret is empty.
+func WithCSRF(source CSRFTokenSource, secret []byte) Option {
+	if len(secret) == 0 {
+		panic("ginbinding: WithCSRF needs a secret")
+	}
+	csrf := &csrfCheck{source: source, secret: secret}
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.csrf = csrf
+	}
+}
+
+// CSRFToken returns the CSRF token to send with the forms of the client of
+// ctx, setting its CSRF cookie first if it has none
+func CSRFToken(ctx *gin.Context, secret []byte) string {
+	value, err := ctx.Cookie(CSRFCookieName)
+	if err != nil || value == "" {
+		b := make([]byte, 32)
+		_, _ = rand.Read(b)
+		value = base64.RawURLEncoding.EncodeToString(b)
+		http.SetCookie(ctx.Writer, &http.Cookie{
+			Name:     CSRFCookieName,
+			Value:    value,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   ctx.Request.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+		// Later calls for the same request return the same token
+		ctx.Request.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: value})
+	}
+	return csrfSign(secret, value)
+}
+
+// csrfSign derives the CSRF token of the cookie value
+func csrfSign(secret []byte, value string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// csrfCheck is the configuration of WithCSRF
+type csrfCheck struct {
+	source CSRFTokenSource
+	secret []byte
+}
+
+// verify checks the CSRF token of the request of ctx
+func (c *csrfCheck) verify(ctx *gin.Context) error {
+	switch ctx.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+
+	value, err := ctx.Cookie(CSRFCookieName)
+	if err != nil || value == "" {
+		return fmt.Errorf("missing CSRF cookie: %w", ErrForbidden)
+	}
+	token := c.source(ctx)
+	if token == "" {
+		return fmt.Errorf("missing CSRF token: %w", ErrForbidden)
+	}
+	if !hmac.Equal([]byte(token), []byte(csrfSign(c.secret, value))) {
+		return fmt.Errorf("invalid CSRF token: %w", ErrForbidden)
+	}
+	return nil
+}
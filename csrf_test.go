@@ -0,0 +1,74 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCSRF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	secret := []byte("s3cret")
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	router := gin.New()
+	router.GET("/profile", func(c *gin.Context) {
+		c.String(http.StatusOK, CSRFToken(c, secret))
+	})
+	router.POST("/profile", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Name string `form:"name"`
+	}) (string, error) {
+		return req.Name, nil
+	}, WithCSRF(CSRFFormField("csrf_token"), secret)))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/profile", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	cookie, token := cookies[0], w.Body.String()
+	assert.Equal(t, CSRFCookieName, cookie.Name)
+
+	tests := []struct {
+		name           string
+		cookie         *http.Cookie
+		token          string
+		expectedStatus int
+	}{
+		{name: "valid", cookie: cookie, token: token, expectedStatus: http.StatusOK},
+		{name: "missing token", cookie: cookie, expectedStatus: http.StatusForbidden},
+		{name: "wrong token", cookie: cookie, token: token + "x", expectedStatus: http.StatusForbidden},
+		{name: "missing cookie", token: token, expectedStatus: http.StatusForbidden},
+		{name: "other cookie", cookie: &http.Cookie{Name: CSRFCookieName, Value: "forged"}, token: token, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{"name": {"ann"}}
+			if tt.token != "" {
+				form.Set("csrf_token", tt.token)
+			}
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/profile", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"data":"ann"`)
+			}
+		})
+	}
+
+	assert.Panics(t, func() { WithCSRF(CSRFHeader("X-CSRF-Token"), nil) })
+}
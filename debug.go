@@ -0,0 +1,127 @@
+package ginbinding
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugContextKey is the gin context key under which the *DebugInfo of the
+// request is stored when the builder was configured with WithDebug
+const DebugContextKey = "ginbinding.debug"
+
+// DebugInfo describes the request struct bound for a request
+type DebugInfo struct {
+	// Request is the bound and defaulted request struct
+	Request any
+	// Fields lists every field of Request with the source of its value
+	Fields []DebugField
+}
+
+// DebugField describes a single bound field
+type DebugField struct {
+	// Path is the dotted field path, with embedded struct names omitted
+	Path string
+	// Source is where the value came from, such as "path:id", "query:page",
+	// "header:Authorization", "body:name" or "default"
+	Source string
+	// Value is the bound value of the field
+	Value any
+}
+
+// WithDebug enables debug output for troubleshooting tag mistakes during
+// development. For every request, the bound and defaulted request struct and
+// the source of each field are written to w and stored in the gin context
+// under DebugContextKey. A nil w disables debug output.
+//
+//	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil,
+//		ginbinding.WithDebug(gin.DefaultWriter),
+//	)
+func WithDebug(w io.Writer) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.debugWriter = w
+	}
+}
+
+// GetDebugInfo returns the DebugInfo stored in ctx by a handler built with
+// WithDebug
+func GetDebugInfo(ctx *gin.Context) (*DebugInfo, bool) {
+	info, ok := ctx.Get(DebugContextKey)
+	if !ok {
+		return nil, false
+	}
+	debugInfo, ok := info.(*DebugInfo)
+	return debugInfo, ok
+}
+
+// debugBinding records the bound struct val. bound is a copy of val taken
+// before default values were applied, used to tell defaults from inputs.
+func (builder *BasicFormBindingGinHandlerBuilder) debugBinding(ctx *gin.Context, bound, val reflect.Value) {
+	zeroBeforeDefaults := map[string]bool{}
+	_ = walkFields(bound, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		zeroBeforeDefaults[path] = fieldVal.IsZero()
+		return nil
+	})
+
+	info := &DebugInfo{Request: val.Interface()}
+	_ = walkFields(val, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		if sf.Anonymous {
+			return nil
+		}
+
+		source := fieldSource(sf)
+		if _, ok := sf.Tag.Lookup(builder.defaultTag); ok && zeroBeforeDefaults[path] && !fieldVal.IsZero() {
+			source = "default"
+		}
+
+		info.Fields = append(info.Fields, DebugField{Path: path, Source: source, Value: fieldVal.Interface()})
+		return nil
+	})
+
+	ctx.Set(DebugContextKey, info)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[GIN-binding-debug] %s %s bound %s\n", ctx.Request.Method, ctx.Request.URL.Path, val.Type())
+	for _, f := range info.Fields {
+		fmt.Fprintf(&b, "[GIN-binding-debug]   %s = %#v (%s)\n", f.Path, f.Value, f.Source)
+	}
+	_, _ = io.WriteString(builder.debugWriter, b.String())
+}
+
+// fieldSource names the request input a field is bound from based on its tags
+func fieldSource(sf reflect.StructField) string {
+	for _, tag := range []struct{ key, source string }{
+		{"path", "path"},
+		{"uri", "path"},
+		{"form", "query"},
+		{"query", "query"},
+		{"header", "header"},
+		{"host", "host"},
+		{"tls", "tls"},
+		{"pseudo", "header"},
+		{"trailer", "trailer"},
+		{"file", "body"},
+		{"part", "body"},
+		{"json", "body"},
+	} {
+		if key, ok := sf.Tag.Lookup(tag.key); ok {
+			key, _, _ = strings.Cut(key, ",")
+			if key == "" {
+				key = sf.Name
+			}
+			if tag.key == "header" {
+				key = canonicalHeaderName(key)
+			}
+			return tag.source + ":" + key
+		}
+	}
+
+	if _, ok := sf.Tag.Lookup("ifmatch"); ok {
+		return "header:If-Match"
+	}
+
+	return "body:" + sf.Name
+}
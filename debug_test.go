@@ -0,0 +1,91 @@
+package ginbinding
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDebug(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type debugRequest struct {
+		Pagination
+		UserID    int    `path:"user_id"`
+		Search    string `form:"search"`
+		AuthToken string `header:"Authorization"`
+		Name      string `json:"name"`
+		Role      string `json:"role" default:"member"`
+	}
+
+	var info *DebugInfo
+	handler := func(c *gin.Context, req debugRequest) (interface{}, error) {
+		info, _ = GetDebugInfo(c)
+		return nil, nil
+	}
+
+	var out bytes.Buffer
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithDebug(&out))
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.PUT("/users/:user_id", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/users/7?search=go&page=2", strings.NewReader(`{"name":"John"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer token123")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.NotNil(t, info) {
+		assert.Equal(t, "member", info.Request.(debugRequest).Role)
+		assert.Equal(t, []DebugField{
+			{Path: "Page", Source: "query:page", Value: 2},
+			{Path: "PageSize", Source: "query:page_size", Value: 20},
+			{Path: "Offset", Source: "query:offset", Value: 20},
+			{Path: "Limit", Source: "query:limit", Value: 20},
+			{Path: "UserID", Source: "path:user_id", Value: 7},
+			{Path: "Search", Source: "query:search", Value: "go"},
+			{Path: "AuthToken", Source: "header:Authorization", Value: "Bearer token123"},
+			{Path: "Name", Source: "body:name", Value: "John"},
+			{Path: "Role", Source: "default", Value: "member"},
+		}, info.Fields)
+	}
+
+	assert.Contains(t, out.String(), "[GIN-binding-debug] PUT /users/7 bound ginbinding.debugRequest\n")
+	assert.Contains(t, out.String(), `[GIN-binding-debug]   Role = "member" (default)`)
+}
+
+func TestWithoutDebug(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	found := true
+	handler := func(c *gin.Context, req struct {
+		Name string `form:"name"`
+	}) (interface{}, error) {
+		_, found = GetDebugInfo(c)
+		return nil, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/test", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test?name=go", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, found)
+}
@@ -322,3 +322,113 @@ func TestDefaultValuesWithTimeTypes(t *testing.T) {
 	assert.Equal(t, "2023-01-01T00:00:00Z", data["created_at"])
 	assert.Equal(t, "30s", data["timeout"])
 }
+
+func TestWithDefaultTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Page  int    `form:"page" fallback:"1"`
+		Order string `form:"order" default:"id" fallback:"created_at"`
+	}) (interface{}, error) {
+		return gin.H{"page": req.Page, "order": req.Order}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithDefaultTag("fallback"))
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/items", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/items", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	// The "default" tag is left to other tooling
+	assert.Equal(t, map[string]interface{}{"page": float64(1), "order": "created_at"}, response["data"])
+}
+
+func TestDefaultValuesCheckedAtBuildTime(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	type nested struct {
+		Limit int `json:"limit" default:"ten"`
+	}
+	tests := []struct {
+		name    string
+		handler any
+		err     string
+	}{
+		{"int", func(c *gin.Context, req struct {
+			Age int `json:"age" default:"thirty"`
+		}) error {
+			return nil
+		}, `field Age: invalid default value "thirty": strconv.ParseInt: parsing "thirty": invalid syntax`},
+		{"pointer", func(c *gin.Context, req struct {
+			Active *bool `form:"active" default:"maybe"`
+		}) error {
+			return nil
+		}, `field Active: invalid default value "maybe": invalid boolean value "maybe"`},
+		{"duration", func(c *gin.Context, req struct {
+			Timeout time.Duration `form:"timeout" default:"5 minutes"`
+		}) error {
+			return nil
+		}, `field Timeout: invalid default value "5 minutes": invalid duration "5 minutes": time: unknown unit " minutes" in duration "5 minutes"`},
+		{"uint overflow", func(c *gin.Context, req struct {
+			Limit uint8 `form:"limit" default:"300"`
+		}) error {
+			return nil
+		}, `field Limit: invalid default value "300": 300 overflows uint8`},
+		{"int overflow", func(c *gin.Context, req struct {
+			Offset int8 `form:"offset" default:"-200"`
+		}) error {
+			return nil
+		}, `field Offset: invalid default value "-200": -200 overflows int8`},
+		{"float overflow", func(c *gin.Context, req struct {
+			Ratio float32 `form:"ratio" default:"1e40"`
+		}) error {
+			return nil
+		}, `field Ratio: invalid default value "1e40": 1e+40 overflows float32`},
+		{"nested", func(c *gin.Context, req struct {
+			Page nested `json:"page"`
+		}) error {
+			return nil
+		}, `field Limit: invalid default value "ten": strconv.ParseInt: parsing "ten": invalid syntax`},
+		{"body", func(c *gin.Context, query struct{}, body struct {
+			Count uint `json:"count" default:"-1"`
+		}) error {
+			return nil
+		}, `field Count: invalid default value "-1": strconv.ParseUint: parsing "-1": invalid syntax`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := builder.FormBindingGinHandlerFunc(tt.handler)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Age     int8          `form:"age" default:"127"`
+		Ratio   float32       `form:"ratio" default:"0.5"`
+		Timeout time.Duration `form:"timeout" default:"1m"`
+		Since   time.Time     `form:"since" time_format:"2006-01-02" default:"2024-01-01"`
+		Order   string        `form:"order" default:"thirty"`
+	}) error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// Only the configured default tag is checked
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Age int `form:"age" default:"thirty" fallback:"30"`
+	}) error {
+		return nil
+	}, WithDefaultTag("fallback"))
+	assert.NoError(t, err)
+}
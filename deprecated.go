@@ -0,0 +1,65 @@
+package ginbinding
+
+import (
+	"log/slog"
+	"reflect"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithDeprecationLog logs a warning to logger for every deprecated field
+// supplied with a request, with the method, route, field and note of the
+// deprecated tag, to find the clients still sending old fields
+func WithDeprecationLog(logger *slog.Logger) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.deprecationLog = logger
+	}
+}
+
+// deprecation parses the deprecated tag of sf. The tag marks the field
+// deprecated unless it is a false boolean, and values other than booleans,
+// such as "use new_name", are notes telling clients how to migrate.
+func deprecation(sf reflect.StructField) (deprecated bool, note string) {
+	tag, ok := sf.Tag.Lookup("deprecated")
+	if !ok {
+		return false, ""
+	}
+	if b, err := strconv.ParseBool(tag); err == nil {
+		return b, ""
+	}
+	return true, tag
+}
+
+// deprecatedFields returns the deprecated fields of the struct type ty and of
+// the structs nested in it, except within slices and maps, with the notes of
+// their deprecated tags as values
+func deprecatedFields(ty reflect.Type) []taggedField {
+	return taggedFields(ty, false, func(sf reflect.StructField) (string, bool) {
+		deprecated, note := deprecation(sf)
+		return note, deprecated
+	})
+}
+
+// warnDeprecated adds a Warning header, and logs a warning if configured, for
+// every deprecated field of the struct type ty sent with the request, and
+// marks the response with the Deprecation header
+func (builder *BasicFormBindingGinHandlerBuilder) warnDeprecated(ctx *gin.Context, ty reflect.Type, sent *requestPresence) {
+	for _, f := range typeInfoOf(ty).deprecated {
+		if !sent.has(ty, f.path) {
+			continue
+		}
+
+		text := "deprecated field " + strconv.Quote(f.name)
+		if f.value != "" {
+			text += ": " + f.value
+		}
+		ctx.Writer.Header().Set("Deprecation", "true")
+		ctx.Writer.Header().Add("Warning", "299 - "+strconv.Quote(text))
+
+		if builder.deprecationLog != nil {
+			builder.deprecationLog.WarnContext(ctx.Request.Context(), "deprecated request field",
+				"method", ctx.Request.Method, "route", ctx.FullPath(), "field", f.name, "note", f.value)
+		}
+	}
+}
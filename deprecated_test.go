@@ -0,0 +1,102 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type deprecatedProfile struct {
+	Bio string `json:"bio" deprecated:""`
+}
+
+type deprecatedRequest struct {
+	Name    string            `json:"name"`
+	Nick    string            `json:"nick" deprecated:"use name"`
+	Old     int               `form:"old" deprecated:"true"`
+	Kept    string            `form:"kept" deprecated:"false"`
+	Profile deprecatedProfile `json:"profile"`
+}
+
+func TestDeprecatedFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var logs bytes.Buffer
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithDeprecationLog(slog.New(slog.NewJSONHandler(&logs, nil))))
+
+	router := gin.New()
+	router.POST("/users", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req deprecatedRequest) (any, error) {
+		return req, nil
+	}))
+
+	tests := []struct {
+		name             string
+		target           string
+		body             string
+		expectedWarnings []string
+	}{
+		{name: "none sent", target: "/users?kept=1", body: `{"name":"Ann","profile":{}}`},
+		{
+			name:             "body field",
+			target:           "/users",
+			body:             `{"nick":"ann"}`,
+			expectedWarnings: []string{`299 - "deprecated field \"nick\": use name"`},
+		},
+		{
+			name:   "query and nested fields",
+			target: "/users?old=1",
+			body:   `{"profile":{"bio":"hi"}}`,
+			expectedWarnings: []string{
+				`299 - "deprecated field \"old\""`,
+				`299 - "deprecated field \"profile.bio\""`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedWarnings, w.Header().Values("Warning"))
+			if tt.expectedWarnings == nil {
+				assert.Empty(t, w.Header().Get("Deprecation"))
+				assert.Empty(t, logs.String())
+			} else {
+				assert.Equal(t, "true", w.Header().Get("Deprecation"))
+				assert.Equal(t, len(tt.expectedWarnings), strings.Count(logs.String(), "\n"))
+			}
+		})
+	}
+
+	t.Run("bound and logged", func(t *testing.T) {
+		logs.Reset()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/users", strings.NewReader(`{"nick":"ann"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Contains(t, w.Body.String(), `"nick":"ann"`)
+
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+		assert.Equal(t, "WARN", entry["level"])
+		assert.Equal(t, "deprecated request field", entry["msg"])
+		assert.Equal(t, "/users", entry["route"])
+		assert.Equal(t, "nick", entry["field"])
+		assert.Equal(t, "use name", entry["note"])
+	})
+}
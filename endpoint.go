@@ -0,0 +1,50 @@
+package ginbinding
+
+import (
+	"context"
+	"reflect"
+)
+
+// Endpoint is a handler function exposed both as an HTTP route and as an
+// in-process call, so that services of the same binary call each other
+// without going through HTTP:
+//
+//	getUser := ginbinding.NewEndpoint(r, http.MethodGet, "/users/:id", users.Get)
+//	user, err := getUser.Call(ctx, GetUserRequest{ID: 42})
+type Endpoint[Req, Resp any] struct {
+	builder *BasicFormBindingGinHandlerBuilder
+	fn      func(context.Context, Req) (Resp, error)
+}
+
+// NewEndpoint registers fn on r for method and relativePath, with opts
+// applied to it like Router.Handle does, and returns the Endpoint calling fn
+// in process. It panics if Req is not a valid request type.
+func NewEndpoint[Req, Resp any](r *Router, method, relativePath string, fn func(context.Context, Req) (Resp, error), opts ...Option) *Endpoint[Req, Resp] {
+	r.Handle(method, relativePath, fn, opts...)
+
+	builder := r.builder
+	if len(opts) > 0 {
+		builder = builder.Clone(opts...)
+	}
+	return &Endpoint[Req, Resp]{builder: builder, fn: fn}
+}
+
+// Call runs req through the default, mutate and validate stages of the
+// endpoint's pipeline and calls the handler function with it, without
+// serializing either the request or the response. Custom pipeline stages,
+// hooks and the response handler are not involved, since there is no HTTP
+// request. Like over HTTP, requests failing validation return a
+// BindingError. A nil pointer request is replaced by a new zero request.
+func (e *Endpoint[Req, Resp]) Call(ctx context.Context, req Req) (Resp, error) {
+	form := reflect.ValueOf(&req).Elem()
+	if ty := form.Type(); ty != mapAnyTy {
+		if ty.Kind() == reflect.Pointer && form.IsNil() {
+			form.Set(reflect.New(ty.Elem()))
+		}
+		if err := e.builder.runStages(nil, ty, form, nil); err != nil {
+			var zero Resp
+			return zero, err
+		}
+	}
+	return e.fn(ctx, req)
+}
@@ -0,0 +1,71 @@
+package ginbinding
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type endpointRequest struct {
+	ID     int    `path:"id" binding:"required"`
+	Locale string `form:"locale" default:"en" mod:"lower"`
+}
+
+type endpointResponse struct {
+	ID     int    `json:"id"`
+	Locale string `json:"locale"`
+}
+
+func TestEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	r := NewRouter(engine, NewBasicFormBindingGinHandlerBuilder(nil, nil))
+
+	getUser := NewEndpoint(r, http.MethodGet, "/users/:id", func(ctx context.Context, req endpointRequest) (endpointResponse, error) {
+		return endpointResponse{ID: req.ID, Locale: req.Locale}, nil
+	})
+	getPointer := NewEndpoint(r, http.MethodGet, "/pointers/:id", func(ctx context.Context, req *endpointRequest) (string, error) {
+		return req.Locale, nil
+	})
+
+	t.Run("over HTTP", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users/7?locale=FR", nil)
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status":"success","data":{"id":7,"locale":"fr"}}`, w.Body.String())
+	})
+
+	t.Run("in process", func(t *testing.T) {
+		resp, err := getUser.Call(context.Background(), endpointRequest{ID: 7, Locale: "FR"})
+		assert.NoError(t, err)
+		assert.Equal(t, endpointResponse{ID: 7, Locale: "fr"}, resp)
+
+		resp, err = getUser.Call(context.Background(), endpointRequest{ID: 7})
+		assert.NoError(t, err)
+		assert.Equal(t, "en", resp.Locale)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := getUser.Call(context.Background(), endpointRequest{})
+
+		var bindingErr *BindingError
+		assert.True(t, errors.As(err, &bindingErr))
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		_, err := getPointer.Call(context.Background(), nil)
+		assert.Error(t, err)
+
+		locale, err := getPointer.Call(context.Background(), &endpointRequest{ID: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, "en", locale)
+	})
+}
@@ -0,0 +1,148 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// parseEnum splits an "enum" tag into its allowed values
+func parseEnum(tag string) []string {
+	var values []string
+	for _, v := range strings.Split(tag, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// checkEnums verifies every "enum" tag of ty at handler build time
+func checkEnums(ty reflect.Type) error {
+	return walkTypeFields(ty, func(sf reflect.StructField) error {
+		tag, ok := sf.Tag.Lookup("enum")
+		if !ok {
+			return nil
+		}
+
+		values := parseEnum(tag)
+		if len(values) == 0 {
+			return fmt.Errorf("field %s: enum tag has no values", sf.Name)
+		}
+
+		elemTy := enumElemType(sf.Type)
+		if elemTy == nil {
+			return fmt.Errorf("field %s: enum tag requires a string or integer field, got %s", sf.Name, sf.Type)
+		}
+
+		for _, v := range values {
+			if _, err := enumValueToString(v, elemTy); err != nil {
+				return fmt.Errorf("field %s: invalid enum value %q: %w", sf.Name, v, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// applyEnums rejects non-zero values of "enum" tagged fields that are not in
+// the allowed list. Zero values are left to defaults and required checks.
+func applyEnums(val reflect.Value) error {
+	if !typeInfoOf(val.Type()).enums {
+		return nil
+	}
+
+	return walkFields(val, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		tag, ok := sf.Tag.Lookup("enum")
+		if !ok {
+			return nil
+		}
+		return checkEnumValue(fieldVal, parseEnum(tag), path)
+	})
+}
+
+func checkEnumValue(val reflect.Value, allowed []string, path string) error {
+	switch val.Kind() {
+	case reflect.Pointer:
+		if val.IsNil() {
+			return nil
+		}
+		return checkEnumValue(val.Elem(), allowed, path)
+	case reflect.Slice, reflect.Array:
+		for j := 0; j < val.Len(); j++ {
+			if err := checkEnumValue(val.Index(j), allowed, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if val.IsZero() {
+		return nil
+	}
+
+	s := enumFieldString(val)
+	for _, v := range allowed {
+		if normalized, _ := enumValueToString(v, val.Type()); normalized == s {
+			return nil
+		}
+	}
+
+	return &fieldError{path: path, err: fmt.Errorf("field %s: value %q is not one of [%s]", path, s, strings.Join(allowed, ", ")), value: s}
+}
+
+// enumFieldString formats a string or integer value for comparison with the allowed values
+func enumFieldString(val reflect.Value) string {
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(val.Uint(), 10)
+	default:
+		return val.String()
+	}
+}
+
+// enumValueToString normalizes an allowed enum value the way enumFieldString
+// formats a value of type ty, so that e.g. "007" matches the integer 7
+func enumValueToString(v string, ty reflect.Type) (string, error) {
+	switch ty.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(v, 10, ty.Bits())
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(i, 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(v, 10, ty.Bits())
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatUint(u, 10), nil
+	default:
+		return v, nil
+	}
+}
+
+// enumElemType returns the string or integer type behind ty's pointers and
+// slices, or nil when enum constraints cannot apply to ty
+func enumElemType(ty reflect.Type) reflect.Type {
+	for ty.Kind() == reflect.Pointer || ty.Kind() == reflect.Slice || ty.Kind() == reflect.Array {
+		ty = ty.Elem()
+	}
+
+	// time.Duration is an int64 but is printed as "1s", so it is not supported
+	if ty == durationTy {
+		return nil
+	}
+
+	switch ty.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ty
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Order    string   `form:"order" enum:"asc,desc" default:"asc"`
+		Priority int      `json:"priority" enum:"1,2,3"`
+		Status   *string  `json:"status" enum:"active, inactive"`
+		Tags     []string `json:"tags" enum:"red,green,blue"`
+	}) (interface{}, error) {
+		return gin.H{"order": req.Order, "priority": req.Priority}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/items", ginHandler)
+
+	tests := []struct {
+		name           string
+		query          string
+		body           string
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:           "allowed values",
+			query:          "?order=desc",
+			body:           `{"priority":2,"status":"inactive","tags":["red","blue"]}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "zero values are skipped",
+			query:          "",
+			body:           `{}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid string",
+			query:          "?order=up",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Order: value "up" is not one of [asc, desc]`,
+		},
+		{
+			name:           "invalid integer",
+			body:           `{"priority":5}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Priority: value "5" is not one of [1, 2, 3]`,
+		},
+		{
+			name:           "invalid pointer",
+			body:           `{"status":"deleted"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Status: value "deleted" is not one of [active, inactive]`,
+		},
+		{
+			name:           "invalid slice element",
+			body:           `{"tags":["red","pink"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Tags: value "pink" is not one of [red, green, blue]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/items"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, response["message"])
+			}
+		})
+	}
+}
+
+func TestEnumTagInvalidAtBuildTime(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Priority int `json:"priority" enum:"low,high"`
+	}) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `field Priority: invalid enum value "low"`)
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Ratio float64 `json:"ratio" enum:"0.5,1"`
+	}) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "enum tag requires a string or integer field")
+}
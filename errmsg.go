@@ -0,0 +1,270 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// fieldError attributes a binding error to a field of the request struct,
+// identified by its dotted Go field path. Its message is the message of err.
+type fieldError struct {
+	path string
+	err  error
+	// source is the request input the value came from, if it is not the one
+	// the tags of the field name, such as SourceDefault
+	source string
+	// value is the offending input, if any
+	value string
+	// missing is set when the input of the field is absent from the request
+	missing bool
+}
+
+func (e *fieldError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fieldError) Unwrap() error {
+	return e.err
+}
+
+// inputError attributes a binding error to a request source when the field it
+// was raised for is unknown, such as errors of gin's query and header mapping
+type inputError struct {
+	source string
+	err    error
+}
+
+func (e *inputError) Error() string {
+	return e.err.Error()
+}
+
+func (e *inputError) Unwrap() error {
+	return e.err
+}
+
+// messageError replaces the client message of err with the errmsg tag of the
+// field that caused it
+type messageError struct {
+	err     error
+	message string
+}
+
+func (e *messageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *messageError) Unwrap() error {
+	return e.err
+}
+
+// PublicMessage implements PublicMessager
+func (e *messageError) PublicMessage() string {
+	return e.message
+}
+
+// withErrorMessage attaches the errmsg tag of the field of ty that caused err,
+// if any, as the client message of err
+func withErrorMessage(ty reflect.Type, err error) error {
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	if ty.Kind() != reflect.Struct {
+		return err
+	}
+
+	for _, sf := range errorFields(ty, err) {
+		if message, ok := sf.Tag.Lookup("errmsg"); ok {
+			return &messageError{err: err, message: message}
+		}
+	}
+	return err
+}
+
+// bindingError wraps the binding failure err of a request of type ty in a
+// BindingError reporting the source, key and value of the offending input
+func (builder *BasicFormBindingGinHandlerBuilder) bindingError(ty reflect.Type, err error) *BindingError {
+	bindingErr := &BindingError{Err: builder.clientError(ty, err)}
+
+	var fe *fieldError
+	if errors.As(err, &fe) {
+		bindingErr.Source, bindingErr.Value = fe.source, fe.value
+	}
+
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	if ty.Kind() == reflect.Struct {
+		if fields := errorFields(ty, err); len(fields) > 0 {
+			source, key, _ := strings.Cut(fieldSource(fields[0]), ":")
+			if bindingErr.Source == "" {
+				bindingErr.Source = source
+			}
+			bindingErr.Field = key
+		}
+	}
+
+	// JSON type errors know the full path of nested body fields
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		bindingErr.Source, bindingErr.Field = SourceBody, typeErr.Field
+	}
+
+	var schemaErr *SchemaError
+	if errors.As(err, &schemaErr) && len(schemaErr.Violations) > 0 {
+		bindingErr.Source, bindingErr.Field = SourceBody, schemaErr.Violations[0].Field
+	}
+
+	var limitErr *JSONLimitError
+	if errors.As(err, &limitErr) {
+		bindingErr.Source, bindingErr.Field = SourceBody, limitErr.Field
+	}
+
+	var duplicateErr *DuplicateKeyError
+	if errors.As(err, &duplicateErr) {
+		bindingErr.Source, bindingErr.Field = SourceBody, duplicateErr.Field
+	}
+
+	var ie *inputError
+	if bindingErr.Source == "" && errors.As(err, &ie) {
+		bindingErr.Source = ie.source
+	}
+
+	if bindingErr.Value == "" {
+		bindingErr.Value = errorValue(err)
+	}
+	if builder.redactErrors {
+		bindingErr.Value = ""
+	}
+
+	return bindingErr
+}
+
+// errorValue returns the input err was raised for, if err records it
+func errorValue(err error) string {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return numErr.Num
+	}
+
+	var timeErr *time.ParseError
+	if errors.As(err, &timeErr) {
+		return timeErr.Value
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) && len(validationErrs) > 0 {
+		switch v := reflect.ValueOf(validationErrs[0].Value()); v.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return fmt.Sprint(v.Interface())
+		}
+	}
+
+	return ""
+}
+
+// errorFields returns the fields of ty that err reports as invalid
+func errorFields(ty reflect.Type, err error) []reflect.StructField {
+	var fields []reflect.StructField
+
+	var fe *fieldError
+	if errors.As(err, &fe) {
+		if sf, ok := fieldByPath(ty, strings.Split(fe.path, "."), false); ok {
+			fields = append(fields, sf)
+		} else if sf, ok := fieldByName(ty, fe.path); ok {
+			fields = append(fields, sf)
+		}
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, ve := range validationErrs {
+			// The namespace starts with the name of named request types
+			path := strings.TrimPrefix(ve.StructNamespace(), ty.Name()+".")
+			if sf, ok := fieldByPath(ty, strings.Split(path, "."), false); ok {
+				fields = append(fields, sf)
+			}
+		}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		if sf, ok := fieldByPath(ty, strings.Split(typeErr.Field, "."), true); ok {
+			fields = append(fields, sf)
+		}
+	}
+
+	return fields
+}
+
+// fieldByPath looks up the field at path in the struct type ty, matching Go
+// field names or, if byJSON is set, JSON keys the way encoding/json does
+func fieldByPath(ty reflect.Type, path []string, byJSON bool) (reflect.StructField, bool) {
+	var sf reflect.StructField
+	for _, name := range path {
+		// Drop slice and map indexes such as "Items[0]"
+		name, _, _ = strings.Cut(name, "[")
+
+		for ty.Kind() == reflect.Pointer || ty.Kind() == reflect.Slice || ty.Kind() == reflect.Array || ty.Kind() == reflect.Map {
+			ty = ty.Elem()
+		}
+		if ty.Kind() != reflect.Struct {
+			return reflect.StructField{}, false
+		}
+
+		var ok bool
+		if byJSON {
+			sf, ok = fieldByJSONKey(ty, name)
+		} else {
+			sf, ok = ty.FieldByName(name)
+		}
+		if !ok {
+			return reflect.StructField{}, false
+		}
+		ty = sf.Type
+	}
+	return sf, len(path) > 0
+}
+
+// fieldByJSONKey finds the field of ty decoded from the JSON key: exact match
+// first, then case-insensitive
+func fieldByJSONKey(ty reflect.Type, key string) (reflect.StructField, bool) {
+	var fold reflect.StructField
+	folded := false
+	for _, sf := range reflect.VisibleFields(ty) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		name := jsonFieldName(sf)
+		if name == key {
+			return sf, true
+		}
+		if !folded && strings.EqualFold(name, key) {
+			fold, folded = sf, true
+		}
+	}
+	return fold, folded
+}
+
+// fieldByName finds a field named name anywhere in ty, for errors raised while
+// walking nested structs
+func fieldByName(ty reflect.Type, name string) (reflect.StructField, bool) {
+	var found reflect.StructField
+	ok := false
+	_ = walkTypeFields(ty, func(sf reflect.StructField) error {
+		if !ok && sf.Name == name {
+			found, ok = sf, true
+		}
+		return nil
+	})
+	return found, ok
+}
@@ -0,0 +1,206 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrMsgTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		ID      int    `path:"id" errmsg:"id must be a number"`
+		Age     int    `json:"age" binding:"min=18,max=100" errmsg:"age must be between 18 and 100"`
+		Name    string `json:"name" binding:"required"`
+		Address struct {
+			Zip int `json:"zip" errmsg:"zip must be a number"`
+		} `json:"address"`
+	}) error {
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/users/:id", ginHandler)
+
+	tests := []struct {
+		name            string
+		target          string
+		body            string
+		expectedMessage string
+	}{
+		{
+			name:            "validation",
+			target:          "/users/1",
+			body:            `{"age":12,"name":"John"}`,
+			expectedMessage: "age must be between 18 and 100",
+		},
+		{
+			name:            "path parameter",
+			target:          "/users/abc",
+			body:            `{"age":20,"name":"John"}`,
+			expectedMessage: "id must be a number",
+		},
+		{
+			name:            "nested body type",
+			target:          "/users/1",
+			body:            `{"age":20,"name":"John","address":{"zip":"abc"}}`,
+			expectedMessage: "zip must be a number",
+		},
+		{
+			name:   "field without errmsg",
+			target: "/users/1",
+			body:   `{"age":20}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedMessage != "" {
+				assert.Equal(t, tt.expectedMessage, response["message"])
+			} else {
+				assert.Contains(t, response["message"], "Name")
+			}
+		})
+	}
+}
+
+type errMsgRequest struct {
+	Email string `form:"email" binding:"required,email" errmsg:"please enter a valid email address"`
+}
+
+func TestErrMsgTag_NamedType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req *errMsgRequest) error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/subscribe", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/subscribe?email=nope", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "please enter a valid email address", response["message"])
+}
+
+func TestBindingErrorDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		redact   bool
+		target   string
+		header   string
+		body     string
+		expected map[string]interface{}
+	}{
+		{
+			name:     "path",
+			target:   "/items/abc",
+			expected: map[string]interface{}{"source": "path", "field": "id", "value": "abc"},
+		},
+		{
+			name:     "query",
+			target:   "/items/1?timeout=soon",
+			expected: map[string]interface{}{"source": "query", "field": "timeout", "value": "soon"},
+		},
+		{
+			name:     "enum",
+			target:   "/items/1?status=deleted",
+			expected: map[string]interface{}{"source": "query", "field": "status", "value": "deleted"},
+		},
+		{
+			name:     "header",
+			target:   "/items/1",
+			header:   "many",
+			expected: map[string]interface{}{"source": "header", "value": "many"},
+		},
+		{
+			name:     "body type",
+			target:   "/items/1",
+			body:     `{"owner":{"age":"old"}}`,
+			expected: map[string]interface{}{"source": "body", "field": "owner.age"},
+		},
+		{
+			name:     "redacted",
+			redact:   true,
+			target:   "/items/abc",
+			expected: map[string]interface{}{"source": "path", "field": "id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.redact {
+				opts = append(opts, WithRedactedErrors())
+			}
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, opts...)
+
+			router := gin.New()
+			router.POST("/items/:id", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+				ID      int           `path:"id"`
+				Timeout time.Duration `form:"timeout" unit:"duration"`
+				Status  string        `form:"status" enum:"active,archived"`
+				Retries int           `header:"X-Retries"`
+				Owner   struct {
+					Age int `json:"age"`
+				} `json:"owner"`
+			}) error {
+				return nil
+			}))
+
+			w := httptest.NewRecorder()
+			body := tt.body
+			if body == "" {
+				body = "{}"
+			}
+			req, _ := http.NewRequest("POST", tt.target, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set("X-Retries", tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			for _, key := range []string{"source", "field", "value"} {
+				assert.Equal(t, tt.expected[key], response[key], key)
+			}
+		})
+	}
+}
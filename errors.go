@@ -0,0 +1,61 @@
+package ginbinding
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// StatusClientClosedRequest is the non-standard status code (popularized by
+// nginx) reported when the client canceled the request before a response was
+// written. No response body is written for it.
+const StatusClientClosedRequest = 499
+
+// Sentinel errors that handlers can return, or wrap, to produce the matching
+// HTTP status code in DefaultResponseHandler
+var (
+	ErrNotFound        = errors.New("record not found")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrForbidden       = errors.New("forbidden")
+	ErrConflict        = errors.New("conflict")
+	ErrTooManyRequests = errors.New("too many requests")
+
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrMethodNotAllowed     = errors.New("method not allowed")
+
+	ErrRequestEntityTooLarge = errors.New("request entity too large")
+	ErrURITooLong            = errors.New("URI too long")
+
+	ErrResponseTooLarge = errors.New("response too large")
+)
+
+// sentinelStatusCodes maps the sentinel and context errors to their HTTP status codes
+var sentinelStatusCodes = []struct {
+	err        error
+	statusCode int
+}{
+	{ErrNotFound, http.StatusNotFound},
+	{ErrUnauthorized, http.StatusUnauthorized},
+	{ErrForbidden, http.StatusForbidden},
+	{ErrConflict, http.StatusConflict},
+	{ErrTooManyRequests, http.StatusTooManyRequests},
+	{ErrPreconditionFailed, http.StatusPreconditionFailed},
+	{ErrUnsupportedMediaType, http.StatusUnsupportedMediaType},
+	{ErrMethodNotAllowed, http.StatusMethodNotAllowed},
+	{ErrRequestEntityTooLarge, http.StatusRequestEntityTooLarge},
+	{ErrURITooLong, http.StatusRequestURITooLong},
+	{ErrResponseTooLarge, http.StatusInternalServerError},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout},
+	{context.Canceled, StatusClientClosedRequest},
+}
+
+// sentinelStatusCode returns the status code of the first sentinel error in err's chain
+func sentinelStatusCode(err error) (int, bool) {
+	for _, s := range sentinelStatusCodes {
+		if errors.Is(err, s.err) {
+			return s.statusCode, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,108 @@
+package ginbinding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		err             error
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{name: "not found", err: ErrNotFound, expectedStatus: http.StatusNotFound, expectedMessage: "record not found"},
+		{name: "unauthorized", err: ErrUnauthorized, expectedStatus: http.StatusUnauthorized, expectedMessage: "unauthorized"},
+		{name: "forbidden", err: ErrForbidden, expectedStatus: http.StatusForbidden, expectedMessage: "forbidden"},
+		{name: "conflict", err: ErrConflict, expectedStatus: http.StatusConflict, expectedMessage: "conflict"},
+		{
+			name:            "too many requests",
+			err:             ErrTooManyRequests,
+			expectedStatus:  http.StatusTooManyRequests,
+			expectedMessage: "too many requests",
+		},
+		{
+			name:            "wrapped sentinel",
+			err:             fmt.Errorf("user 42: %w", ErrNotFound),
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "user 42: record not found",
+		},
+		{
+			name:            "deadline exceeded",
+			err:             fmt.Errorf("query users: %w", context.DeadlineExceeded),
+			expectedStatus:  http.StatusGatewayTimeout,
+			expectedMessage: "query users: context deadline exceeded",
+		},
+		{
+			name:            "same text but not the sentinel",
+			err:             errors.New("record not found"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "record not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(c *gin.Context) (interface{}, error) {
+				return nil, tt.err
+			}
+
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+			ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+			assert.NoError(t, err)
+
+			router := gin.New()
+			router.GET("/test", ginHandler)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/test", nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err = json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			assert.Equal(t, "error", response["status"])
+			assert.Equal(t, tt.expectedMessage, response["message"])
+		})
+	}
+}
+
+func TestContextCanceledWritesNoBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, responseHandler := range []ResponseHandler{NewDefaultResponseHandler(), NewJSONAPIResponseHandler()} {
+		handler := func(c *gin.Context) (interface{}, error) {
+			return nil, fmt.Errorf("load report: %w", context.Canceled)
+		}
+
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, responseHandler)
+		ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+		assert.NoError(t, err)
+
+		router := gin.New()
+		router.GET("/test", ginHandler)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, StatusClientClosedRequest, w.Code)
+		assert.Empty(t, w.Body.String())
+	}
+}
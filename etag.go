@@ -0,0 +1,78 @@
+package ginbinding
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithETag sets a function computing the ETag of data returned by handlers
+// that do not implement ETagger themselves. An empty result leaves the
+// response without an ETag.
+func WithETag(fn func(data any) string) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.etagFunc = fn
+	}
+}
+
+// notModified sets the ETag header for data and reports whether the request's
+// If-None-Match header already matches it, in which case a 304 Not Modified
+// response has been written and the success response must be skipped
+func (builder *BasicFormBindingGinHandlerBuilder) notModified(ctx *gin.Context, data any) bool {
+	var etag string
+	if tagger, ok := data.(ETagger); ok {
+		etag = tagger.ETag()
+	} else if builder.etagFunc != nil && data != nil {
+		etag = builder.etagFunc(data)
+	}
+	if etag == "" {
+		return false
+	}
+
+	etag = quoteETag(etag)
+	ctx.Header("ETag", etag)
+	return answerNotModified(ctx, etag)
+}
+
+// answerNotModified writes a 304 Not Modified response and reports true when
+// the request is a GET or HEAD whose If-None-Match header matches the quoted
+// etag, which may be empty
+func answerNotModified(ctx *gin.Context, etag string) bool {
+	method := ctx.Request.Method
+	if etag == "" || (method != http.MethodGet && method != http.MethodHead) {
+		return false
+	}
+	if !etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+
+	ctx.AbortWithStatus(http.StatusNotModified)
+	return true
+}
+
+// quoteETag wraps etag in double quotes unless it is already a quoted strong
+// or weak entity tag
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
+
+// etagMatches reports whether the If-None-Match header value matches etag
+// using the weak comparison of RFC 9110
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package ginbinding
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type etagTestArticle struct {
+	ID      int `json:"id"`
+	Version int `json:"version"`
+}
+
+func (a etagTestArticle) ETag() string {
+	return fmt.Sprintf("article-%d-v%d", a.ID, a.Version)
+}
+
+func TestETagConditionalGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context) (interface{}, error) {
+		return etagTestArticle{ID: 1, Version: 3}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/article", ginHandler)
+	router.PUT("/article", ginHandler)
+
+	tests := []struct {
+		name           string
+		method         string
+		ifNoneMatch    string
+		expectedStatus int
+	}{
+		{name: "no condition", method: "GET", expectedStatus: http.StatusOK},
+		{name: "matching", method: "GET", ifNoneMatch: `"article-1-v3"`, expectedStatus: http.StatusNotModified},
+		{name: "weak match in list", method: "GET", ifNoneMatch: `"article-1-v2", W/"article-1-v3"`, expectedStatus: http.StatusNotModified},
+		{name: "wildcard", method: "GET", ifNoneMatch: "*", expectedStatus: http.StatusNotModified},
+		{name: "stale", method: "GET", ifNoneMatch: `"article-1-v2"`, expectedStatus: http.StatusOK},
+		{name: "unsafe method", method: "PUT", ifNoneMatch: `"article-1-v3"`, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, "/article", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, `"article-1-v3"`, w.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			} else {
+				assert.Contains(t, w.Body.String(), `"version":3`)
+			}
+		})
+	}
+}
+
+func TestWithETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context) (interface{}, error) {
+		return gin.H{"name": "report"}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithETag(func(data any) string {
+		return `W/"` + fmt.Sprint(data.(gin.H)["name"]) + `"`
+	}))
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/report", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/report", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `W/"report"`, w.Header().Get("ETag"))
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/report", nil)
+	req.Header.Set("If-None-Match", `"report"`)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+}
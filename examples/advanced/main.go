@@ -29,15 +29,9 @@ func (h *CustomResponseHandler) HandleError(ctx *gin.Context, err error) {
 	})
 }
 
-// Embedded struct for pagination
-type Pagination struct {
-	Page     int `form:"page" default:"1"`
-	PageSize int `form:"page_size" default:"10"`
-}
-
 // Advanced request struct with mixed binding sources
 type AdvancedRequest struct {
-	Pagination
+	ginbinding.Pagination
 	UserID    int           `path:"user_id"`
 	AuthToken string        `header:"Authorization"`
 	Name      string        `json:"name" binding:"required"`
@@ -53,7 +47,9 @@ func main() {
 	r := gin.Default()
 
 	// Create form binding builder with custom response handler and validation
-	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(binding.Validator, &CustomResponseHandler{})
+	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(binding.Validator, &CustomResponseHandler{},
+		ginbinding.WithPagination(10, 100),
+	)
 
 	// Advanced handler with mixed binding
 	advancedHandler := func(c *gin.Context, req AdvancedRequest) (interface{}, error) {
@@ -69,14 +65,12 @@ func main() {
 			"pagination": gin.H{
 				"page":      req.Page,
 				"page_size": req.PageSize,
+				"offset":    req.Offset,
 			},
 		}, nil
 	}
 
-	advancedGinHandler, err := builder.FormBindingGinHandlerFunc(advancedHandler)
-	if err != nil {
-		log.Fatal(err)
-	}
+	advancedGinHandler := builder.MustFormBindingGinHandlerFunc(advancedHandler)
 
 	// Handler with pointer types
 	pointerHandler := func(c *gin.Context, req struct {
@@ -97,10 +91,7 @@ func main() {
 		return result, nil
 	}
 
-	pointerGinHandler, err := builder.FormBindingGinHandlerFunc(pointerHandler)
-	if err != nil {
-		log.Fatal(err)
-	}
+	pointerGinHandler := builder.MustFormBindingGinHandlerFunc(pointerHandler)
 
 	// Handler with time parsing
 	timeHandler := func(c *gin.Context, req struct {
@@ -115,10 +106,7 @@ func main() {
 		}, nil
 	}
 
-	timeGinHandler, err := builder.FormBindingGinHandlerFunc(timeHandler)
-	if err != nil {
-		log.Fatal(err)
-	}
+	timeGinHandler := builder.MustFormBindingGinHandlerFunc(timeHandler)
 
 	// Handler with complex validation
 	validationHandler := func(c *gin.Context, req struct {
@@ -135,10 +123,7 @@ func main() {
 		}, nil
 	}
 
-	validationGinHandler, err := builder.FormBindingGinHandlerFunc(validationHandler)
-	if err != nil {
-		log.Fatal(err)
-	}
+	validationGinHandler := builder.MustFormBindingGinHandlerFunc(validationHandler)
 
 	// Register routes
 	r.PUT("/users/:user_id", advancedGinHandler)
@@ -0,0 +1,419 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxFilterConditions bounds the size of a filter expression
+	maxFilterConditions = 32
+	// maxFilterDepth bounds the nesting of parentheses and NOT in a filter
+	// expression, and so the recursion of its parser
+	maxFilterDepth = 32
+)
+
+// FilterOp is a comparison operator of a filter condition
+type FilterOp string
+
+// Supported filter operators
+const (
+	FilterEq  FilterOp = "="
+	FilterNe  FilterOp = "!="
+	FilterGt  FilterOp = ">"
+	FilterGte FilterOp = ">="
+	FilterLt  FilterOp = "<"
+	FilterLte FilterOp = "<="
+)
+
+// FilterExpr is a node of a parsed filter expression: *FilterCondition,
+// *FilterAnd, *FilterOr or *FilterNot
+type FilterExpr interface {
+	filterExpr()
+	String() string
+}
+
+// FilterCondition compares a field with a literal value. Value is a string,
+// int64, float64, bool or nil depending on the literal.
+type FilterCondition struct {
+	Field string
+	Op    FilterOp
+	Value any
+}
+
+// FilterAnd matches when both sides match
+type FilterAnd struct {
+	Left, Right FilterExpr
+}
+
+// FilterOr matches when either side matches
+type FilterOr struct {
+	Left, Right FilterExpr
+}
+
+// FilterNot negates an expression
+type FilterNot struct {
+	Expr FilterExpr
+}
+
+func (*FilterCondition) filterExpr() {}
+func (*FilterAnd) filterExpr()       {}
+func (*FilterOr) filterExpr()        {}
+func (*FilterNot) filterExpr()       {}
+
+func (c *FilterCondition) String() string {
+	var value string
+	switch v := c.Value.(type) {
+	case string:
+		value = strconv.Quote(v)
+	case nil:
+		value = "null"
+	default:
+		value = fmt.Sprint(v)
+	}
+	return c.Field + string(c.Op) + value
+}
+
+func (e *FilterAnd) String() string { return "(" + e.Left.String() + " AND " + e.Right.String() + ")" }
+func (e *FilterOr) String() string  { return "(" + e.Left.String() + " OR " + e.Right.String() + ")" }
+func (e *FilterNot) String() string { return "NOT " + e.Expr.String() }
+
+// Filter is a parsed filter query parameter such as
+// "age>=18 AND (status=active OR status='pending')". Conditions compare a
+// field with a literal using =, !=, >, >=, < or <= and are combined with AND,
+// OR, NOT and parentheses. Use WithFilterFields to restrict the fields that
+// may be referenced. A zero Filter has a nil Expr and matches everything.
+type Filter struct {
+	Expr FilterExpr
+}
+
+var filterTy = reflect.TypeOf(Filter{})
+
+// ParseFilter parses a filter expression. An empty string yields an empty Filter.
+func ParseFilter(s string) (Filter, error) {
+	p := &filterParser{input: s}
+	if err := p.tokenize(); err != nil {
+		return Filter{}, err
+	}
+
+	if len(p.tokens) == 0 {
+		return Filter{}, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return Filter{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return Filter{}, fmt.Errorf("invalid filter: unexpected %q", p.tokens[p.pos].text)
+	}
+
+	return Filter{Expr: expr}, nil
+}
+
+// UnmarshalParam implements binding.BindUnmarshaler for query, form and path binding
+func (f *Filter) UnmarshalParam(param string) error {
+	filter, err := ParseFilter(param)
+	if err != nil {
+		return err
+	}
+	*f = filter
+	return nil
+}
+
+// UnmarshalJSON parses a filter expression given as a JSON string
+func (f *Filter) UnmarshalJSON(data []byte) error {
+	var expr string
+	if err := json.Unmarshal(data, &expr); err != nil {
+		return err
+	}
+	return f.UnmarshalParam(expr)
+}
+
+// MarshalJSON renders the filter back into its expression form
+func (f Filter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// String formats the filter as a fully parenthesized expression
+func (f Filter) String() string {
+	if f.Expr == nil {
+		return ""
+	}
+	return f.Expr.String()
+}
+
+// Conditions returns every condition of the filter in the order they appear
+func (f Filter) Conditions() []*FilterCondition {
+	var conditions []*FilterCondition
+	var walk func(expr FilterExpr)
+	walk = func(expr FilterExpr) {
+		switch e := expr.(type) {
+		case *FilterCondition:
+			conditions = append(conditions, e)
+		case *FilterAnd:
+			walk(e.Left)
+			walk(e.Right)
+		case *FilterOr:
+			walk(e.Left)
+			walk(e.Right)
+		case *FilterNot:
+			walk(e.Expr)
+		}
+	}
+	if f.Expr != nil {
+		walk(f.Expr)
+	}
+	return conditions
+}
+
+// WithFilterFields restricts the fields Filter values of the request struct
+// may reference. Requests using any other field are rejected with a BindingError.
+func WithFilterFields(fields ...string) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.filterFields = fields
+	}
+}
+
+// checkFilterFields rejects Filter values referencing fields outside the whitelist
+func (builder *BasicFormBindingGinHandlerBuilder) checkFilterFields(val reflect.Value) error {
+	if len(builder.filterFields) == 0 || !typeInfoOf(val.Type()).filter {
+		return nil
+	}
+
+	return walkFields(val, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		fieldVal = reflect.Indirect(fieldVal)
+		if !fieldVal.IsValid() || fieldVal.Type() != filterTy {
+			return nil
+		}
+
+		for _, c := range fieldVal.Interface().(Filter).Conditions() {
+			if !containsString(builder.filterFields, c.Field) {
+				return fmt.Errorf("field %s: unknown filter field %q, allowed: [%s]",
+					path, c.Field, strings.Join(builder.filterFields, ", "))
+			}
+		}
+		return nil
+	})
+}
+
+type filterTokenKind int
+
+const (
+	filterTokenIdent filterTokenKind = iota
+	filterTokenOp
+	filterTokenString
+	filterTokenLParen
+	filterTokenRParen
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+// filterParser is a recursive descent parser over the tokens of a filter expression
+type filterParser struct {
+	input      string
+	tokens     []filterToken
+	pos        int
+	conditions int
+	depth      int
+}
+
+func (p *filterParser) tokenize() error {
+	s := p.input
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			p.tokens = append(p.tokens, filterToken{kind: filterTokenLParen, text: "("})
+			i++
+		case c == ')':
+			p.tokens = append(p.tokens, filterToken{kind: filterTokenRParen, text: ")"})
+			i++
+		case c == '\'' || c == '"':
+			j := i + 1
+			var sb strings.Builder
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				sb.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return fmt.Errorf("invalid filter: unterminated string at position %d", i)
+			}
+			p.tokens = append(p.tokens, filterToken{kind: filterTokenString, text: sb.String()})
+			i = j + 1
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			j := i + 1
+			if j < len(s) && s[j] == '=' {
+				j++
+			}
+			op := s[i:j]
+			if op == "!" {
+				return fmt.Errorf("invalid filter: unexpected %q at position %d", op, i)
+			}
+			p.tokens = append(p.tokens, filterToken{kind: filterTokenOp, text: op})
+			i = j
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\r\n()'\"=!<>", rune(s[j])) {
+				j++
+			}
+			p.tokens = append(p.tokens, filterToken{kind: filterTokenIdent, text: s[i:j]})
+			i = j
+		}
+	}
+	return nil
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) &&
+		p.tokens[p.pos].kind == filterTokenIdent &&
+		strings.EqualFold(p.tokens[p.pos].text, keyword)
+}
+
+func (p *filterParser) parseOr() (FilterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("OR") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &FilterOr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (FilterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("AND") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &FilterAnd{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (FilterExpr, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("invalid filter: unexpected end of expression")
+	}
+
+	if p.peekKeyword("NOT") || p.tokens[p.pos].kind == filterTokenLParen {
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxFilterDepth {
+			return nil, fmt.Errorf("invalid filter: nested more than %d levels deep", maxFilterDepth)
+		}
+	}
+
+	if p.peekKeyword("NOT") {
+		p.pos++
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &FilterNot{Expr: expr}, nil
+	}
+
+	if p.tokens[p.pos].kind == filterTokenLParen {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != filterTokenRParen {
+			return nil, fmt.Errorf("invalid filter: missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	}
+
+	return p.parseCondition()
+}
+
+func (p *filterParser) parseCondition() (FilterExpr, error) {
+	if p.pos+3 > len(p.tokens) {
+		return nil, fmt.Errorf("invalid filter: incomplete condition")
+	}
+
+	field, op, value := p.tokens[p.pos], p.tokens[p.pos+1], p.tokens[p.pos+2]
+	if field.kind != filterTokenIdent || !isFilterIdent(field.text) {
+		return nil, fmt.Errorf("invalid filter: invalid field name %q", field.text)
+	}
+	if op.kind != filterTokenOp {
+		return nil, fmt.Errorf("invalid filter: expected operator after %q", field.text)
+	}
+	if value.kind != filterTokenIdent && value.kind != filterTokenString {
+		return nil, fmt.Errorf("invalid filter: expected value after %q", field.text+op.text)
+	}
+	p.pos += 3
+
+	p.conditions++
+	if p.conditions > maxFilterConditions {
+		return nil, fmt.Errorf("invalid filter: more than %d conditions", maxFilterConditions)
+	}
+
+	return &FilterCondition{
+		Field: field.text,
+		Op:    FilterOp(op.text),
+		Value: filterLiteral(value),
+	}, nil
+}
+
+// filterLiteral converts a value token to a typed literal. Quoted values are
+// always strings; bare values are parsed as null, booleans or numbers when possible.
+func filterLiteral(tok filterToken) any {
+	if tok.kind == filterTokenString {
+		return tok.text
+	}
+
+	switch strings.ToLower(tok.text) {
+	case "null":
+		return nil
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if i, err := strconv.ParseInt(tok.text, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(tok.text, 64); err == nil {
+		return f
+	}
+	return tok.text
+}
+
+// isFilterIdent reports whether s is a valid field reference: letters, digits,
+// underscores and dots, not starting with a digit
+func isFilterIdent(s string) bool {
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
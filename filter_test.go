@@ -0,0 +1,173 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "empty", input: "", expected: ""},
+		{name: "single condition", input: "age>=18", expected: "age>=18"},
+		{name: "and", input: "age>=18 AND status=active", expected: `(age>=18 AND status="active")`},
+		{
+			name:     "precedence",
+			input:    "a=1 OR b=2 AND c=3",
+			expected: "(a=1 OR (b=2 AND c=3))",
+		},
+		{
+			name:     "parentheses and not",
+			input:    "NOT (status='deleted' or status=\"banned\") and score<4.5",
+			expected: `(NOT (status="deleted" OR status="banned") AND score<4.5)`,
+		},
+		{name: "literals", input: "verified=true AND deleted_at=null", expected: "(verified=true AND deleted_at=null)"},
+		{name: "nested field", input: "profile.city!='New York'", expected: `profile.city!="New York"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseFilter(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, filter.String())
+		})
+	}
+}
+
+func TestParseFilterTypedValues(t *testing.T) {
+	filter, err := ParseFilter("age>=18 AND score<4.5 AND name='18' AND ok=true AND x=null AND s=active")
+	assert.NoError(t, err)
+
+	conditions := filter.Conditions()
+	assert.Len(t, conditions, 6)
+	assert.Equal(t, &FilterCondition{Field: "age", Op: FilterGte, Value: int64(18)}, conditions[0])
+	assert.Equal(t, &FilterCondition{Field: "score", Op: FilterLt, Value: 4.5}, conditions[1])
+	assert.Equal(t, &FilterCondition{Field: "name", Op: FilterEq, Value: "18"}, conditions[2])
+	assert.Equal(t, &FilterCondition{Field: "ok", Op: FilterEq, Value: true}, conditions[3])
+	assert.Equal(t, &FilterCondition{Field: "x", Op: FilterEq, Value: nil}, conditions[4])
+	assert.Equal(t, &FilterCondition{Field: "s", Op: FilterEq, Value: "active"}, conditions[5])
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "age>=", expected: "invalid filter: incomplete condition"},
+		{input: "age 18", expected: "invalid filter: incomplete condition"},
+		{input: "age 18 x", expected: `invalid filter: expected operator after "age"`},
+		{input: "(age=1", expected: "invalid filter: missing closing parenthesis"},
+		{input: "age=1 status=2", expected: `invalid filter: unexpected "status"`},
+		{input: "name='abc", expected: "invalid filter: unterminated string at position 5"},
+		{input: "1abc=2", expected: `invalid filter: invalid field name "1abc"`},
+		{input: "a;drop=1", expected: `invalid filter: invalid field name "a;drop"`},
+		{input: "a!1", expected: `invalid filter: unexpected "!" at position 1`},
+		{input: "age=1 AND", expected: "invalid filter: unexpected end of expression"},
+		{
+			input:    strings.Repeat("(", maxFilterDepth+1) + "age=1" + strings.Repeat(")", maxFilterDepth+1),
+			expected: "invalid filter: nested more than 32 levels deep",
+		},
+		{
+			input:    strings.Repeat("NOT ", maxFilterDepth+1) + "age=1",
+			expected: "invalid filter: nested more than 32 levels deep",
+		},
+		{
+			input:    strings.Repeat("NOT (", maxFilterDepth/2+1) + "age=1" + strings.Repeat(")", maxFilterDepth/2+1),
+			expected: "invalid filter: nested more than 32 levels deep",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseFilter(tt.input)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestParseFilterMaxDepth(t *testing.T) {
+	filter, err := ParseFilter(strings.Repeat("(", maxFilterDepth) + "age=1" + strings.Repeat(")", maxFilterDepth))
+	assert.NoError(t, err)
+	assert.Equal(t, "age=1", filter.String())
+
+	_, err = ParseFilter(strings.Repeat("(", 100000) + "age=1" + strings.Repeat(")", 100000))
+	assert.EqualError(t, err, "invalid filter: nested more than 32 levels deep")
+}
+
+func TestFilterBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Filter Filter `form:"filter"`
+	}) (interface{}, error) {
+		return gin.H{"filter": req.Filter, "conditions": len(req.Filter.Conditions())}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler, WithFilterFields("age", "status"))
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/users", ginHandler)
+
+	tests := []struct {
+		name           string
+		filter         string
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{name: "allowed fields", filter: "age>=18 AND status=active", expectedStatus: http.StatusOK},
+		{
+			name:           "unknown field",
+			filter:         "age>=18 OR password='x'",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Filter: unknown filter field "password", allowed: [age, status]`,
+		},
+		{
+			name:           "syntax error",
+			filter:         "age>=",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid filter: incomplete condition",
+		},
+		{
+			name:           "nested too deep",
+			filter:         strings.Repeat("NOT ", 1000) + "age=1",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid filter: nested more than 32 levels deep",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/users?filter="+url.QueryEscape(tt.filter), nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedStatus == http.StatusOK {
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, `(age>=18 AND status="active")`, data["filter"])
+				assert.Equal(t, float64(2), data["conditions"])
+			}
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, response["message"])
+			}
+		})
+	}
+}
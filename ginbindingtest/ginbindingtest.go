@@ -0,0 +1,437 @@
+// Package ginbindingtest provides helpers for testing handlers built with
+// gin-form-binding without hand-writing HTTP requests.
+//
+// Invoke serializes a request struct back into the path parameters, query
+// string, headers and JSON body described by its tags, runs the handler
+// through httptest and decodes the response:
+//
+//	resp, status, err := ginbindingtest.Invoke(t, getUser, GetUserRequest{ID: 42})
+//
+// Replay does the same for requests recorded with ginbinding.WithCapture, and
+// ReplaySplit for handlers taking the body in a separate struct.
+package ginbindingtest
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	ginbinding "github.com/zgs225/gin-form-binding"
+)
+
+var timeTy = reflect.TypeOf(time.Time{})
+
+// Invoke builds handler with a default builder configured by opts, sends req
+// to it and returns the decoded "data" of the response together with the
+// status code. Path, query ("form" tags), header, If-Match and host fields are
+// serialized from their tags; requests with "json" tags are sent as a POST
+// with the whole struct as JSON body, others as a GET.
+//
+// Responses are expected in the envelope of ginbinding.DefaultResponseHandler.
+// For error responses the returned error carries the response message. Invoke
+// calls t.Fatal when the handler cannot be built or the response cannot be
+// decoded.
+func Invoke[Req, Resp any](
+	t testing.TB,
+	handler func(*gin.Context, Req) (Resp, error),
+	req Req,
+	opts ...ginbinding.Option,
+) (Resp, int, error) {
+	t.Helper()
+
+	httpReq, route, err := NewRequest(req)
+	if err != nil {
+		t.Fatalf("ginbindingtest: %v", err)
+		var resp Resp
+		return resp, 0, err
+	}
+	return serve[Resp](t, handler, httpReq, route, opts)
+}
+
+// Replay sends the request captured with ginbinding.WithCapture to the file
+// name to handler, like Invoke, for regression tests against recorded
+// traffic. The request is sent with the method of the capture.
+//
+//	captures, _ := filepath.Glob("testdata/captures/*.json")
+//	for _, name := range captures {
+//		_, status, err := ginbindingtest.Replay(t, createOrder, name)
+//		...
+//	}
+func Replay[Req, Resp any](
+	t testing.TB,
+	handler func(*gin.Context, Req) (Resp, error),
+	name string,
+	opts ...ginbinding.Option,
+) (Resp, int, error) {
+	t.Helper()
+
+	var resp Resp
+
+	capture, err := ginbinding.ReadCapture(name)
+	if err != nil {
+		t.Fatalf("ginbindingtest: %v", err)
+		return resp, 0, err
+	}
+
+	req, err := decode[Req](&capture.CapturedStruct)
+	if err != nil {
+		t.Fatalf("ginbindingtest: %v", err)
+		return resp, 0, err
+	}
+
+	httpReq, route, err := NewRequest(req)
+	if err != nil {
+		t.Fatalf("ginbindingtest: %v", err)
+		return resp, 0, err
+	}
+	httpReq.Method = capture.Method
+	return serve[Resp](t, handler, httpReq, route, opts)
+}
+
+// ReplaySplit is Replay for handlers binding the body into a separate struct
+// Body. The request is rebuilt from the captured Req like Replay, and the
+// captured Body is sent as its JSON body.
+func ReplaySplit[Req, Body, Resp any](
+	t testing.TB,
+	handler func(*gin.Context, Req, Body) (Resp, error),
+	name string,
+	opts ...ginbinding.Option,
+) (Resp, int, error) {
+	t.Helper()
+
+	var resp Resp
+
+	capture, err := ginbinding.ReadCapture(name)
+	if err != nil {
+		t.Fatalf("ginbindingtest: %v", err)
+		return resp, 0, err
+	}
+	if capture.Body == nil {
+		err := fmt.Errorf("capture %s has no body struct", name)
+		t.Fatalf("ginbindingtest: %v", err)
+		return resp, 0, err
+	}
+
+	req, err := decode[Req](&capture.CapturedStruct)
+	if err != nil {
+		t.Fatalf("ginbindingtest: %v", err)
+		return resp, 0, err
+	}
+	body, err := decode[Body](capture.Body)
+	if err != nil {
+		t.Fatalf("ginbindingtest: %v", err)
+		return resp, 0, err
+	}
+
+	httpReq, route, err := NewRequest(req)
+	if err != nil {
+		t.Fatalf("ginbindingtest: %v", err)
+		return resp, 0, err
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("ginbindingtest: encode body: %v", err)
+		return resp, 0, err
+	}
+	httpReq.Method = capture.Method
+	httpReq.Body = io.NopCloser(bytes.NewReader(data))
+	httpReq.ContentLength = int64(len(data))
+	httpReq.Header.Set("Content-Type", "application/json")
+	return serve[Resp](t, handler, httpReq, route, opts)
+}
+
+// decode returns a new T, allocating the struct T points to, set to the
+// captured values of s
+func decode[T any](s *ginbinding.CapturedStruct) (T, error) {
+	val := reflect.New(reflect.TypeOf((*T)(nil)).Elem())
+	target := val
+	if target.Elem().Kind() == reflect.Pointer {
+		target.Elem().Set(reflect.New(target.Elem().Type().Elem()))
+		target = target.Elem()
+	}
+	err := s.Decode(target.Interface())
+	return val.Elem().Interface().(T), err
+}
+
+// serve sends httpReq to handler registered at route and decodes the response
+func serve[Resp any](
+	t testing.TB,
+	handler any,
+	httpReq *http.Request,
+	route string,
+	opts []ginbinding.Option,
+) (Resp, int, error) {
+	t.Helper()
+
+	var resp Resp
+
+	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil, opts...)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	if err != nil {
+		t.Fatalf("ginbindingtest: build handler: %v", err)
+		return resp, 0, err
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(httpReq.Method, route, ginHandler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	var envelope struct {
+		Status  string          `json:"status"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if w.Body.Len() > 0 {
+		if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+			t.Fatalf("ginbindingtest: decode response %q: %v", w.Body.String(), err)
+			return resp, w.Code, err
+		}
+	}
+
+	if w.Code >= http.StatusBadRequest || envelope.Status == "error" {
+		return resp, w.Code, errors.New(envelope.Message)
+	}
+
+	if len(envelope.Data) > 0 {
+		if err := json.Unmarshal(envelope.Data, &resp); err != nil {
+			t.Fatalf("ginbindingtest: decode data %s: %v", envelope.Data, err)
+			return resp, w.Code, err
+		}
+	}
+
+	return resp, w.Code, nil
+}
+
+// NewRequest serializes req, a struct or pointer to struct, into an HTTP
+// request following its binding tags. It also returns the gin route pattern
+// matching the request's path parameters, such as "/:id".
+func NewRequest(req any) (*http.Request, string, error) {
+	val := reflect.Indirect(reflect.ValueOf(req))
+	if val.Kind() != reflect.Struct {
+		return nil, "", fmt.Errorf("request must be a struct or pointer to struct, got %T", req)
+	}
+
+	r := &requestBuilder{query: url.Values{}, header: http.Header{}}
+	if err := r.addFields(val); err != nil {
+		return nil, "", err
+	}
+
+	method := http.MethodGet
+	var body *bytes.Reader
+	if r.hasBody {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return nil, "", fmt.Errorf("encode body: %w", err)
+		}
+		method = http.MethodPost
+		body = bytes.NewReader(data)
+		r.header.Set("Content-Type", "application/json")
+	}
+
+	route, target := "/", "/"
+	if len(r.routeSegments) > 0 {
+		route = "/" + strings.Join(r.routeSegments, "/")
+		target = "/" + strings.Join(r.pathSegments, "/")
+	}
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+
+	var httpReq *http.Request
+	var err error
+	if body != nil {
+		httpReq, err = http.NewRequest(method, target, body)
+	} else {
+		httpReq, err = http.NewRequest(method, target, nil)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	httpReq.Header = r.header
+	if host := r.host(); host != "" {
+		httpReq.Host = host
+	}
+
+	return httpReq, route, nil
+}
+
+// requestBuilder accumulates the parts of a request while walking the fields
+// of a request struct
+type requestBuilder struct {
+	routeSegments []string
+	pathSegments  []string
+	query         url.Values
+	header        http.Header
+	subdomain     string
+	domain        string
+	hasBody       bool
+}
+
+func (r *requestBuilder) addFields(val reflect.Value) error {
+	ty := val.Type()
+
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		fieldVal := val.Field(i)
+
+		if sf.Anonymous {
+			embedded := reflect.Indirect(fieldVal)
+			if embedded.Kind() == reflect.Struct {
+				if err := r.addFields(embedded); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if key, ok := pathKey(sf); ok {
+			value := pathValue(fieldVal)
+			if value == "" {
+				return fmt.Errorf("field %s: path parameter %q has no value", sf.Name, key)
+			}
+			r.routeSegments = append(r.routeSegments, ":"+key)
+			r.pathSegments = append(r.pathSegments, url.PathEscape(value))
+		}
+
+		if key, ok := queryKey(sf); ok && key != "-" {
+			key, _, _ = strings.Cut(key, ",")
+			if key == "" {
+				key = sf.Name
+			}
+			for _, v := range stringValues(fieldVal) {
+				r.query.Add(key, v)
+			}
+		}
+
+		if key, ok := sf.Tag.Lookup("header"); ok {
+			if strings.HasSuffix(key, "*") {
+				iter := fieldVal.MapRange()
+				for iter.Next() {
+					for _, v := range stringValues(iter.Value()) {
+						r.header.Add(iter.Key().String(), v)
+					}
+				}
+			} else {
+				for _, v := range stringValues(fieldVal) {
+					r.header.Add(key, v)
+				}
+			}
+		}
+
+		if _, ok := sf.Tag.Lookup("ifmatch"); ok && fieldVal.String() != "" {
+			r.header.Set("If-Match", fieldVal.String())
+		}
+
+		switch sf.Tag.Get("host") {
+		case "subdomain":
+			r.subdomain = fieldVal.String()
+		case "domain":
+			r.domain = fieldVal.String()
+		}
+
+		if _, ok := sf.Tag.Lookup("json"); ok {
+			r.hasBody = true
+		}
+	}
+
+	return nil
+}
+
+// host rebuilds the request host from bound subdomain and domain fields
+func (r *requestBuilder) host() string {
+	domain := r.domain
+	if domain == "" && r.subdomain != "" {
+		domain = "example.com"
+	}
+	if r.subdomain == "" {
+		return domain
+	}
+	return r.subdomain + "." + domain
+}
+
+// pathValue formats a path parameter, keeping zero values such as ID 0
+func pathValue(val reflect.Value) string {
+	if values := stringValues(val); len(values) > 0 {
+		return values[0]
+	}
+	if val = reflect.Indirect(val); val.IsValid() && val.Kind() != reflect.String {
+		return fmt.Sprint(val.Interface())
+	}
+	return ""
+}
+
+// stringValues formats val the way the binder parses it back. Nil pointers
+// and zero values yield no value so that defaults still apply.
+func stringValues(val reflect.Value) []string {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.IsZero() {
+		return nil
+	}
+
+	if val.Type() == timeTy {
+		return []string{val.Interface().(time.Time).Format(time.RFC3339Nano)}
+	}
+	if stringer, ok := val.Interface().(fmt.Stringer); ok {
+		return []string{stringer.String()}
+	}
+	if marshaler, ok := val.Interface().(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return []string{string(text)}
+		}
+	}
+
+	if (val.Kind() == reflect.Slice || val.Kind() == reflect.Array) && val.Type().Elem().Kind() != reflect.Uint8 {
+		values := make([]string, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			values = append(values, stringValues(val.Index(i))...)
+		}
+		return values
+	}
+
+	return []string{fmt.Sprint(val.Interface())}
+}
+
+// pathKey returns the path parameter name of sf from its "path" tag or gin's
+// "uri" alias
+func pathKey(sf reflect.StructField) (string, bool) {
+	if key, ok := sf.Tag.Lookup("path"); ok {
+		return key, true
+	}
+	if tag, ok := sf.Tag.Lookup("uri"); ok {
+		key, _, _ := strings.Cut(tag, ",")
+		return key, true
+	}
+	return "", false
+}
+
+// queryKey returns the query tag of sf, either "form" or its "query" alias
+func queryKey(sf reflect.StructField) (string, bool) {
+	if tag, ok := sf.Tag.Lookup("form"); ok {
+		return tag, true
+	}
+	return sf.Tag.Lookup("query")
+}
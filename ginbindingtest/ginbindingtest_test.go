@@ -0,0 +1,264 @@
+package ginbindingtest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ginbinding "github.com/zgs225/gin-form-binding"
+)
+
+type updateUserRequest struct {
+	ginbinding.Pagination
+	UserID    int               `path:"user_id"`
+	Tags      []string          `form:"tag"`
+	Sort      ginbinding.Sort   `form:"sort"`
+	Since     time.Time         `form:"since"`
+	AuthToken string            `header:"Authorization"`
+	Meta      map[string]string `header:"X-Meta-*"`
+	Version   string            `ifmatch:""`
+	Tenant    string            `host:"subdomain"`
+	Name      string            `json:"name"`
+	Age       int               `json:"age" default:"18"`
+}
+
+type updateUserResponse struct {
+	UserID    int               `json:"user_id"`
+	Page      int               `json:"page"`
+	Tags      []string          `json:"tags"`
+	Sort      string            `json:"sort"`
+	Since     time.Time         `json:"since"`
+	AuthToken string            `json:"auth_token"`
+	Meta      map[string]string `json:"meta"`
+	Version   string            `json:"version"`
+	Tenant    string            `json:"tenant"`
+	Name      string            `json:"name"`
+	Age       int               `json:"age"`
+}
+
+func updateUser(c *gin.Context, req updateUserRequest) (updateUserResponse, error) {
+	if req.UserID == 404 {
+		return updateUserResponse{}, ginbinding.ErrNotFound
+	}
+	return updateUserResponse{
+		UserID:    req.UserID,
+		Page:      req.Page,
+		Tags:      req.Tags,
+		Sort:      req.Sort.String(),
+		Since:     req.Since,
+		AuthToken: req.AuthToken,
+		Meta:      req.Meta,
+		Version:   req.Version,
+		Tenant:    req.Tenant,
+		Name:      req.Name,
+		Age:       req.Age,
+	}, nil
+}
+
+func TestInvoke(t *testing.T) {
+	since := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+
+	resp, status, err := Invoke(t, updateUser, updateUserRequest{
+		Pagination: ginbinding.Pagination{Page: 3},
+		UserID:     7,
+		Tags:       []string{"a", "b"},
+		Sort:       ginbinding.Sort{{Field: "name", Direction: ginbinding.SortDesc}},
+		Since:      since,
+		AuthToken:  "Bearer token123",
+		Meta:       map[string]string{"X-Meta-Region": "eu"},
+		Version:    `"v2"`,
+		Tenant:     "acme",
+		Name:       "John",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, updateUserResponse{
+		UserID:    7,
+		Page:      3,
+		Tags:      []string{"a", "b"},
+		Sort:      "-name",
+		Since:     since,
+		AuthToken: "Bearer token123",
+		Meta:      map[string]string{"X-Meta-Region": "eu"},
+		Version:   `"v2"`,
+		Tenant:    "acme",
+		Name:      "John",
+		Age:       18,
+	}, resp)
+}
+
+func TestInvokeError(t *testing.T) {
+	_, status, err := Invoke(t, updateUser, updateUserRequest{UserID: 404})
+
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.EqualError(t, err, "record not found")
+}
+
+func TestInvokeQueryOnly(t *testing.T) {
+	handler := func(c *gin.Context, req struct {
+		ID    int    `path:"id"`
+		Query string `form:"q"`
+	}) (map[string]any, error) {
+		if c.Request.Method != http.MethodGet {
+			return nil, errors.New("expected GET")
+		}
+		return map[string]any{"id": req.ID, "q": req.Query}, nil
+	}
+
+	resp, status, err := Invoke(t, handler, struct {
+		ID    int    `path:"id"`
+		Query string `form:"q"`
+	}{ID: 0, Query: "go lang"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]any{"id": float64(0), "q": "go lang"}, resp)
+}
+
+func TestNewRequest(t *testing.T) {
+	req, route, err := NewRequest(&struct {
+		OrgID  string `path:"org"`
+		UserID int    `path:"user"`
+		Filter string `form:"filter"`
+	}{OrgID: "acme corp", UserID: 9, Filter: "age>=18"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/:org/:user", route)
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "/acme%20corp/9?filter=age%3E%3D18", req.URL.String())
+
+	_, _, err = NewRequest(struct {
+		OrgID string `path:"org"`
+	}{})
+	assert.EqualError(t, err, `field OrgID: path parameter "org" has no value`)
+
+	_, _, err = NewRequest(42)
+	assert.EqualError(t, err, "request must be a struct or pointer to struct, got int")
+}
+
+func TestReplay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dir := t.TempDir()
+	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.PUT("/users/:user_id", builder.MustFormBindingGinHandlerFunc(updateUser, ginbinding.WithCapture(dir)))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/users/7?page=3&tag=a&tag=b&sort=-name", strings.NewReader(`{"name":"John"}`))
+	req.Host = "acme.example.com"
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer token123")
+	req.Header.Set("X-Meta-Region", "eu")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	names := waitCaptures(t, dir, 1)
+
+	expected := updateUserResponse{
+		UserID:    7,
+		Page:      3,
+		Tags:      []string{"a", "b"},
+		Sort:      "-name",
+		AuthToken: "Bearer token123",
+		Meta:      map[string]string{"X-Meta-Region": "eu"},
+		Tenant:    "acme",
+		Name:      "John",
+		Age:       18,
+	}
+
+	resp, status, err := Replay(t, func(c *gin.Context, req updateUserRequest) (updateUserResponse, error) {
+		if c.Request.Method != http.MethodPut {
+			return updateUserResponse{}, errors.New("expected PUT")
+		}
+		return updateUser(c, req)
+	}, names[0])
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, expected, resp)
+
+	// Pointer requests are allocated
+	resp, _, err = Replay(t, func(c *gin.Context, req *updateUserRequest) (updateUserResponse, error) {
+		return updateUser(c, *req)
+	}, names[0])
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+type renameUserQuery struct {
+	UserID int    `path:"user_id"`
+	Notify bool   `form:"notify"`
+	Token  string `header:"Authorization"`
+}
+
+type renameUserBody struct {
+	Name string `json:"name"`
+}
+
+func renameUser(c *gin.Context, req renameUserQuery, body renameUserBody) (gin.H, error) {
+	return gin.H{"user_id": req.UserID, "notify": req.Notify, "token": req.Token, "name": body.Name, "method": c.Request.Method}, nil
+}
+
+func TestReplaySplit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dir := t.TempDir()
+	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.PATCH("/users/:user_id", builder.MustFormBindingGinHandlerFunc(renameUser, ginbinding.WithCapture(dir)))
+	router.PUT("/users/:user_id", builder.MustFormBindingGinHandlerFunc(updateUser, ginbinding.WithCapture(dir)))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PATCH", "/users/7?notify=true", strings.NewReader(`{"name":"John"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer token123")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	names := waitCaptures(t, dir, 1)
+	capture, err := ginbinding.ReadCapture(names[0])
+	require.NoError(t, err)
+	require.NotNil(t, capture.Body)
+	assert.Equal(t, "ginbindingtest.renameUserBody", capture.Body.Type)
+	assert.Equal(t, map[string]string{"Name": "body:name"}, capture.Body.Sources)
+
+	resp, status, err := ReplaySplit(t, renameUser, names[0])
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, gin.H{"user_id": float64(7), "notify": true, "token": "Bearer token123", "name": "John", "method": "PATCH"}, resp)
+
+	// Captures of handlers without a body struct have none
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("PUT", "/users/7", strings.NewReader(`{"name":"John"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	names = waitCaptures(t, dir, 2)
+	for _, name := range names {
+		c, err := ginbinding.ReadCapture(name)
+		require.NoError(t, err)
+		assert.Equal(t, c.Method == http.MethodPatch, c.Body != nil)
+	}
+}
+
+// waitCaptures waits for n captures to be written to dir in the background
+// and returns their file names
+func waitCaptures(t *testing.T, dir string, n int) []string {
+	t.Helper()
+
+	var names []string
+	require.Eventually(t, func() bool {
+		names, _ = filepath.Glob(filepath.Join(dir, "*.json"))
+		return len(names) == n
+	}, time.Second, 10*time.Millisecond)
+	return names
+}
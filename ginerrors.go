@@ -0,0 +1,28 @@
+package ginbinding
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithGinErrors pushes binding, validation and handler errors onto ctx.Errors
+// before the response handler runs, so that gin's logging and recovery
+// middleware and error aggregators reading ctx.Errors see them. Binding and
+// validation errors are of type gin.ErrorTypeBind, handler errors of type
+// gin.ErrorTypePrivate.
+func WithGinErrors() Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.ginErrors = true
+	}
+}
+
+// ginErrorType classifies err for ctx.Errors
+func ginErrorType(err error) gin.ErrorType {
+	var bindingErr *BindingError
+	var ve *ValidationError
+	if errors.As(err, &bindingErr) || errors.As(err, &ve) {
+		return gin.ErrorTypeBind
+	}
+	return gin.ErrorTypePrivate
+}
@@ -0,0 +1,61 @@
+package ginbinding
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithGinErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	errHandler := errors.New("storage unavailable")
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		target       string
+		expectedType gin.ErrorType
+		expectedLen  int
+	}{
+		{name: "disabled", target: "/items/1", expectedLen: 0},
+		{name: "binding error", opts: []Option{WithGinErrors()}, target: "/items/abc", expectedType: gin.ErrorTypeBind, expectedLen: 1},
+		{name: "validation error", opts: []Option{WithGinErrors()}, target: "/items/0", expectedType: gin.ErrorTypeBind, expectedLen: 1},
+		{name: "handler error", opts: []Option{WithGinErrors()}, target: "/items/1", expectedType: gin.ErrorTypePrivate, expectedLen: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewBasicFormBindingGinHandlerBuilder(binding.Validator, nil, tt.opts...)
+
+			var ginErrors []*gin.Error
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Next()
+				ginErrors = c.Errors
+			})
+			router.GET("/items/:id", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+				ID int `path:"id" binding:"min=1"`
+			}) error {
+				return errHandler
+			}))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.target, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Len(t, ginErrors, tt.expectedLen)
+			if tt.expectedLen > 0 {
+				assert.Equal(t, tt.expectedType, ginErrors[0].Type)
+			}
+			if tt.expectedType == gin.ErrorTypePrivate {
+				assert.ErrorIs(t, ginErrors[0].Err, errHandler)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package ginbinding
+
+import (
+	"maps"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderResponse is the data returned by a handler along with headers of the
+// success response, see WithHeaders
+type HeaderResponse struct {
+	Data    any
+	Headers map[string]string
+}
+
+// WithHeaders wraps the data returned by a handler with headers to set on the
+// success response, such as Location, X-Total-Count or Link. The response
+// handler receives data as if it was returned directly, and the headers are
+// only set when the success response is written:
+//
+//	func createUser(c *gin.Context, req CreateUserRequest) (any, error) {
+//		user, err := users.Create(c, req)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return ginbinding.WithHeaders(user, map[string]string{
+//			"Location": fmt.Sprintf("/users/%d", user.ID),
+//		}), nil
+//	}
+func WithHeaders(data any, headers map[string]string) *HeaderResponse {
+	return &HeaderResponse{Data: data, Headers: headers}
+}
+
+// splitHeaders returns the data wrapped by WithHeaders and the headers to set,
+// or data and nil if it is not wrapped. The headers of outer wrappers take
+// precedence over those of the data they wrap.
+func splitHeaders(data any) (any, map[string]string) {
+	resp, ok := data.(*HeaderResponse)
+	if !ok || resp == nil {
+		return data, nil
+	}
+
+	inner, headers := splitHeaders(resp.Data)
+	if headers == nil {
+		return inner, resp.Headers
+	}
+	headers = maps.Clone(headers)
+	maps.Copy(headers, resp.Headers)
+	return inner, headers
+}
+
+// setHeaders sets the headers of the response of ctx
+func setHeaders(ctx *gin.Context, headers map[string]string) {
+	for name, value := range headers {
+		ctx.Header(name, value)
+	}
+}
@@ -0,0 +1,77 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	var hookResp any
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil).
+		OnSuccess(func(c *gin.Context, req any, resp any) {
+			hookResp = resp
+		})
+	router := gin.New()
+	router.POST("/users", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return WithHeaders(user{ID: 1, Name: "ann"}, map[string]string{
+			"Location":      "/users/1",
+			"X-Total-Count": "1",
+		}), nil
+	}))
+	router.GET("/nested", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return WithHeaders(WithHeaders([]user{}, map[string]string{"Link": "inner", "X-Inner": "1"}),
+			map[string]string{"Link": "outer"}), nil
+	}))
+	router.GET("/error", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return WithHeaders(nil, map[string]string{"Location": "/users/1"}), errors.New("failed")
+	}))
+
+	t.Run("headers and data", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/users", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "/users/1", w.Header().Get("Location"))
+		assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, map[string]interface{}{"id": float64(1), "name": "ann"}, response["data"])
+		assert.Equal(t, user{ID: 1, Name: "ann"}, hookResp)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/nested", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "outer", w.Header().Get("Link"))
+		assert.Equal(t, "1", w.Header().Get("X-Inner"))
+		assert.JSONEq(t, `{"status":"success","data":[]}`, w.Body.String())
+	})
+
+	t.Run("not set on errors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/error", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("Location"))
+	})
+}
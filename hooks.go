@@ -0,0 +1,120 @@
+package ginbinding
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SuccessHook observes a request that was handled successfully. req is the
+// bound request struct, or nil for handlers without a request, and resp is
+// the data returned by the handler.
+type SuccessHook func(ctx *gin.Context, req any, resp any)
+
+// ErrorHook observes a request that failed. req is the bound request struct,
+// or nil when the handler has no request or it could not be bound and
+// validated.
+type ErrorHook func(ctx *gin.Context, req any, err error)
+
+// OnSuccess registers a hook that runs after the success response of every
+// handler built afterwards has been written, e.g. for audit logging or event
+// emission. Hooks run in registration order.
+func (builder *BasicFormBindingGinHandlerBuilder) OnSuccess(hook SuccessHook) *BasicFormBindingGinHandlerBuilder {
+	builder.successHooks = append(builder.successHooks, hook)
+	return builder
+}
+
+// OnError registers a hook that runs after the error response of every
+// handler built afterwards has been written, including binding and validation
+// failures. Requests aborted by their client run the OnClientAbort hooks
+// instead. Hooks run in registration order.
+func (builder *BasicFormBindingGinHandlerBuilder) OnError(hook ErrorHook) *BasicFormBindingGinHandlerBuilder {
+	builder.errorHooks = append(builder.errorHooks, hook)
+	return builder
+}
+
+// WithPreBindHook adds a hook that runs before the request is bound, e.g. to
+// decompress bodies or normalize content types. A hook returning an error
+// rejects the request through the response handler; the error is not wrapped
+// in a BindingError, so it can choose its status with StatusCode or the
+// sentinel errors. Hooks run in the order they were added.
+func WithPreBindHook(hook func(*gin.Context) error) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		// Always reallocate so that per-handler hooks never leak into the
+		// builder the handler was copied from
+		hooks := builder.preBindHooks
+		builder.preBindHooks = append(hooks[:len(hooks):len(hooks)], hook)
+	}
+}
+
+// handleSuccess writes the success response for body and runs the success
+// hooks with data, which body replaces when data is over the response budget
+func (builder *BasicFormBindingGinHandlerBuilder) handleSuccess(ctx *gin.Context, req reflect.Value, body, data any) {
+	// written is the number of items or bytes of a streamed or raw response
+	// body written before err ended it
+	var written int
+	var err error
+	switch items, ok := responseStream(body); {
+	case builder.rawContentType != "" && isRawData(body):
+		written, err = builder.writeRaw(ctx, body)
+	case ok:
+		written, err = builder.writeStream(ctx, items)
+	case body == nil && builder.noContent:
+		writeNoContent(ctx)
+	case !builder.notModified(ctx, body):
+		builder.responseHandler.HandleSuccess(ctx, body)
+	}
+	if err != nil {
+		if written == 0 {
+			builder.handleError(ctx, req, err)
+			return
+		}
+		// The response is already under way and ends truncated
+		_ = ctx.Error(err)
+		builder.runErrorHooks(ctx, req, err)
+		return
+	}
+
+	if len(builder.successHooks) == 0 {
+		return
+	}
+	reqData := hookRequest(req)
+	for _, hook := range builder.successHooks {
+		hook(ctx, reqData, data)
+	}
+}
+
+// handleError writes the error response for err and runs the error hooks
+func (builder *BasicFormBindingGinHandlerBuilder) handleError(ctx *gin.Context, req reflect.Value, err error) {
+	if builder.ginErrors {
+		_ = ctx.Error(err).SetType(ginErrorType(err))
+	}
+
+	var abortErr *ClientAbortError
+	if errors.As(err, &abortErr) {
+		builder.handleClientAbort(ctx, abortErr)
+		return
+	}
+
+	builder.responseHandler.HandleError(ctx, err)
+	builder.runErrorHooks(ctx, req, err)
+}
+
+// runErrorHooks runs the error hooks for err
+func (builder *BasicFormBindingGinHandlerBuilder) runErrorHooks(ctx *gin.Context, req reflect.Value, err error) {
+	if len(builder.errorHooks) == 0 {
+		return
+	}
+	reqData := hookRequest(req)
+	for _, hook := range builder.errorHooks {
+		hook(ctx, reqData, err)
+	}
+}
+
+func hookRequest(req reflect.Value) any {
+	if !req.IsValid() {
+		return nil
+	}
+	return req.Interface()
+}
@@ -0,0 +1,143 @@
+package ginbinding
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type hooksTestRequest struct {
+	ID   int    `path:"id"`
+	Name string `json:"name"`
+}
+
+func TestOnSuccessAndOnError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type event struct {
+		status int
+		req    any
+		resp   any
+		err    string
+	}
+	var events []event
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil).
+		OnSuccess(func(c *gin.Context, req any, resp any) {
+			events = append(events, event{status: c.Writer.Status(), req: req, resp: resp})
+		}).
+		OnError(func(c *gin.Context, req any, err error) {
+			events = append(events, event{status: c.Writer.Status(), req: req, err: err.Error()})
+		})
+
+	handler := func(c *gin.Context, req hooksTestRequest) (interface{}, error) {
+		if req.ID == 0 {
+			return nil, errors.New("id must not be zero")
+		}
+		return gin.H{"id": req.ID}, nil
+	}
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.PUT("/items/:id", ginHandler)
+
+	tests := []struct {
+		name     string
+		url      string
+		expected event
+	}{
+		{
+			name: "success",
+			url:  "/items/3",
+			expected: event{
+				status: http.StatusOK,
+				req:    hooksTestRequest{ID: 3, Name: "box"},
+				resp:   gin.H{"id": 3},
+			},
+		},
+		{
+			name: "handler error",
+			url:  "/items/0",
+			expected: event{
+				status: http.StatusInternalServerError,
+				req:    hooksTestRequest{ID: 0, Name: "box"},
+				err:    "id must not be zero",
+			},
+		},
+		{
+			name: "binding error",
+			url:  "/items/abc",
+			expected: event{
+				status: http.StatusBadRequest,
+				err:    `failed to parse path parameter "id": strconv.ParseInt: parsing "abc": invalid syntax`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events = nil
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", tt.url, strings.NewReader(`{"name":"box"}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, []event{tt.expected}, events)
+		})
+	}
+}
+
+func TestWithPreBindHook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	normalize := func(c *gin.Context) error {
+		if c.ContentType() == "text/json" {
+			c.Request.Header.Set("Content-Type", "application/json")
+		}
+		return nil
+	}
+	requireTenant := func(c *gin.Context) error {
+		if c.GetHeader("X-Tenant") == "" {
+			return fmt.Errorf("missing tenant: %w", ErrUnauthorized)
+		}
+		return nil
+	}
+
+	handler := func(c *gin.Context, req struct {
+		Name string `json:"name"`
+	}) (interface{}, error) {
+		return gin.H{"name": req.Name}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithPreBindHook(normalize))
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler, WithPreBindHook(requireTenant))
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/items", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/items", strings.NewReader(`{"name":"box"}`))
+	req.Header.Set("Content-Type", "text/json")
+	req.Header.Set("X-Tenant", "acme")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"box"`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/items", strings.NewReader(`{"name":"box"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.JSONEq(t, `{"status":"error","code":"UNAUTHORIZED","message":"missing tenant: unauthorized"}`, w.Body.String())
+}
@@ -0,0 +1,85 @@
+package ginbinding
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+)
+
+// HostResolver splits a request host, without port, into its subdomain and
+// domain parts for "host" tags. Returning an error rejects the request with a
+// BindingError, which lets multi-tenant services refuse unknown hosts.
+type HostResolver func(host string) (subdomain string, domain string, err error)
+
+// WithHostResolver replaces the default host splitting used by `host:"subdomain"`
+// and `host:"domain"` fields. The default treats the last two labels of the
+// host as the domain, which is wrong for public suffixes such as "co.uk".
+func WithHostResolver(resolver HostResolver) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.hostResolver = resolver
+	}
+}
+
+// hostParts holds the resolved parts of a request host
+type hostParts struct {
+	subdomain string
+	domain    string
+}
+
+func (h *hostParts) part(name string) string {
+	if name == "subdomain" {
+		return h.subdomain
+	}
+	return h.domain
+}
+
+// checkHostTags verifies every "host" tag of ty at handler build time
+func checkHostTags(ty reflect.Type) error {
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if tag, ok := sf.Tag.Lookup("host"); ok && tag != "subdomain" && tag != "domain" {
+			return fmt.Errorf("field %s: host tag must be \"subdomain\" or \"domain\", got %q", sf.Name, tag)
+		}
+	}
+	return nil
+}
+
+// resolveHost strips the port from host and splits it with the configured
+// resolver, or splitHost when there is none
+func (builder *BasicFormBindingGinHandlerBuilder) resolveHost(host string) (*hostParts, error) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	resolver := builder.hostResolver
+	if resolver == nil {
+		resolver = splitHost
+	}
+
+	subdomain, domain, err := resolver(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve host %q: %w", host, err)
+	}
+	return &hostParts{subdomain: subdomain, domain: domain}, nil
+}
+
+// splitHost is the default HostResolver. IP addresses and hosts with at most
+// two labels have no subdomain.
+func splitHost(host string) (string, string, error) {
+	if net.ParseIP(host) != nil {
+		return "", host, nil
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) <= 2 {
+		return "", host, nil
+	}
+
+	cut := len(labels) - 2
+	return strings.Join(labels[:cut], "."), strings.Join(labels[cut:], "."), nil
+}
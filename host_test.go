@@ -0,0 +1,115 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Tenant string `host:"subdomain"`
+		Domain string `host:"domain"`
+	}) (interface{}, error) {
+		return gin.H{"tenant": req.Tenant, "domain": req.Domain}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/dashboard", ginHandler)
+
+	tests := []struct {
+		host           string
+		expectedTenant string
+		expectedDomain string
+	}{
+		{host: "acme.example.com", expectedTenant: "acme", expectedDomain: "example.com"},
+		{host: "eu.Acme.example.com:8443", expectedTenant: "eu.acme", expectedDomain: "example.com"},
+		{host: "example.com", expectedTenant: "", expectedDomain: "example.com"},
+		{host: "127.0.0.1:8080", expectedTenant: "", expectedDomain: "127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/dashboard", nil)
+			req.Host = tt.host
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			data := response["data"].(map[string]interface{})
+			assert.Equal(t, tt.expectedTenant, data["tenant"])
+			assert.Equal(t, tt.expectedDomain, data["domain"])
+		})
+	}
+}
+
+func TestWithHostResolver(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Tenant string `host:"subdomain"`
+	}) (interface{}, error) {
+		return gin.H{"tenant": req.Tenant}, nil
+	}
+
+	resolver := func(host string) (string, string, error) {
+		tenant, ok := strings.CutSuffix(host, ".shop.co.uk")
+		if !ok {
+			return "", "", errors.New("unknown tenant")
+		}
+		return tenant, "shop.co.uk", nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithHostResolver(resolver))
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/dashboard", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/dashboard", nil)
+	req.Host = "acme.shop.co.uk"
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"tenant":"acme"`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/dashboard", nil)
+	req.Host = "acme.example.com"
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `failed to resolve host \"acme.example.com\": unknown tenant`)
+}
+
+func TestHostTagInvalid(t *testing.T) {
+	handler := func(c *gin.Context, req struct {
+		Tenant string `host:"tenant"`
+	}) error {
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	_, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.EqualError(t, err, `field Tenant: host tag must be "subdomain" or "domain", got "tenant"`)
+}
@@ -0,0 +1,51 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// checkIfMatchTags verifies at handler build time that "ifmatch" tags are only
+// used on top-level string fields
+func checkIfMatchTags(ty reflect.Type) error {
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if _, ok := sf.Tag.Lookup("ifmatch"); !ok {
+			continue
+		}
+		if sf.Type.Kind() != reflect.String {
+			return fmt.Errorf("field %s: ifmatch tag requires a string field, got %s", sf.Name, sf.Type)
+		}
+	}
+	return nil
+}
+
+// CheckIfMatch implements the If-Match precondition for optimistic locking.
+// ifMatch is the raw header value, as bound by an `ifmatch:""` field, and etag
+// is the current entity tag of the resource, quoted or not. It returns an
+// error wrapping ErrPreconditionFailed (412 with the default response handler)
+// unless ifMatch is empty, is "*" for an existing resource, or lists etag.
+// As required by RFC 9110 the comparison is strong, so weak tags never match.
+func CheckIfMatch(ifMatch, etag string) error {
+	if ifMatch == "" {
+		return nil
+	}
+
+	if etag == "" {
+		return fmt.Errorf("resource does not exist for If-Match %s: %w", ifMatch, ErrPreconditionFailed)
+	}
+
+	etag = quoteETag(etag)
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || (candidate == etag && !strings.HasPrefix(etag, "W/")) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("resource version %s does not match If-Match %s: %w", etag, ifMatch, ErrPreconditionFailed)
+}
@@ -0,0 +1,102 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIfMatchBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	currentVersion := `"v2"`
+	handler := func(c *gin.Context, req struct {
+		ID      int    `path:"id"`
+		IfMatch string `ifmatch:""`
+		Title   string `json:"title"`
+	}) (interface{}, error) {
+		if err := CheckIfMatch(req.IfMatch, currentVersion); err != nil {
+			return nil, err
+		}
+		return gin.H{"id": req.ID, "title": req.Title}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.PUT("/articles/:id", ginHandler)
+
+	tests := []struct {
+		name            string
+		ifMatch         string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{name: "no precondition", expectedStatus: http.StatusOK},
+		{name: "matching", ifMatch: `"v2"`, expectedStatus: http.StatusOK},
+		{name: "matching in list", ifMatch: `"v1", "v2"`, expectedStatus: http.StatusOK},
+		{name: "wildcard", ifMatch: "*", expectedStatus: http.StatusOK},
+		{
+			name:            "stale",
+			ifMatch:         `"v1"`,
+			expectedStatus:  http.StatusPreconditionFailed,
+			expectedMessage: `resource version "v2" does not match If-Match "v1": precondition failed`,
+		},
+		{
+			name:            "weak tags never match",
+			ifMatch:         `W/"v2"`,
+			expectedStatus:  http.StatusPreconditionFailed,
+			expectedMessage: `resource version "v2" does not match If-Match W/"v2": precondition failed`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", "/articles/7", strings.NewReader(`{"title":"Hello"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMessage != "" {
+				var response map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMessage, response["message"])
+			}
+		})
+	}
+}
+
+func TestCheckIfMatchMissingResource(t *testing.T) {
+	assert.NoError(t, CheckIfMatch("", ""))
+
+	err := CheckIfMatch("*", "")
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+	assert.EqualError(t, err, "resource does not exist for If-Match *: precondition failed")
+
+	assert.NoError(t, CheckIfMatch(`"abc"`, "abc"))
+}
+
+func TestIfMatchTagRequiresString(t *testing.T) {
+	handler := func(c *gin.Context, req struct {
+		IfMatch int `ifmatch:""`
+	}) error {
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	_, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.EqualError(t, err, "field IfMatch: ifmatch tag requires a string field, got int")
+}
@@ -0,0 +1,142 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MIMEJSONAPI is the media type defined by the JSON:API specification
+const MIMEJSONAPI = "application/vnd.api+json"
+
+// JSONAPIResource is implemented by values rendered as JSON:API resource objects.
+// The remaining JSON fields of the value, except "id", become its attributes.
+type JSONAPIResource interface {
+	JSONAPIType() string
+	JSONAPIID() string
+}
+
+// JSONAPIResourceObject is a JSON:API resource object
+type JSONAPIResourceObject struct {
+	Type       string         `json:"type"`
+	ID         string         `json:"id"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// JSONAPIError is a JSON:API error object
+type JSONAPIError struct {
+	Status string `json:"status"`
+	Code   string `json:"code,omitempty"`
+	Title  string `json:"title"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// JSONAPIResponseHandler renders responses according to the JSON:API specification.
+// Resources and slices of resources are rendered as primary data, any other
+// success value is rendered as top-level meta, and errors are rendered as an
+// errors array.
+type JSONAPIResponseHandler struct{}
+
+// NewJSONAPIResponseHandler creates a new JSON:API response handler
+func NewJSONAPIResponseHandler() *JSONAPIResponseHandler {
+	return &JSONAPIResponseHandler{}
+}
+
+// HandleSuccess renders data as a JSON:API document
+func (h *JSONAPIResponseHandler) HandleSuccess(ctx *gin.Context, data interface{}) {
+	doc, err := jsonAPIDocument(data)
+	if err != nil {
+		h.HandleError(ctx, err)
+		return
+	}
+
+	renderJSONAPI(ctx, http.StatusOK, doc)
+}
+
+// HandleError renders err as a JSON:API errors document
+func (h *JSONAPIResponseHandler) HandleError(ctx *gin.Context, err error) {
+	statusCode, message := errorStatusAndMessage(err)
+
+	if abortClientClosedRequest(ctx, statusCode) {
+		return
+	}
+
+	renderJSONAPI(ctx, statusCode, gin.H{
+		"errors": []JSONAPIError{{
+			Status: strconv.Itoa(statusCode),
+			Code:   ErrorCode(err),
+			Title:  http.StatusText(statusCode),
+			Detail: message,
+		}},
+	})
+}
+
+// jsonAPIDocument builds the top-level document for a success value
+func jsonAPIDocument(data interface{}) (gin.H, error) {
+	if data == nil {
+		return gin.H{"data": nil}, nil
+	}
+
+	if resource, ok := data.(JSONAPIResource); ok {
+		obj, err := jsonAPIResourceObject(resource)
+		if err != nil {
+			return nil, err
+		}
+		return gin.H{"data": obj}, nil
+	}
+
+	val := reflect.ValueOf(data)
+	if (val.Kind() == reflect.Slice || val.Kind() == reflect.Array) &&
+		val.Type().Elem().Implements(reflect.TypeOf((*JSONAPIResource)(nil)).Elem()) {
+		objs := make([]JSONAPIResourceObject, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			obj, err := jsonAPIResourceObject(val.Index(i).Interface().(JSONAPIResource))
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, obj)
+		}
+		return gin.H{"data": objs}, nil
+	}
+
+	return gin.H{"meta": data}, nil
+}
+
+// jsonAPIResourceObject converts a resource into a resource object whose
+// attributes are the resource's JSON fields without "id"
+func jsonAPIResourceObject(resource JSONAPIResource) (JSONAPIResourceObject, error) {
+	raw, err := json.Marshal(resource)
+	if err != nil {
+		return JSONAPIResourceObject{}, err
+	}
+
+	var attributes map[string]any
+	if err := json.Unmarshal(raw, &attributes); err != nil {
+		return JSONAPIResourceObject{}, err
+	}
+	delete(attributes, "id")
+
+	return JSONAPIResourceObject{
+		Type:       resource.JSONAPIType(),
+		ID:         resource.JSONAPIID(),
+		Attributes: attributes,
+	}, nil
+}
+
+func renderJSONAPI(ctx *gin.Context, statusCode int, doc gin.H) {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		body, _ = json.Marshal(gin.H{
+			"errors": []JSONAPIError{{
+				Status: strconv.Itoa(statusCode),
+				Title:  http.StatusText(statusCode),
+			}},
+		})
+	}
+
+	ctx.Data(statusCode, MIMEJSONAPI, body)
+}
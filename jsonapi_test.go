@@ -0,0 +1,131 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonAPITestUser struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func (u jsonAPITestUser) JSONAPIType() string { return "users" }
+func (u jsonAPITestUser) JSONAPIID() string   { return strconv.Itoa(u.ID) }
+
+func TestJSONAPIResponseHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		handler        interface{}
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "single resource",
+			handler: func(c *gin.Context) (interface{}, error) {
+				return jsonAPITestUser{ID: 1, Name: "John", Email: "john@example.com"}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"type":"users","id":"1","attributes":{"name":"John","email":"john@example.com"}}}`,
+		},
+		{
+			name: "resource collection",
+			handler: func(c *gin.Context) (interface{}, error) {
+				return []jsonAPITestUser{{ID: 1, Name: "John"}, {ID: 2, Name: "Jane"}}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[` +
+				`{"type":"users","id":"1","attributes":{"name":"John","email":""}},` +
+				`{"type":"users","id":"2","attributes":{"name":"Jane","email":""}}]}`,
+		},
+		{
+			name: "non-resource data",
+			handler: func(c *gin.Context) (interface{}, error) {
+				return gin.H{"count": 3}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"meta":{"count":3}}`,
+		},
+		{
+			name: "no data",
+			handler: func(c *gin.Context, req struct{}) error {
+				return nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":null}`,
+		},
+		{
+			name: "handler error",
+			handler: func(c *gin.Context) (interface{}, error) {
+				return nil, ErrNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"errors":[{"status":"404","code":"NOT_FOUND","title":"Not Found","detail":"record not found"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, NewJSONAPIResponseHandler())
+			ginHandler, err := builder.FormBindingGinHandlerFunc(tt.handler)
+			assert.NoError(t, err)
+
+			router := gin.New()
+			router.POST("/test", ginHandler)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/test", strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, MIMEJSONAPI, w.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestJSONAPIResponseHandlerBindingError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		ID int `path:"id"`
+	}) (interface{}, error) {
+		return nil, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, NewJSONAPIResponseHandler())
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/users/:id", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/abc", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response struct {
+		Errors []JSONAPIError `json:"errors"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Errors, 1)
+	assert.Equal(t, "400", response.Errors[0].Status)
+	assert.Equal(t, "Bad Request", response.Errors[0].Title)
+	assert.Contains(t, response.Errors[0].Detail, `failed to parse path parameter "id"`)
+}
@@ -0,0 +1,34 @@
+package ginbinding
+
+import "fmt"
+
+// WithDuplicateKeyRejection rejects JSON request bodies repeating a key within
+// an object, such as {"role":"user","role":"admin"}. encoding/json silently
+// keeps the last value while other parsers keep the first, so a proxy or
+// validator in front of the handler could check a different value than the
+// handler binds. Rejected bodies fail with a BindingError wrapping a
+// DuplicateKeyError naming the key.
+//
+// Keys of objects decoded into structs are compared case-insensitively, the
+// way encoding/json matches them to fields, so {"role":"user","ROLE":"admin"}
+// is rejected too. Keys of map fields are compared exactly, as maps tell keys
+// differing in case apart.
+func WithDuplicateKeyRejection() Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.rejectDuplicateKeys = true
+	}
+}
+
+// DuplicateKeyError reports a key repeated within an object of a JSON request
+// body
+type DuplicateKeyError struct {
+	// Key is the repeated key
+	Key string
+	// Field is the path of the key, e.g. items[0].name
+	Field string
+}
+
+// Error implements the error interface
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key %q at %s", e.Key, e.Field)
+}
@@ -0,0 +1,135 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDuplicateKeyRejection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type item struct {
+		Name string `json:"name"`
+	}
+	handler := func(c *gin.Context, req struct {
+		Role  string            `json:"role"`
+		Items []item            `json:"items"`
+		Attrs map[string]string `json:"attrs"`
+	}) (interface{}, error) {
+		return gin.H{"role": req.Role}, nil
+	}
+
+	serve := func(body string, opts ...Option) (*httptest.ResponseRecorder, map[string]interface{}) {
+		router := gin.New()
+		router.POST("/users", NewBasicFormBindingGinHandlerBuilder(nil, nil, opts...).MustFormBindingGinHandlerFunc(handler))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedMsg    string
+		expectedField  string
+	}{
+		{
+			name:           "unique keys",
+			body:           `{"role":"user","items":[{"name":"a"},{"name":"b"}],"attrs":{"k":"1","K":"2"}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "top-level duplicate",
+			body:           `{"role":"user","items":[],"role":"admin"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `duplicate key "role" at role`,
+			expectedField:  "role",
+		},
+		{
+			name:           "duplicate differing in case",
+			body:           `{"role":"user","ROLE":"admin"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `duplicate key "ROLE" at ROLE`,
+			expectedField:  "ROLE",
+		},
+		{
+			name:           "nested duplicate differing in case",
+			body:           `{"items":[{"name":"a","Name":"b"}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `duplicate key "Name" at items[0].Name`,
+			expectedField:  "items[0].Name",
+		},
+		{
+			name:           "nested duplicate",
+			body:           `{"items":[{"name":"a"},{"name":"b","name":"c"}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `duplicate key "name" at items[1].name`,
+			expectedField:  "items[1].name",
+		},
+		{
+			name:           "map duplicate",
+			body:           `{"attrs":{"k":"1","k":"2"}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `duplicate key "k" at attrs.k`,
+			expectedField:  "attrs.k",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, response := serve(tt.body, WithDuplicateKeyRejection())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, response["message"])
+				assert.Equal(t, tt.expectedField, response["field"])
+				assert.Equal(t, "body", response["source"])
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		w, response := serve(`{"role":"user","role":"admin"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "admin", response["data"].(map[string]interface{})["role"])
+	})
+}
+
+func TestScanJSONBodyDuplicates(t *testing.T) {
+	scan := func(body string) error {
+		return scanJSONBody(json.NewDecoder(strings.NewReader(body)), nil, &JSONLimits{}, true)
+	}
+
+	assert.NoError(t, scan(`{"a":{"a":1},"b":[{"a":1},{"a":2}]}`))
+	assert.EqualError(t, scan(`{"a":{"x":1},"b":2,"a":3}`), `duplicate key "a" at a`)
+	assert.EqualError(t, scan(`[{"x":1,"y":{},"x":2}]`), `duplicate key "x" at [0].x`)
+
+	// Only keys of struct objects are folded
+	type request struct {
+		Key   string            `json:"key"`
+		Attrs map[string]string `json:"attrs"`
+		Any   any               `json:"any"`
+	}
+	scanInto := func(body string) error {
+		return scanJSONBody(json.NewDecoder(strings.NewReader(body)), reflect.TypeOf(&request{}), &JSONLimits{}, true)
+	}
+	assert.NoError(t, scanInto(`{"key":"a","attrs":{"k":"1","K":"2"},"any":{"k":1,"K":2}}`))
+	assert.EqualError(t, scanInto(`{"key":"a","KEY":"b"}`), `duplicate key "KEY" at KEY`)
+	assert.EqualError(t, scanInto(`{"\u212Aey":"a","key":"b"}`), `duplicate key "key" at key`)
+	assert.NoError(t, scan(`{"key":"a","KEY":"b"}`))
+}
@@ -0,0 +1,249 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// JSONLimits bounds the size of the structure of JSON request bodies, see
+// WithJSONLimits. Zero fields mean no limit.
+type JSONLimits struct {
+	// MaxDepth is the maximum nesting depth of arrays and objects; the
+	// top-level object of a body is at depth 1
+	MaxDepth int
+	// MaxArrayLen is the maximum number of elements of each array
+	MaxArrayLen int
+	// MaxObjectKeys is the maximum number of keys of each object, such as
+	// the entries of a map field
+	MaxObjectKeys int
+}
+
+// WithJSONLimits rejects JSON request bodies exceeding limits before they are
+// decoded, to protect handlers against payloads crafted to exhaust memory or
+// CPU, such as deeply nested arrays or maps with millions of keys. Exceeding
+// bodies fail with a BindingError wrapping a JSONLimitError, which the
+// default response handler serves with 400:
+//
+//	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil,
+//		ginbinding.WithJSONLimits(ginbinding.JSONLimits{MaxDepth: 16, MaxArrayLen: 1000, MaxObjectKeys: 100}))
+//
+// The limits complement a limit on the size of the body, e.g. with
+// http.MaxBytesReader, which bounds the time the checks take.
+func WithJSONLimits(limits JSONLimits) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		if limits == (JSONLimits{}) {
+			builder.jsonLimits = nil
+			return
+		}
+		builder.jsonLimits = &limits
+	}
+}
+
+// JSONLimitError reports a JSON request body exceeding a limit of JSONLimits
+type JSONLimitError struct {
+	// Limit names the exceeded limit: "depth", "array length" or "object
+	// keys"
+	Limit string
+	// Max is the value of the exceeded limit
+	Max int
+	// Field is the path of the array or object exceeding the limit, e.g.
+	// items[0].tags, or empty for the body itself
+	Field string
+}
+
+// Error implements the error interface
+func (e *JSONLimitError) Error() string {
+	field := e.Field
+	if field == "" {
+		field = "body"
+	}
+	switch e.Limit {
+	case "depth":
+		return fmt.Sprintf("%s exceeds the maximum nesting depth of %d", field, e.Max)
+	case "array length":
+		return fmt.Sprintf("%s exceeds the maximum array length of %d", field, e.Max)
+	default:
+		return fmt.Sprintf("%s exceeds the maximum of %d object keys", field, e.Max)
+	}
+}
+
+// checkJSONBody checks the JSON body of ctx, decoded into a value of type ty,
+// against limits, if not nil, and for duplicate keys if duplicates is set,
+// leaving the body to be decoded again
+func checkJSONBody(ctx *gin.Context, ty reflect.Type, limits *JSONLimits, duplicates bool) error {
+	if ctx.Request.Body == nil || ctx.ContentType() != binding.MIMEJSON {
+		return nil
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return &inputError{source: SourceBody, err: err}
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if limits == nil {
+		limits = &JSONLimits{}
+	}
+	if err := scanJSONBody(json.NewDecoder(bytes.NewReader(body)), ty, limits, duplicates); err != nil {
+		return &inputError{source: SourceBody, err: err}
+	}
+	return nil
+}
+
+// jsonContainer is an array or object open while scanning a JSON document
+type jsonContainer struct {
+	array bool
+	// n is the number of elements or keys seen so far
+	n int
+	// key is the last key of an object
+	key string
+	// value is set while the value of key is read
+	value bool
+	// keys holds the keys of an object seen so far when checking for
+	// duplicates, folded for objects decoded into structs
+	keys map[string]bool
+	// ty is the type the container is decoded into, nil when unknown
+	ty reflect.Type
+}
+
+// elemType returns the type the value of the current element or key of c is
+// decoded into, nil when unknown
+func (c *jsonContainer) elemType() reflect.Type {
+	ty := c.ty
+	if ty == nil {
+		return nil
+	}
+	switch {
+	case ty.Kind() == reflect.Struct && !c.array:
+		if f, ok := schemaFieldsOf(ty).lookup(c.key); ok {
+			return jsonValueType(f.ty)
+		}
+	case ty.Kind() == reflect.Map && !c.array, (ty.Kind() == reflect.Slice || ty.Kind() == reflect.Array) && c.array:
+		return jsonValueType(ty.Elem())
+	}
+	return nil
+}
+
+// jsonValueType returns the type encoding/json decodes the JSON values of
+// fields of type ty with, nil for values it does not decode itself
+func jsonValueType(ty reflect.Type) reflect.Type {
+	for ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	if ty.Kind() == reflect.Interface || reflect.PointerTo(ty).Implements(jsonUnmarshalerTy) || reflect.PointerTo(ty).Implements(textUnmarshalerTy) {
+		return nil
+	}
+	return ty
+}
+
+// foldJSONKey folds key the way encoding/json matches keys to struct fields,
+// so that keys matching the same field fold to the same string
+func foldJSONKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		// The smallest rune of the fold set of r
+		for {
+			folded := unicode.SimpleFold(r)
+			if folded <= r {
+				return folded
+			}
+			r = folded
+		}
+	}, key)
+}
+
+// scanJSONBody reads the tokens of the JSON document of dec until a limit is
+// exceeded or, with duplicates, a key is repeated within an object. Keys of
+// objects decoded into structs of the type ty are compared case-insensitively,
+// as encoding/json matches them to fields; keys of other objects, such as
+// maps, are compared exactly. Malformed documents are left to the JSON binding
+// to report.
+func scanJSONBody(dec *json.Decoder, ty reflect.Type, limits *JSONLimits, duplicates bool) error {
+	dec.UseNumber()
+	var stack []jsonContainer
+	if ty != nil {
+		ty = jsonValueType(ty)
+	}
+
+	// path returns the path of the innermost container
+	path := func() string {
+		var p string
+		for _, c := range stack[:len(stack)-1] {
+			if c.array {
+				p += "[" + strconv.Itoa(c.n-1) + "]"
+			} else {
+				p = joinJSONPath(p, c.key)
+			}
+		}
+		return p
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+
+		delim, isDelim := tok.(json.Delim)
+		if isDelim && (delim == ']' || delim == '}') {
+			// A closed container completes the value of its parent object
+			stack = stack[:len(stack)-1]
+			if len(stack) > 0 {
+				stack[len(stack)-1].value = false
+			}
+			continue
+		}
+
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			switch {
+			case top.array:
+				top.n++
+				if limits.MaxArrayLen > 0 && top.n > limits.MaxArrayLen {
+					return &JSONLimitError{Limit: "array length", Max: limits.MaxArrayLen, Field: path()}
+				}
+			case !top.value:
+				top.key, top.value = tok.(string), true
+				top.n++
+				if limits.MaxObjectKeys > 0 && top.n > limits.MaxObjectKeys {
+					return &JSONLimitError{Limit: "object keys", Max: limits.MaxObjectKeys, Field: path()}
+				}
+				if duplicates {
+					key := top.key
+					if top.ty != nil && top.ty.Kind() == reflect.Struct {
+						key = foldJSONKey(key)
+					}
+					if top.keys[key] {
+						return &DuplicateKeyError{Key: top.key, Field: joinJSONPath(path(), top.key)}
+					}
+					if top.keys == nil {
+						top.keys = map[string]bool{}
+					}
+					top.keys[key] = true
+				}
+				continue
+			case !isDelim:
+				top.value = false
+			}
+		}
+
+		if isDelim {
+			elemTy := ty
+			if len(stack) > 0 {
+				elemTy = stack[len(stack)-1].elemType()
+			}
+			stack = append(stack, jsonContainer{array: delim == '[', ty: elemTy})
+			if limits.MaxDepth > 0 && len(stack) > limits.MaxDepth {
+				return &JSONLimitError{Limit: "depth", Max: limits.MaxDepth, Field: path()}
+			}
+		}
+	}
+}
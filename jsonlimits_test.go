@@ -0,0 +1,122 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithJSONLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type item struct {
+		Tags []string `json:"tags"`
+	}
+	handler := func(c *gin.Context, req struct {
+		Items []item           `json:"items"`
+		Meta  map[string]any   `json:"meta"`
+		Attrs map[string]int64 `json:"attrs"`
+	}) (interface{}, error) {
+		return gin.H{"items": len(req.Items)}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil,
+		WithJSONLimits(JSONLimits{MaxDepth: 4, MaxArrayLen: 3, MaxObjectKeys: 3}))
+	router := gin.New()
+	router.POST("/items", builder.MustFormBindingGinHandlerFunc(handler))
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedMsg    string
+		expectedField  string
+	}{
+		{
+			name:           "within limits",
+			body:           `{"items":[{"tags":["a","b","c"]},{"tags":[]}],"meta":{"a":{"b":1}},"attrs":{"x":1,"y":2,"z":3}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "too deep",
+			body:           `{"meta":{"a":{"b":{"c":{}}}}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "meta.a.b.c exceeds the maximum nesting depth of 4",
+			expectedField:  "meta.a.b.c",
+		},
+		{
+			name:           "too deep array",
+			body:           `{"items":[{"tags":[["a"]]}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "items[0].tags[0] exceeds the maximum nesting depth of 4",
+			expectedField:  "items[0].tags[0]",
+		},
+		{
+			name:           "array too long",
+			body:           `{"items":[{"tags":[]},{"tags":["a","b","c","d"]}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "items[1].tags exceeds the maximum array length of 3",
+			expectedField:  "items[1].tags",
+		},
+		{
+			name:           "too many keys",
+			body:           `{"attrs":{"a":1,"b":2,"c":3,"d":4}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "attrs exceeds the maximum of 3 object keys",
+			expectedField:  "attrs",
+		},
+		{
+			name:           "too many top-level keys",
+			body:           `{"items":[],"meta":{},"attrs":{},"other":1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "body exceeds the maximum of 3 object keys",
+		},
+		{
+			name:           "malformed body",
+			body:           `{"items":[`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/items", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, response["message"])
+			}
+			if tt.expectedField != "" {
+				assert.Equal(t, tt.expectedField, response["field"])
+			}
+		})
+	}
+}
+
+func TestScanJSONBodyLimits(t *testing.T) {
+	scan := func(body string, limits JSONLimits) error {
+		return scanJSONBody(json.NewDecoder(strings.NewReader(body)), nil, &limits, false)
+	}
+
+	assert.NoError(t, scan(`[[1,2],[3,4]]`, JSONLimits{MaxDepth: 2, MaxArrayLen: 2}))
+	assert.EqualError(t, scan(`[[1,2],[3,4,5]]`, JSONLimits{MaxArrayLen: 2}), "[1] exceeds the maximum array length of 2")
+	assert.EqualError(t, scan(`[1,2,3]`, JSONLimits{MaxArrayLen: 2}), "body exceeds the maximum array length of 2")
+	assert.EqualError(t, scan(`[[[1]]]`, JSONLimits{MaxDepth: 2}), "[0][0] exceeds the maximum nesting depth of 2")
+	// Keys after nested values are counted in their own object
+	assert.NoError(t, scan(`{"a":{"x":1,"y":2},"b":[1],"c":3}`, JSONLimits{MaxObjectKeys: 3}))
+	assert.EqualError(t, scan(`{"a":{"x":1},"b":{"y":1,"z":2,"w":3}}`, JSONLimits{MaxObjectKeys: 2}), "b exceeds the maximum of 2 object keys")
+	assert.NoError(t, scan(`"scalar"`, JSONLimits{MaxDepth: 1}))
+}
@@ -0,0 +1,108 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// knownTagKeys are the struct tag keys read by this package, by gin's
+// bindings and by its validator
+var knownTagKeys = []string{
+	"json", "form", "query", "path", "uri", "header", "host", "tls", "pseudo",
+	"trailer", "file", "part", "ifmatch", "ctx", "kind", "binding", "validate",
+	"time_format", "time_utc", "time_location", "collection_format", "mod",
+	"enum", "pattern", "idsafe", "require", "requires_role", "sanitize", "unit",
+	"numfmt", "errmsg", "deprecated", "bindable", "doc", "example", "xml",
+	"yaml", "toml", "msgpack",
+}
+
+// WithSchemaLint checks the request structs of handlers for suspicious
+// definitions when the handler is built, failing FormBindingGinHandlerFunc
+// instead of requests: form and json tags naming a field differently, input
+// tags on unexported fields, which are never bound, and tag keys this package,
+// gin and its validator do not know, such as a misspelled "hedaer". tagKeys
+// lists the keys of other tooling to accept, e.g. "gorm" or "db". Default
+// values are checked for every handler.
+//
+//	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil,
+//		ginbinding.WithSchemaLint("gorm"))
+func WithSchemaLint(tagKeys ...string) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.lint = true
+		// Always reallocate so that per-handler keys never leak into the
+		// builder the handler was copied from
+		keys := builder.lintTagKeys
+		builder.lintTagKeys = append(keys[:len(keys):len(keys)], tagKeys...)
+	}
+}
+
+// lintRequestType reports the suspicious definitions of the request struct
+// type ty, and of the structs nested in it, in a single error
+func (builder *BasicFormBindingGinHandlerBuilder) lintRequestType(ty reflect.Type) error {
+	if !builder.lint || ty == mapAnyTy {
+		return nil
+	}
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+
+	var issues []string
+	builder.lintStruct(ty, "", map[reflect.Type]bool{}, &issues)
+	if len(issues) == 0 {
+		return nil
+	}
+	return fmt.Errorf("schema lint of %s: %s", ty, strings.Join(issues, "; "))
+}
+
+// lintStruct appends the issues of the fields of ty, whose path is path, to
+// issues
+func (builder *BasicFormBindingGinHandlerBuilder) lintStruct(ty reflect.Type, path string, seen map[reflect.Type]bool, issues *[]string) {
+	if seen[ty] {
+		return
+	}
+	seen[ty] = true
+
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		fieldPath := path
+		if !sf.Anonymous {
+			fieldPath = joinFieldPath(path, sf.Name)
+		}
+		report := func(format string, args ...any) {
+			*issues = append(*issues, fmt.Sprintf("field %s: ", fieldPath)+fmt.Sprintf(format, args...))
+		}
+
+		if !sf.IsExported() {
+			for _, key := range []string{"path", "uri", "form", "query", "header"} {
+				if _, ok := sf.Tag.Lookup(key); ok {
+					report("%s tag on an unexported field, which is never bound", key)
+				}
+			}
+			continue
+		}
+
+		for _, kv := range parseStructTag(sf.Tag) {
+			key, version, versioned := strings.Cut(kv[0], "@")
+			switch {
+			case versioned && (version == "" || !slices.Contains(versionedTagKeys, key)):
+				report("unknown versioned tag %s", kv[0])
+			case !slices.Contains(knownTagKeys, key) && key != builder.defaultTag && !slices.Contains(builder.lintTagKeys, key):
+				report("unknown tag %s", key)
+			}
+		}
+
+		formName, hasForm := queryTag(sf)
+		jsonName, hasJSON := sf.Tag.Lookup("json")
+		formName, _, _ = strings.Cut(formName, ",")
+		jsonName, _, _ = strings.Cut(jsonName, ",")
+		if hasForm && hasJSON && formName != "" && jsonName != "" && formName != "-" && jsonName != "-" && formName != jsonName {
+			report("form name %q differs from json name %q", formName, jsonName)
+		}
+
+		if nested := nestedStructType(sf.Type); nested != nil {
+			builder.lintStruct(nested, fieldPath, seen, issues)
+		}
+	}
+}
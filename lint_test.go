@@ -0,0 +1,68 @@
+package ginbinding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSchemaLint(t *testing.T) {
+	type address struct {
+		City string `json:"city" form:"town"`
+	}
+	type clean struct {
+		ID      int           `path:"id"`
+		Page    int           `form:"page" json:"page,omitempty" default:"1" binding:"min=1"`
+		Since   time.Time     `form:"since" time_format:"2006-01-02" default:"2024-01-01"`
+		Timeout time.Duration `form:"timeout" default:"30s"`
+		Token   string        `header:"X-Token" doc:"API token" example:"abc"`
+		Name    string        `json:"name" json@v1:"full_name" gorm:"column:name"`
+		Status  string        `json:"status" form:"-"`
+		Tags    []string      `json:"tags" enum:"a,b"`
+		secret  string
+	}
+	type suspicious struct {
+		ID      int    `json:"id" form:"user_id"`
+		Token   string `hedaer:"X-Token"`
+		Name    string `json:"name" json@:"n" binding@v1:"required"`
+		Address address
+		owner   string `path:"owner"`
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req suspicious) error { return nil })
+	assert.NoError(t, err, "lint is disabled by default")
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req clean) error { return nil },
+		WithSchemaLint("gorm"))
+	assert.NoError(t, err)
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req clean) error { return nil },
+		WithSchemaLint())
+	assert.EqualError(t, err, "schema lint of ginbinding.clean: field Name: unknown tag gorm")
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req *suspicious) error { return nil },
+		WithSchemaLint())
+	if assert.Error(t, err) {
+		for _, issue := range []string{
+			`field ID: form name "user_id" differs from json name "id"`,
+			`field Token: unknown tag hedaer`,
+			`field Name: unknown versioned tag json@`,
+			`field Name: unknown versioned tag binding@v1`,
+			`field Address.City: form name "town" differs from json name "city"`,
+			`field owner: path tag on an unexported field, which is never bound`,
+		} {
+			assert.Contains(t, err.Error(), issue)
+		}
+	}
+
+	type renamedDefault struct {
+		Order string `form:"order" fallback:"asc" default:"x"`
+	}
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req renamedDefault) error { return nil },
+		WithDefaultTag("fallback"), WithSchemaLint())
+	assert.EqualError(t, err, "schema lint of ginbinding.renamedDefault: field Order: unknown tag default")
+}
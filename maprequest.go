@@ -0,0 +1,71 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+var mapAnyTy = reflect.TypeOf(map[string]any{})
+
+// bindMap binds the request into a map for handlers that take a
+// map[string]any request. A JSON body is decoded as is, form bodies map each
+// key to a string or, for repeated keys, a []string. Query parameters are
+// merged in the same way for keys the body does not set.
+func bindMap(ctx *gin.Context, useNumber bool) (map[string]any, error) {
+	m := map[string]any{}
+
+	switch contentType := ctx.ContentType(); contentType {
+	case "", binding.MIMEJSON:
+		if ctx.Request.Body == nil {
+			break
+		}
+		decoder := json.NewDecoder(ctx.Request.Body)
+		if useNumber || binding.EnableDecoderUseNumber {
+			decoder.UseNumber()
+		}
+		if err := decoder.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if m == nil {
+			// A JSON null body
+			m = map[string]any{}
+		}
+	case binding.MIMEPOSTForm:
+		if err := ctx.Request.ParseForm(); err != nil {
+			return nil, err
+		}
+		mergeValues(m, ctx.Request.PostForm)
+	case binding.MIMEMultipartPOSTForm:
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			return nil, err
+		}
+		mergeValues(m, form.Value)
+	default:
+		return nil, fmt.Errorf("unsupported content type %q for a map request", contentType)
+	}
+
+	mergeValues(m, ctx.Request.URL.Query())
+	return m, nil
+}
+
+// mergeValues adds the keys of values that are not yet in m
+func mergeValues(m map[string]any, values url.Values) {
+	for key, vs := range values {
+		if _, ok := m[key]; ok || len(vs) == 0 {
+			continue
+		}
+		if len(vs) == 1 {
+			m[key] = vs[0]
+		} else {
+			m[key] = vs
+		}
+	}
+}
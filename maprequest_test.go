@@ -0,0 +1,122 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req map[string]any) (interface{}, error) {
+		return req, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.Any("/proxy", ginHandler)
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		expected    map[string]interface{}
+	}{
+		{
+			name:        "json body with query",
+			method:      "POST",
+			target:      "/proxy?tag=a&tag=b&name=ignored",
+			contentType: "application/json",
+			body:        `{"name":"widget","price":9.5,"meta":{"color":"red"}}`,
+			expected: map[string]interface{}{
+				"name":  "widget",
+				"price": 9.5,
+				"meta":  map[string]interface{}{"color": "red"},
+				"tag":   []interface{}{"a", "b"},
+			},
+		},
+		{
+			name:     "query only",
+			method:   "GET",
+			target:   "/proxy?q=shoes",
+			expected: map[string]interface{}{"q": "shoes"},
+		},
+		{
+			name:        "form body",
+			method:      "POST",
+			target:      "/proxy",
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"name": {"widget"}}.Encode(),
+			expected:    map[string]interface{}{"name": "widget"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, response["data"])
+		})
+	}
+}
+
+func TestMapRequest_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req map[string]any) (interface{}, error) {
+		return req, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/proxy", ginHandler)
+
+	for _, contentType := range []string{"application/json", "application/xml"} {
+		t.Run(contentType, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/proxy", strings.NewReader(`[1, 2`))
+			req.Header.Set("Content-Type", contentType)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestMapRequest_NoBodyStruct(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req map[string]any, body struct {
+		Name string `json:"name"`
+	}) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
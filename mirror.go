@@ -0,0 +1,197 @@
+package ginbinding
+
+import (
+	"context"
+	"math/rand/v2"
+	"reflect"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMirrorInFlight is the number of mirrored requests in progress beyond
+// which WithMirror drops requests when MirrorConfig.MaxInFlight is zero
+const DefaultMirrorInFlight = 64
+
+// MirrorSink receives copies of bound requests, e.g. to call a new
+// implementation of a handler with live traffic. Handlers with a separate body
+// struct pass the struct of the other sources as req and the body struct as
+// body, which is nil for other handlers. ctx carries the values of the request
+// context but is not canceled when the request ends.
+type MirrorSink func(ctx context.Context, req, body any)
+
+// MirrorTo returns a MirrorSink calling fn with the requests of type Req, such
+// as the shadow implementation of a handler. Requests of other types are
+// ignored.
+//
+//	ginbinding.MirrorTo(func(ctx context.Context, req SearchRequest) {
+//		if _, err := searchV2.Search(ctx, req); err != nil {
+//			log.Printf("search v2: %v", err)
+//		}
+//	})
+func MirrorTo[Req any](fn func(ctx context.Context, req Req)) MirrorSink {
+	return func(ctx context.Context, req, body any) {
+		if req, ok := req.(Req); ok {
+			fn(ctx, req)
+		}
+	}
+}
+
+// MirrorSplitTo is MirrorTo for handlers with a separate body struct, calling
+// fn with the requests of type Req and bodies of type Body. Requests of other
+// types are ignored.
+//
+//	ginbinding.MirrorSplitTo(func(ctx context.Context, q UpdateQuery, body UpdateBody) {
+//		usersV2.Update(ctx, q, body)
+//	})
+func MirrorSplitTo[Req, Body any](fn func(ctx context.Context, req Req, body Body)) MirrorSink {
+	return func(ctx context.Context, req, body any) {
+		r, ok := req.(Req)
+		if !ok {
+			return
+		}
+		if b, ok := body.(Body); ok {
+			fn(ctx, r, b)
+		}
+	}
+}
+
+// MirrorConfig configures the requests WithMirror forwards
+type MirrorConfig struct {
+	// Sink receives the copies of the mirrored requests
+	Sink MirrorSink
+	// Rate is the fraction of requests mirrored, between 0 and 1. Zero
+	// mirrors every request.
+	Rate float64
+	// Sample selects the requests to mirror, if not nil, given the bound
+	// request and body structs as passed to Sink. It runs before Rate is
+	// applied.
+	Sample func(ctx *gin.Context, req, body any) bool
+	// MaxInFlight is the number of mirrored requests in progress beyond
+	// which requests are dropped instead of mirrored, or zero for
+	// DefaultMirrorInFlight
+	MaxInFlight int
+	// Timeout bounds the context passed to Sink, if not zero
+	Timeout time.Duration
+}
+
+// WithMirror forwards a copy of the bound request struct of sampled requests,
+// and of the body struct of handlers with a separate one, to config.Sink, for
+// dark launches of new implementations against live traffic. Sinks run
+// asynchronously after binding and validation succeeded, and never affect the
+// response: they get deep copies of the request and body, so changes made by
+// the handler or by the sink are not shared, and panics of the sink are
+// recovered. Handlers without a request are not mirrored, and the items of a
+// Stream body are not either, since the handler reads them.
+//
+//	r.GET("/search", builder.MustFormBindingGinHandlerFunc(search, ginbinding.WithMirror(ginbinding.MirrorConfig{
+//		Sink: ginbinding.MirrorTo(searchV2),
+//		Rate: 0.1,
+//	})))
+func WithMirror(config MirrorConfig) Option {
+	if config.MaxInFlight <= 0 {
+		config.MaxInFlight = DefaultMirrorInFlight
+	}
+	m := &mirror{config: config, sem: make(chan struct{}, config.MaxInFlight)}
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.mirror = m
+	}
+}
+
+// mirror is the configuration of WithMirror. Its semaphore is shared by the
+// handlers built with the option.
+type mirror struct {
+	config MirrorConfig
+	sem    chan struct{}
+}
+
+// forward sends copies of the bound request req and body of ctx to the sink
+// if they are sampled and a slot is free. body is invalid for handlers
+// without a separate body struct.
+func (m *mirror) forward(ctx *gin.Context, req, body reflect.Value) {
+	if !req.IsValid() {
+		return
+	}
+	if m.config.Sample != nil && !m.config.Sample(ctx, req.Interface(), hookRequest(body)) {
+		return
+	}
+	if m.config.Rate > 0 && rand.Float64() >= m.config.Rate {
+		return
+	}
+
+	select {
+	case m.sem <- struct{}{}:
+	default:
+		return
+	}
+
+	// The copies are taken before the handler runs and pooled requests are
+	// reset
+	reqCopy := deepCopy(req).Interface()
+	var bodyCopy any
+	if body.IsValid() {
+		bodyCopy = deepCopy(body).Interface()
+	}
+	mirrorCtx := context.WithoutCancel(ctx.Request.Context())
+	go func() {
+		defer func() { <-m.sem }()
+		defer func() { _ = recover() }()
+
+		ctx := mirrorCtx
+		if m.config.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, m.config.Timeout)
+			defer cancel()
+		}
+		m.config.Sink(ctx, reqCopy, bodyCopy)
+	}()
+}
+
+// deepCopy returns a copy of v sharing no pointers, slices or maps with it.
+// Unexported fields, functions and channels are copied shallowly.
+func deepCopy(v reflect.Value) reflect.Value {
+	cp := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			elem := reflect.New(v.Type().Elem())
+			elem.Elem().Set(deepCopy(v.Elem()))
+			cp.Set(elem)
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			cp.Set(deepCopy(v.Elem()))
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				s.Index(i).Set(deepCopy(v.Index(i)))
+			}
+			cp.Set(s)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i)))
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			m := reflect.MakeMapWithSize(v.Type(), v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				m.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+			}
+			cp.Set(m)
+		}
+	case reflect.Struct:
+		cp.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				cp.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+	default:
+		cp.Set(v)
+	}
+	return cp
+}
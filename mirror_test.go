@@ -0,0 +1,178 @@
+package ginbinding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mirrorRequest struct {
+	ID   string            `path:"id"`
+	Tags []string          `form:"tag"`
+	Meta map[string]string `json:"meta"`
+}
+
+func TestWithMirror(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(config MirrorConfig, target string) *httptest.ResponseRecorder {
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+		router := gin.New()
+		router.POST("/items/:id", builder.MustFormBindingGinHandlerFunc(func(req *mirrorRequest) (any, error) {
+			// Changes of the handler are not seen by the sink
+			req.Tags[0] = "changed"
+			req.Meta["k"] = "changed"
+			return req.ID, nil
+		}, WithMirror(config)))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", target, strings.NewReader(`{"meta":{"k":"v"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("copy", func(t *testing.T) {
+		mirrored := make(chan mirrorRequest, 1)
+		w := serve(MirrorConfig{Sink: MirrorTo(func(ctx context.Context, req *mirrorRequest) {
+			mirrored <- *req
+		})}, "/items/1?tag=a")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		select {
+		case req := <-mirrored:
+			assert.Equal(t, mirrorRequest{ID: "1", Tags: []string{"a"}, Meta: map[string]string{"k": "v"}}, req)
+		case <-time.After(time.Second):
+			t.Fatal("request not mirrored")
+		}
+	})
+
+	t.Run("sampling", func(t *testing.T) {
+		mirrored := make(chan any, 1)
+		sink := func(ctx context.Context, req, body any) { mirrored <- req }
+
+		serve(MirrorConfig{Sink: sink, Sample: func(ctx *gin.Context, req, body any) bool {
+			return req.(*mirrorRequest).ID != "skip"
+		}}, "/items/skip?tag=a")
+		serve(MirrorConfig{Sink: sink, Rate: 1e-300}, "/items/1?tag=a")
+
+		select {
+		case <-mirrored:
+			t.Fatal("request mirrored")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("in flight", func(t *testing.T) {
+		release := make(chan struct{})
+		calls := make(chan struct{}, 2)
+		config := MirrorConfig{MaxInFlight: 1, Sink: func(ctx context.Context, req, body any) {
+			calls <- struct{}{}
+			<-release
+		}}
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithMirror(config))
+		router := gin.New()
+		router.GET("/items/:id", builder.MustFormBindingGinHandlerFunc(func(req mirrorRequest) error {
+			return nil
+		}))
+		for range 2 {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/items/1", nil)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+		close(release)
+
+		<-calls
+		assert.Empty(t, calls)
+	})
+
+	t.Run("panic and timeout", func(t *testing.T) {
+		deadline := make(chan bool, 1)
+		serve(MirrorConfig{Timeout: time.Minute, Sink: func(ctx context.Context, req, body any) {
+			_, ok := ctx.Deadline()
+			deadline <- ok
+			panic("shadow failure")
+		}}, "/items/1?tag=a")
+
+		select {
+		case ok := <-deadline:
+			require.True(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("request not mirrored")
+		}
+	})
+
+	t.Run("body", func(t *testing.T) {
+		type query struct {
+			ID string `path:"id"`
+		}
+		type body struct {
+			Tags []string `json:"tags"`
+		}
+
+		type mirroredRequest struct {
+			query query
+			body  body
+		}
+		mirrored := make(chan mirroredRequest, 1)
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+		router := gin.New()
+		router.PUT("/items/:id", builder.MustFormBindingGinHandlerFunc(func(q query, b *body) error {
+			b.Tags[0] = "changed"
+			return nil
+		}, WithMirror(MirrorConfig{
+			Sink: MirrorSplitTo(func(ctx context.Context, q query, b *body) {
+				mirrored <- mirroredRequest{query: q, body: *b}
+			}),
+			Sample: func(ctx *gin.Context, req, b any) bool {
+				return len(b.(*body).Tags) > 0
+			},
+		})))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/items/1", strings.NewReader(`{"tags":["a"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		select {
+		case m := <-mirrored:
+			assert.Equal(t, mirroredRequest{query: query{ID: "1"}, body: body{Tags: []string{"a"}}}, m)
+		case <-time.After(time.Second):
+			t.Fatal("request not mirrored")
+		}
+	})
+}
+
+func TestDeepCopy(t *testing.T) {
+	type inner struct {
+		Values []int
+	}
+	type outer struct {
+		Ptr   *inner
+		Any   any
+		Array [1]*int
+		note  []int
+	}
+
+	n := 1
+	src := outer{Ptr: &inner{Values: []int{1}}, Any: &inner{Values: []int{2}}, Array: [1]*int{&n}, note: []int{3}}
+	cp := deepCopy(reflect.ValueOf(src)).Interface().(outer)
+	assert.Equal(t, src, cp)
+
+	src.Ptr.Values[0] = 10
+	src.Any.(*inner).Values[0] = 20
+	*src.Array[0] = 30
+	assert.Equal(t, []int{1}, cp.Ptr.Values)
+	assert.Equal(t, []int{2}, cp.Any.(*inner).Values)
+	assert.Equal(t, 1, *cp.Array[0])
+}
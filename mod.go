@@ -0,0 +1,118 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// stringModifiers maps the names accepted by the "mod" tag to their implementation
+var stringModifiers = map[string]func(string) string{
+	"trim":     strings.TrimSpace,
+	"ltrim":    func(s string) string { return strings.TrimLeftFunc(s, unicode.IsSpace) },
+	"rtrim":    func(s string) string { return strings.TrimRightFunc(s, unicode.IsSpace) },
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+	"collapse": collapseSpaces,
+	"nfc":      norm.NFC.String,
+	"nfd":      norm.NFD.String,
+	"nfkc":     norm.NFKC.String,
+	"nfkd":     norm.NFKD.String,
+}
+
+// parseModifiers resolves a comma separated "mod" tag into the modifiers to apply in order
+func parseModifiers(tag string) ([]func(string) string, error) {
+	var mods []func(string) string
+
+	for _, name := range strings.Split(tag, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		mod, ok := stringModifiers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown modifier %q", name)
+		}
+		mods = append(mods, mod)
+	}
+
+	return mods, nil
+}
+
+// checkModifiers verifies every "mod" tag of ty at handler build time
+func checkModifiers(ty reflect.Type) error {
+	return walkTypeFields(ty, func(sf reflect.StructField) error {
+		tag, ok := sf.Tag.Lookup("mod")
+		if !ok {
+			return nil
+		}
+		if _, err := parseModifiers(tag); err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		if !isModifiableType(sf.Type) {
+			return fmt.Errorf("field %s: mod tag requires a string, *string or []string field, got %s", sf.Name, sf.Type)
+		}
+		return nil
+	})
+}
+
+// applyModifiers runs the "mod" tag transformations on every string field of val
+func applyModifiers(val reflect.Value) error {
+	if !typeInfoOf(val.Type()).modifiers {
+		return nil
+	}
+
+	return walkFields(val, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		tag, ok := sf.Tag.Lookup("mod")
+		if !ok {
+			return nil
+		}
+		mods, err := parseModifiers(tag)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", path, err)
+		}
+		modifyValue(fieldVal, mods)
+		return nil
+	})
+}
+
+// modifyValue applies mods to a string, *string or []string value in place
+func modifyValue(val reflect.Value, mods []func(string) string) {
+	switch val.Kind() {
+	case reflect.String:
+		s := val.String()
+		for _, mod := range mods {
+			s = mod(s)
+		}
+		val.SetString(s)
+	case reflect.Pointer:
+		if !val.IsNil() {
+			modifyValue(val.Elem(), mods)
+		}
+	case reflect.Slice, reflect.Array:
+		for j := 0; j < val.Len(); j++ {
+			modifyValue(val.Index(j), mods)
+		}
+	}
+}
+
+// isModifiableType reports whether ty is a string kind or a pointer/slice of one
+func isModifiableType(ty reflect.Type) bool {
+	switch ty.Kind() {
+	case reflect.String:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return isModifiableType(ty.Elem())
+	default:
+		return false
+	}
+}
+
+// collapseSpaces replaces every run of whitespace with a single space and trims both ends
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,137 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModifierTags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type Profile struct {
+		Bio string `json:"bio" mod:"collapse"`
+	}
+
+	handler := func(c *gin.Context, req struct {
+		Email    string   `json:"email" mod:"trim,lower"`
+		Code     string   `form:"code" mod:"trim,upper"`
+		Nickname *string  `json:"nickname" mod:"rtrim"`
+		Tags     []string `json:"tags" mod:"trim,lower"`
+		Name     string   `json:"name" mod:"nfc"`
+		Country  string   `json:"country" default:"  cn " mod:"trim,upper"`
+		Profile  Profile  `json:"profile"`
+	}) (interface{}, error) {
+		return gin.H{
+			"email":    req.Email,
+			"code":     req.Code,
+			"nickname": *req.Nickname,
+			"tags":     req.Tags,
+			"name":     req.Name,
+			"country":  req.Country,
+			"bio":      req.Profile.Bio,
+		}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/test", ginHandler)
+
+	body := `{"email":"  John@Example.COM ","nickname":"  neo  ","tags":[" Go ","GIN"],` +
+		`"name":"Cafe\u0301","profile":{"bio":"  hello \n\t  world  "}}`
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/test?code=%20ab1%20", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "john@example.com", data["email"])
+	assert.Equal(t, "AB1", data["code"])
+	assert.Equal(t, "  neo", data["nickname"])
+	assert.Equal(t, []interface{}{"go", "gin"}, data["tags"])
+	assert.Equal(t, "Caf\u00e9", data["name"])
+	assert.Equal(t, "CN", data["country"])
+	assert.Equal(t, "hello world", data["bio"])
+}
+
+func TestModifierTagsRunBeforeValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var validated string
+	validator := &capturingValidator{capture: func(obj interface{}) {
+		validated = obj.(struct {
+			Name string `json:"name" mod:"trim"`
+		}).Name
+	}}
+
+	handler := func(c *gin.Context, req struct {
+		Name string `json:"name" mod:"trim"`
+	}) error {
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(validator, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/test", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/test", strings.NewReader(`{"name":"  john  "}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "john", validated)
+}
+
+func TestModifierTagsInvalidAtBuildTime(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Name string `json:"name" mod:"trim,shout"`
+	}) error {
+		return nil
+	})
+	assert.EqualError(t, err, `field Name: unknown modifier "shout"`)
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req *struct {
+		Age int `json:"age" mod:"trim"`
+	}) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "mod tag requires a string")
+}
+
+// capturingValidator records the struct passed to ValidateStruct
+type capturingValidator struct {
+	capture func(obj interface{})
+}
+
+func (v *capturingValidator) ValidateStruct(obj interface{}) error {
+	v.capture(obj)
+	return nil
+}
+
+func (v *capturingValidator) Engine() interface{} {
+	return nil
+}
@@ -0,0 +1,141 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+var (
+	fileHeaderPtrTy   = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceTy = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// checkMultipartTags verifies every "file" tag of ty at handler build time
+func checkMultipartTags(ty reflect.Type) error {
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if _, ok := sf.Tag.Lookup("file"); !ok || !sf.IsExported() {
+			continue
+		}
+		switch sf.Type {
+		case fileHeaderPtrTy, fileHeaderSliceTy, uploadPtrTy, uploadSliceTy:
+		default:
+			return fmt.Errorf("field %s: file tag requires a %s, %s, %s or %s field, got %s", sf.Name, fileHeaderPtrTy, fileHeaderSliceTy, uploadPtrTy, uploadSliceTy, sf.Type)
+		}
+	}
+	return nil
+}
+
+// bindMultipartParts sets the fields of the struct pointed to by val tagged
+// `file:"name"` to the files uploaded in the part name, rejecting files over
+// the upload size limit, and JSON-decodes the part named by the `part:"name"`
+// tag of a field into it. The part may be sent as a form value or as a file,
+// as some clients send JSON documents.
+// These fields are never bound from other inputs, so they are left zero for
+// requests without the part.
+func (builder *BasicFormBindingGinHandlerBuilder) bindMultipartParts(ctx *gin.Context, val reflect.Value) error {
+	if ctx.ContentType() != binding.MIMEMultipartPOSTForm {
+		return nil
+	}
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		return &inputError{source: SourceBody, err: err}
+	}
+
+	ty := val.Type().Elem()
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		field := val.Elem().Field(i)
+
+		if name, ok := sf.Tag.Lookup("file"); ok {
+			field.SetZero()
+			files := form.File[name]
+			if len(files) == 0 {
+				continue
+			}
+			if sf.Type == fileHeaderPtrTy || sf.Type == uploadPtrTy {
+				files = files[:1]
+			}
+			for _, file := range files {
+				if builder.maxUploadSize > 0 && file.Size > builder.maxUploadSize {
+					return &fieldError{path: sf.Name, source: SourceBody, err: &uploadTooLargeError{name: file.Filename, limit: builder.maxUploadSize}}
+				}
+			}
+
+			switch sf.Type {
+			case fileHeaderPtrTy:
+				field.Set(reflect.ValueOf(files[0]))
+			case fileHeaderSliceTy:
+				field.Set(reflect.ValueOf(append([]*multipart.FileHeader(nil), files...)))
+			case uploadPtrTy:
+				field.Set(reflect.ValueOf(builder.newUpload(files[0])))
+			default:
+				uploads := make([]*Upload, len(files))
+				for i, file := range files {
+					uploads[i] = builder.newUpload(file)
+				}
+				field.Set(reflect.ValueOf(uploads))
+			}
+		}
+
+		if name, ok := sf.Tag.Lookup("part"); ok {
+			field.SetZero()
+			part, ok, err := multipartPart(form, name)
+			if err != nil {
+				return &fieldError{path: sf.Name, source: SourceBody, err: fmt.Errorf("failed to read part %q: %w", name, err)}
+			}
+			if !ok {
+				continue
+			}
+			if err := builder.decodePart(part, field.Addr().Interface()); err != nil {
+				return &fieldError{path: sf.Name, source: SourceBody, err: fmt.Errorf("failed to decode part %q: %w", name, err)}
+			}
+		}
+	}
+	return nil
+}
+
+// multipartPart returns the content of the part name of form, which is a
+// form value or a file depending on whether the client gave it a file name
+func multipartPart(form *multipart.Form, name string) (io.Reader, bool, error) {
+	if values := form.Value[name]; len(values) > 0 {
+		return strings.NewReader(values[0]), true, nil
+	}
+	if files := form.File[name]; len(files) > 0 {
+		f, err := files[0].Open()
+		if err != nil {
+			return nil, false, err
+		}
+		defer f.Close()
+		var b bytes.Buffer
+		if _, err := b.ReadFrom(f); err != nil {
+			return nil, false, err
+		}
+		return &b, true, nil
+	}
+	return nil, false, nil
+}
+
+// decodePart decodes the JSON document r into obj with the decoder settings of
+// JSON bodies
+func (builder *BasicFormBindingGinHandlerBuilder) decodePart(r io.Reader, obj any) error {
+	dec := json.NewDecoder(r)
+	if builder.useNumber || binding.EnableDecoderUseNumber {
+		dec.UseNumber()
+	}
+	if binding.EnableDecoderDisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(obj)
+}
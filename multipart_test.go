@@ -0,0 +1,128 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type documentMeta struct {
+	Title string   `json:"title" binding:"required"`
+	Tags  []string `json:"tags"`
+}
+
+type documentUploadRequest struct {
+	Meta        documentMeta            `part:"meta"`
+	File        *multipart.FileHeader   `file:"file"`
+	Attachments []*multipart.FileHeader `file:"attachments"`
+}
+
+// newDocumentUpload builds a multipart body with the JSON part meta, sent as a
+// file if asFile is set, and the given files
+func newDocumentUpload(t *testing.T, meta string, asFile bool, files map[string][]string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+
+	if meta != "" {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", "application/json")
+		if asFile {
+			header.Set("Content-Disposition", `form-data; name="meta"; filename="meta.json"`)
+		} else {
+			header.Set("Content-Disposition", `form-data; name="meta"`)
+		}
+		pw, err := mw.CreatePart(header)
+		assert.NoError(t, err)
+		_, _ = pw.Write([]byte(meta))
+	}
+
+	for field, names := range files {
+		for _, name := range names {
+			fw, err := mw.CreateFormFile(field, name)
+			assert.NoError(t, err)
+			_, _ = fw.Write([]byte("content of " + name))
+		}
+	}
+
+	assert.NoError(t, mw.Close())
+	return body, mw.FormDataContentType()
+}
+
+func TestMultipartParts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req documentUploadRequest) (interface{}, error) {
+		attachments := []string{}
+		for _, f := range req.Attachments {
+			attachments = append(attachments, f.Filename)
+		}
+		file := ""
+		if req.File != nil {
+			file = req.File.Filename
+		}
+		return gin.H{"meta": req.Meta, "file": file, "attachments": attachments}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.POST("/documents", builder.MustFormBindingGinHandlerFunc(handler))
+
+	send := func(body *bytes.Buffer, contentType string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/documents", body)
+		req.Header.Set("Content-Type", contentType)
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	for _, asFile := range []bool{false, true} {
+		body, contentType := newDocumentUpload(t, `{"title":"Q3 report","tags":["finance"]}`, asFile,
+			map[string][]string{"file": {"report.pdf"}, "attachments": {"a.csv", "b.csv"}})
+		w, response := send(body, contentType)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, map[string]interface{}{
+			"meta":        map[string]interface{}{"title": "Q3 report", "tags": []interface{}{"finance"}},
+			"file":        "report.pdf",
+			"attachments": []interface{}{"a.csv", "b.csv"},
+		}, response["data"])
+	}
+
+	t.Run("invalid JSON part", func(t *testing.T) {
+		body, contentType := newDocumentUpload(t, `{"title":`, false, nil)
+		w, response := send(body, contentType)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "body", response["source"])
+		assert.Contains(t, response["message"], `failed to decode part "meta"`)
+	})
+
+	t.Run("part validated", func(t *testing.T) {
+		body, contentType := newDocumentUpload(t, `{"tags":["finance"]}`, false, nil)
+		w, _ := send(body, contentType)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestFileTagValidation(t *testing.T) {
+	handler := func(c *gin.Context, req struct {
+		File string `file:"file"`
+	}) error {
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	_, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.EqualError(t, err, "field File: file tag requires a *multipart.FileHeader, []*multipart.FileHeader, *ginbinding.Upload or []*ginbinding.Upload field, got string")
+}
@@ -0,0 +1,26 @@
+package ginbinding
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MustFormBindingGinHandlerFunc is like FormBindingGinHandlerFunc but panics
+// if the handler function has an invalid signature. It is intended for route
+// registration at startup.
+func (builder *BasicFormBindingGinHandlerBuilder) MustFormBindingGinHandlerFunc(i any, opts ...Option) gin.HandlerFunc {
+	return Must(builder.FormBindingGinHandlerFunc(i, opts...))
+}
+
+// Must returns h, or panics if err is not nil. It wraps calls returning a
+// handler and an error, such as FormBindingGinHandlerFunc of any
+// FormBindingGinHandlerBuilder:
+//
+//	r.GET("/users/:id", ginbinding.Must(builder.FormBindingGinHandlerFunc(getUser)))
+func Must[H any](h H, err error) H {
+	if err != nil {
+		panic(fmt.Errorf("ginbinding: %w", err))
+	}
+	return h
+}
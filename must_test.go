@@ -0,0 +1,40 @@
+package ginbinding
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMustFormBindingGinHandlerFunc(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	router := gin.New()
+	router.GET("/ping", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (interface{}, error) {
+		return "pong", nil
+	}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/ping", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.PanicsWithError(t, "ginbinding: input must be a function", func() {
+		builder.MustFormBindingGinHandlerFunc("not a function")
+	})
+}
+
+func TestMust(t *testing.T) {
+	assert.Equal(t, 42, Must(42, nil))
+
+	assert.PanicsWithError(t, "ginbinding: boom", func() {
+		Must(0, errors.New("boom"))
+	})
+}
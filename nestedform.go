@@ -0,0 +1,248 @@
+package ginbinding
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	// maxNestedFormDepth bounds the number of segments of a nested form key
+	maxNestedFormDepth = 32
+	// maxNestedFormIndex bounds the slice indexes of nested form keys, so
+	// that a single key cannot allocate a huge slice
+	maxNestedFormIndex = 1000
+)
+
+var (
+	bindUnmarshalerTy = reflect.TypeOf((*binding.BindUnmarshaler)(nil)).Elem()
+	fileHeaderTy      = reflect.TypeOf(multipart.FileHeader{})
+)
+
+// isNestedFormType reports whether fields of type ty can be bound from nested
+// form keys: structs, maps, slices and arrays that do not parse themselves,
+// other than uploaded files
+func isNestedFormType(ty reflect.Type) bool {
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	if ty == timeTy || ty == fileHeaderTy || reflect.PointerTo(ty).Implements(bindUnmarshalerTy) {
+		return false
+	}
+	switch ty.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Array:
+		return true
+	case reflect.Slice:
+		return ty.Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
+
+// splitNestedFormKey splits a form key like a[b][c], list[0].name or ids[]
+// into its segments. It reports false for keys that are not nested or are
+// malformed, which are left to gin's flat binding.
+func splitNestedFormKey(key string) ([]string, bool) {
+	i := strings.IndexAny(key, "[.")
+	if i <= 0 {
+		return nil, false
+	}
+
+	segments := []string{key[:i]}
+	for rest := key[i:]; rest != ""; {
+		var segment string
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, false
+			}
+			segment, rest = rest[1:end], rest[end+1:]
+		case '.':
+			end := strings.IndexAny(rest[1:], "[.")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			segment, rest = rest[1:end+1], rest[end+1:]
+			if segment == "" {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+		segments = append(segments, segment)
+	}
+	return segments, len(segments) <= maxNestedFormDepth
+}
+
+// bindNestedForm binds the nested keys of form, such as a[b][c]=1 or
+// list[0].name=x, into the struct pointed to by val, following the
+// conventions of qs and Rack: brackets or dots select struct fields, map keys
+// and slice indexes, and a trailing [] collects every value of the key. Struct
+// fields are matched by their tag, or by name if they have none, like gin
+// matches flat keys. Keys that name a field as they are were bound by gin.
+// Keys sent in postForm are reported as body inputs, others as query inputs.
+func (builder *BasicFormBindingGinHandlerBuilder) bindNestedForm(form, postForm url.Values, val reflect.Value, tag string) error {
+	ty := val.Type().Elem()
+	for key, values := range form {
+		segments, ok := splitNestedFormKey(key)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if _, ok := formField(ty, key, tag); ok {
+			continue
+		}
+		sf, ok := formField(ty, segments[0], tag)
+		if !ok || !isNestedFormType(sf.Type) {
+			continue
+		}
+
+		if err := builder.setNestedForm(val.Elem().FieldByIndex(sf.Index), segments[1:], values, tag); err != nil {
+			source := SourceQuery
+			if _, ok := postForm[key]; ok {
+				source = SourceBody
+			}
+			return &fieldError{path: sf.Name, source: source, err: fmt.Errorf("failed to bind %q: %w", key, err), value: values[len(values)-1]}
+		}
+	}
+	return nil
+}
+
+// formField returns the exported field of the struct type ty, or of the
+// structs embedded in it, that the form key name selects. Its Index is
+// relative to ty.
+func formField(ty reflect.Type, name string, tag string) (reflect.StructField, bool) {
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+
+		if sf.Anonymous && key == "" && sf.Type.Kind() == reflect.Struct {
+			if embedded, ok := formField(sf.Type, name, tag); ok {
+				embedded.Index = append([]int{i}, embedded.Index...)
+				return embedded, true
+			}
+			continue
+		}
+
+		if key == "" {
+			key = sf.Name
+		}
+		if key == name {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// setNestedForm sets the part of the addressable v selected by segments to
+// values
+func (builder *BasicFormBindingGinHandlerBuilder) setNestedForm(v reflect.Value, segments []string, values []string, tag string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return builder.setNestedForm(v.Elem(), segments, values, tag)
+	}
+
+	if len(segments) == 0 {
+		return builder.setFormValues(v, values)
+	}
+	segment := segments[0]
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() != timeTy {
+			// Unknown fields are ignored, like unknown flat keys
+			sf, ok := formField(v.Type(), segment, tag)
+			if !ok {
+				return nil
+			}
+			return builder.setNestedForm(v.FieldByIndex(sf.Index), segments[1:], values, tag)
+		}
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key type %s", v.Type().Key())
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		key := reflect.ValueOf(segment).Convert(v.Type().Key())
+		// Map elements are not addressable, so a copy is updated
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := builder.setNestedForm(elem, segments[1:], values, tag); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		if segment == "" {
+			if len(segments) > 1 {
+				return fmt.Errorf("[] must end the key")
+			}
+			return builder.setFormValues(v, values)
+		}
+		i, err := strconv.Atoi(segment)
+		if err != nil || i < 0 || i >= maxNestedFormIndex {
+			return fmt.Errorf("invalid index %q", segment)
+		}
+		if v.Kind() == reflect.Array {
+			if i >= v.Len() {
+				return fmt.Errorf("index %d out of range", i)
+			}
+		} else if i >= v.Len() {
+			grown := reflect.MakeSlice(v.Type(), i+1, i+1)
+			reflect.Copy(grown, v)
+			v.Set(grown)
+		}
+		return builder.setNestedForm(v.Index(i), segments[1:], values, tag)
+	}
+
+	return fmt.Errorf("cannot select %q in %s", segment, v.Type())
+}
+
+// setFormValues sets v to values, converting every value for slices and
+// arrays and the last one for other types
+func (builder *BasicFormBindingGinHandlerBuilder) setFormValues(v reflect.Value, values []string) error {
+	switch {
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8:
+		s := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			ev, err := builder.stringToVal(value, v.Type().Elem(), "")
+			if err != nil {
+				return err
+			}
+			s.Index(i).Set(ev)
+		}
+		v.Set(s)
+	case v.Kind() == reflect.Array:
+		if len(values) > v.Len() {
+			return fmt.Errorf("%d values do not fit in %s", len(values), v.Type())
+		}
+		for i, value := range values {
+			ev, err := builder.stringToVal(value, v.Type().Elem(), "")
+			if err != nil {
+				return err
+			}
+			v.Index(i).Set(ev)
+		}
+	default:
+		ev, err := builder.stringToVal(values[len(values)-1], v.Type(), "")
+		if err != nil {
+			return err
+		}
+		v.Set(ev)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type nestedFormLine struct {
+	SKU      string `form:"sku" json:"sku"`
+	Quantity int    `form:"qty" json:"qty"`
+}
+
+type nestedFormRequest struct {
+	Customer struct {
+		Name    string `form:"name" json:"name"`
+		Address struct {
+			City string `form:"city" json:"city"`
+		} `form:"address" json:"address"`
+	} `form:"customer" json:"customer"`
+	Lines []nestedFormLine    `form:"lines" json:"lines"`
+	Tags  []string            `form:"tags" json:"tags"`
+	Attrs map[string]string   `form:"attrs" json:"attrs"`
+	Notes map[string][]string `form:"notes" json:"notes"`
+	Note  string              `form:"note" json:"note"`
+}
+
+func TestNestedFormKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req nestedFormRequest) (interface{}, error) {
+		return req, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.Any("/orders", builder.MustFormBindingGinHandlerFunc(handler))
+
+	form := "customer[name]=Ada&customer[address][city]=London" +
+		"&lines[0].sku=A1&lines[0].qty=2&lines[1][sku]=B2&lines[1][qty]=5" +
+		"&tags[]=new&tags[]=gift&attrs[color]=red&notes[gate][]=3&notes[gate][]=4&note=hello"
+	expected := map[string]interface{}{
+		"customer": map[string]interface{}{"name": "Ada", "address": map[string]interface{}{"city": "London"}},
+		"lines": []interface{}{
+			map[string]interface{}{"sku": "A1", "qty": float64(2)},
+			map[string]interface{}{"sku": "B2", "qty": float64(5)},
+		},
+		"tags":  []interface{}{"new", "gift"},
+		"attrs": map[string]interface{}{"color": "red"},
+		"notes": map[string]interface{}{"gate": []interface{}{"3", "4"}},
+		"note":  "hello",
+	}
+
+	t.Run("query", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/orders?"+form, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, expected, response["data"])
+	})
+
+	t.Run("urlencoded body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/orders", strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, expected, response["data"])
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/orders", strings.NewReader("lines[0][qty]=many"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "body", response["source"])
+		assert.Equal(t, "many", response["value"])
+		assert.Contains(t, response["message"], `failed to bind "lines[0][qty]"`)
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/orders?"+url.Values{"lines[100000][sku]": {"A1"}}.Encode(), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSplitNestedFormKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		segments []string
+		ok       bool
+	}{
+		{key: "a[b][c]", segments: []string{"a", "b", "c"}, ok: true},
+		{key: "list[0].name", segments: []string{"list", "0", "name"}, ok: true},
+		{key: "a.b.c", segments: []string{"a", "b", "c"}, ok: true},
+		{key: "ids[]", segments: []string{"ids", ""}, ok: true},
+		{key: "plain"},
+		{key: "a[b"},
+		{key: "a..b"},
+		{key: "[a]"},
+	}
+
+	for _, tt := range tests {
+		segments, ok := splitNestedFormKey(tt.key)
+		assert.Equal(t, tt.ok, ok, tt.key)
+		if tt.ok {
+			assert.Equal(t, tt.segments, segments, tt.key)
+		}
+	}
+}
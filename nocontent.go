@@ -0,0 +1,26 @@
+package ginbinding
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithNoContent answers successful requests without data, from handlers
+// returning only an error or nil data, with 204 No Content and an empty body
+// instead of the success response of the response handler, as is customary
+// for DELETE and fire-and-forget POST endpoints. Success hooks still run.
+//
+//	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil, ginbinding.WithNoContent())
+func WithNoContent() Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.noContent = true
+	}
+}
+
+// writeNoContent writes the 204 No Content response of a request without
+// data
+func writeNoContent(ctx *gin.Context) {
+	ctx.Status(http.StatusNoContent)
+	ctx.Writer.WriteHeaderNow()
+}
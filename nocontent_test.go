@@ -0,0 +1,73 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithNoContent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	hooks := 0
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithNoContent()).
+		OnSuccess(func(c *gin.Context, req any, resp any) {
+			hooks++
+		})
+	router := gin.New()
+	router.DELETE("/users/1", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) error {
+		return nil
+	}))
+	router.GET("/nil", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (*user, error) {
+		return nil, nil
+	}))
+	router.GET("/user", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (*user, error) {
+		return &user{ID: 1}, nil
+	}))
+	router.DELETE("/missing", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) error {
+		return ErrNotFound
+	}))
+	router.POST("/events", NewBasicFormBindingGinHandlerBuilder(nil, nil).MustFormBindingGinHandlerFunc(func(c *gin.Context) error {
+		return nil
+	}, WithNoContent()))
+	router.POST("/legacy", NewBasicFormBindingGinHandlerBuilder(nil, nil).MustFormBindingGinHandlerFunc(func(c *gin.Context) error {
+		return nil
+	}))
+
+	tests := []struct {
+		method, path string
+		status       int
+		body         string
+	}{
+		{"DELETE", "/users/1", http.StatusNoContent, ""},
+		{"GET", "/nil", http.StatusNoContent, ""},
+		{"GET", "/user", http.StatusOK, `{"status":"success","data":{"id":1}}`},
+		{"DELETE", "/missing", http.StatusNotFound, ""},
+		{"POST", "/events", http.StatusNoContent, ""},
+		{"POST", "/legacy", http.StatusOK, `{"status":"success"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			switch {
+			case tt.status == http.StatusNoContent:
+				assert.Empty(t, w.Body.String())
+				assert.Empty(t, w.Header().Get("Content-Type"))
+			case tt.body != "":
+				assert.JSONEq(t, tt.body, w.Body.String())
+			}
+		})
+	}
+	assert.Equal(t, 3, hooks)
+}
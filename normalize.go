@@ -0,0 +1,161 @@
+package ginbinding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// WithUnicodeNormalization normalizes every bound string to Unicode NFC before
+// the mod and sanitize tags apply, so that visually identical strings, e.g. é
+// as one code point or as e followed by a combining accent, compare and store
+// the same. Strings in maps and interface values are left as is.
+func WithUnicodeNormalization() Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.normalizeUnicode = true
+	}
+}
+
+// normalizeStrings normalizes the strings of the struct val to NFC if the
+// builder was created WithUnicodeNormalization
+func (builder *BasicFormBindingGinHandlerBuilder) normalizeStrings(val reflect.Value) error {
+	if !builder.normalizeUnicode {
+		return nil
+	}
+
+	return walkFields(val, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		if isModifiableType(sf.Type) {
+			modifyValue(fieldVal, []func(string) string{norm.NFC.String})
+		}
+		return nil
+	})
+}
+
+// idSafeScripts are the combinations of scripts identifiers may mix, those of
+// the Japanese, Chinese and Korean writing systems along with Latin
+var idSafeScripts = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+// checkIDSafeTags verifies the "idsafe" tags of ty at handler build time
+func checkIDSafeTags(ty reflect.Type) error {
+	return walkTypeFields(ty, func(sf reflect.StructField) error {
+		tag, ok := sf.Tag.Lookup("idsafe")
+		if !ok {
+			return nil
+		}
+		if _, err := strconv.ParseBool(tag); err != nil {
+			return fmt.Errorf("field %s: invalid idsafe tag %q", sf.Name, tag)
+		}
+		if !isModifiableType(sf.Type) {
+			return fmt.Errorf("field %s: idsafe tag requires a string, *string or []string field, got %s", sf.Name, sf.Type)
+		}
+		return nil
+	})
+}
+
+// applyIDSafe rejects the values of fields tagged idsafe:"true" containing
+// invisible characters or mixing scripts, which let different identifiers
+// such as user names look the same
+func applyIDSafe(val reflect.Value) error {
+	if !typeInfoOf(val.Type()).idsafe {
+		return nil
+	}
+
+	return walkFields(val, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		if safe, _ := strconv.ParseBool(sf.Tag.Get("idsafe")); !safe {
+			return nil
+		}
+		return checkIDSafeValue(fieldVal, path)
+	})
+}
+
+func checkIDSafeValue(val reflect.Value, path string) error {
+	switch val.Kind() {
+	case reflect.Pointer:
+		if val.IsNil() {
+			return nil
+		}
+		return checkIDSafeValue(val.Elem(), path)
+	case reflect.Slice, reflect.Array:
+		for j := 0; j < val.Len(); j++ {
+			if err := checkIDSafeValue(val.Index(j), path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	s := val.String()
+	if err := idSafe(s); err != nil {
+		return &fieldError{path: path, err: fmt.Errorf("field %s: value %q %w", path, s, err), value: s}
+	}
+	return nil
+}
+
+// idSafe reports why the identifier s is unsafe, or nil if it is safe
+func idSafe(s string) error {
+	var scripts []string
+	for i, r := range s {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(s[i:]); size == 1 {
+				return errors.New("is not valid UTF-8")
+			}
+		}
+		if isInvisible(r) {
+			return fmt.Errorf("contains invisible character %U", r)
+		}
+		if script := runeScript(r); script != "" && !slices.Contains(scripts, script) {
+			scripts = append(scripts, script)
+		}
+	}
+	if len(scripts) < 2 {
+		return nil
+	}
+
+	for _, allowed := range idSafeScripts {
+		if !slices.ContainsFunc(scripts, func(script string) bool { return !slices.Contains(allowed, script) }) {
+			return nil
+		}
+	}
+	return fmt.Errorf("mixes %s and %s scripts", scripts[0], scripts[1])
+}
+
+// isInvisible reports whether r is a control, format or private use character,
+// a space other than U+0020, or a character rendered as nothing such as a
+// variation selector or the Hangul filler
+func isInvisible(r rune) bool {
+	if r == ' ' {
+		return false
+	}
+	return unicode.In(r, unicode.Cc, unicode.Cf, unicode.Co, unicode.Cs, unicode.Zs, unicode.Zl, unicode.Zp,
+		unicode.Other_Default_Ignorable_Code_Point, unicode.Variation_Selector)
+}
+
+// runeScript returns the name of the Unicode script of r, or "" for the
+// characters shared by scripts such as digits, punctuation and combining marks
+func runeScript(r rune) string {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' {
+			return "Latin"
+		}
+		return ""
+	}
+	if unicode.In(r, unicode.Common, unicode.Inherited) {
+		return ""
+	}
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
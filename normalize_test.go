@@ -0,0 +1,176 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithUnicodeNormalization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		Name  string   `form:"name"`
+		Slug  *string  `json:"slug" mod:"lower"`
+		Tags  []string `json:"tags"`
+		Inner struct {
+			City string `json:"city"`
+		} `json:"inner"`
+	}
+
+	serve := func(opts ...Option) request {
+		var bound request
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, opts...)
+		router := gin.New()
+		router.POST("/users", builder.MustFormBindingGinHandlerFunc(func(req request) error {
+			bound = req
+			return nil
+		}))
+
+		// Jose%CC%81 and the body hold decomposed characters, e.g. e followed by
+		// a combining acute accent
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/users?name=Jose%CC%81", strings.NewReader(
+			"{\"slug\":\"CAFE\u0301\",\"tags\":[\"e\u0301\"],\"inner\":{\"city\":\"Mu\u0308nchen\"}}"))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		return bound
+	}
+
+	bound := serve(WithUnicodeNormalization())
+	assert.Equal(t, "Jos\u00e9", bound.Name)
+	assert.Equal(t, "caf\u00e9", *bound.Slug)
+	assert.Equal(t, []string{"\u00e9"}, bound.Tags)
+	assert.Equal(t, "M\u00fcnchen", bound.Inner.City)
+
+	bound = serve()
+	assert.Equal(t, "Jose\u0301", bound.Name)
+}
+
+func TestIDSafeTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Username string   `json:"username" idsafe:"true"`
+		Aliases  []string `json:"aliases" idsafe:"true"`
+		Bio      string   `json:"bio" idsafe:"false"`
+	}) (interface{}, error) {
+		return gin.H{"username": req.Username}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/users", ginHandler)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:           "single script",
+			body:           `{"username":"john_doe-42","aliases":["иван","δημήτρης"]}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "allowed script combinations",
+			body:           `{"username":"tanaka_田中たなかタナカ","aliases":["kim김철수"]}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "untagged fields are not checked",
+			body:           "{\"bio\":\"hi\u200bthere\"}",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "zero width space",
+			body:           "{\"username\":\"ad\u200bmin\"}",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Username: value "ad\u200bmin" contains invisible character U+200B`,
+		},
+		{
+			name:           "bidi override",
+			body:           "{\"username\":\"user\u202egnp\"}",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Username: value "user\u202egnp" contains invisible character U+202E`,
+		},
+		{
+			name:           "hangul filler",
+			body:           "{\"username\":\"\u3164\"}",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "field Username: value \"\u3164\" contains invisible character U+3164",
+		},
+		{
+			name:           "mixed scripts",
+			body:           "{\"username\":\"p\u0430ypal\"}",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "field Username: value \"p\u0430ypal\" mixes Latin and Cyrillic scripts",
+		},
+		{
+			name:           "invalid slice element",
+			body:           "{\"aliases\":[\"ok\",\"nbsp\u00a0name\"]}",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Aliases: value "nbsp\u00a0name" contains invisible character U+00A0`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, response["message"])
+			}
+		})
+	}
+}
+
+func TestIDSafe(t *testing.T) {
+	assert.NoError(t, idSafe(""))
+	assert.NoError(t, idSafe("Jos\u00e9 Mar\u00eda"))
+	assert.NoError(t, idSafe("Jose\u0301"))
+	assert.EqualError(t, idSafe("tab\tname"), "contains invisible character U+0009")
+	assert.EqualError(t, idSafe("bad\xffutf8"), "is not valid UTF-8")
+	assert.EqualError(t, idSafe("a\ufe0f"), "contains invisible character U+FE0F")
+	assert.EqualError(t, idSafe("ωmega"), "mixes Greek and Latin scripts")
+}
+
+func TestIDSafeTagInvalidAtBuildTime(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Username string `json:"username" idsafe:"yes"`
+	}) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `field Username: invalid idsafe tag "yes"`)
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		ID int `json:"id" idsafe:"true"`
+	}) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "idsafe tag requires a string, *string or []string field")
+}
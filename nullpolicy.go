@@ -0,0 +1,163 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+)
+
+// NullPolicy selects how nil pointers, slices and maps of success data are
+// serialized
+type NullPolicy int
+
+const (
+	// NullAsNull serializes them as null, like encoding/json
+	NullAsNull NullPolicy = iota
+	// NullOmit omits the keys of object fields that would be null
+	NullOmit
+	// NullAsEmpty serializes nil slices as [] and nil maps as {}. Nil
+	// pointers are still serialized as null.
+	NullAsEmpty
+)
+
+// maxNullPolicyDepth bounds the recursion of NullAsEmpty into cyclic data,
+// which fails to serialize anyway
+const maxNullPolicyDepth = 1000
+
+var (
+	jsonMarshalerTy = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerTy = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// hasCustomEncoding reports whether values of ty, or pointers to them, are
+// serialized by their own MarshalJSON or MarshalText method
+func hasCustomEncoding(ty reflect.Type) bool {
+	ptr := reflect.PointerTo(ty)
+	return ty.Implements(jsonMarshalerTy) || ptr.Implements(jsonMarshalerTy) ||
+		ty.Implements(textMarshalerTy) || ptr.Implements(textMarshalerTy)
+}
+
+// WithNullPolicy sets how nil pointers, slices and maps of success data are
+// serialized, instead of as null. The data returned by handlers is never
+// modified.
+func WithNullPolicy(policy NullPolicy) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.nullPolicy = policy
+	}
+}
+
+// applyNullPolicy returns data prepared for serialization with the null
+// policy of the builder. Streamed responses are returned unchanged.
+func (builder *BasicFormBindingGinHandlerBuilder) applyNullPolicy(data any) (any, error) {
+	if builder.nullPolicy == NullAsNull || data == nil {
+		return data, nil
+	}
+	if _, ok := responseStream(data); ok {
+		return data, nil
+	}
+
+	switch builder.nullPolicy {
+	case NullOmit:
+		b, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		return omitNulls(v), nil
+	case NullAsEmpty:
+		return emptyCollections(reflect.ValueOf(data), 0).Interface(), nil
+	}
+	return data, nil
+}
+
+// omitNulls drops the null values of the objects of the decoded JSON v
+func omitNulls(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if field == nil {
+				delete(v, key)
+			} else {
+				v[key] = omitNulls(field)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = omitNulls(v[i])
+		}
+	}
+	return v
+}
+
+// emptyCollections returns a copy of v in which nil slices and maps are
+// replaced by empty ones. Values with a custom encoding are kept as they are.
+func emptyCollections(v reflect.Value, depth int) reflect.Value {
+	ty := v.Type()
+	if depth > maxNullPolicyDepth || hasCustomEncoding(ty) {
+		return v
+	}
+
+	switch ty.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(ty).Elem()
+		c.Set(emptyCollections(v.Elem(), depth+1))
+		return c
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(ty.Elem())
+		c.Elem().Set(emptyCollections(v.Elem(), depth+1))
+		return c
+
+	case reflect.Struct:
+		c := reflect.New(ty).Elem()
+		c.Set(v)
+		for i := 0; i < ty.NumField(); i++ {
+			if ty.Field(i).IsExported() {
+				c.Field(i).Set(emptyCollections(v.Field(i), depth+1))
+			}
+		}
+		return c
+
+	case reflect.Slice:
+		// Byte slices are serialized as base64 strings
+		if ty.Elem().Kind() == reflect.Uint8 {
+			if v.IsNil() {
+				return reflect.MakeSlice(ty, 0, 0)
+			}
+			return v
+		}
+		c := reflect.MakeSlice(ty, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(emptyCollections(v.Index(i), depth+1))
+		}
+		return c
+
+	case reflect.Array:
+		c := reflect.New(ty).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(emptyCollections(v.Index(i), depth+1))
+		}
+		return c
+
+	case reflect.Map:
+		c := reflect.MakeMapWithSize(ty, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), emptyCollections(iter.Value(), depth+1))
+		}
+		return c
+	}
+	return v
+}
@@ -0,0 +1,59 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithNullPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type profile struct {
+		Bio string `json:"bio"`
+	}
+	type user struct {
+		Name      string            `json:"name"`
+		Tags      []string          `json:"tags"`
+		Labels    map[string]string `json:"labels"`
+		Profile   *profile          `json:"profile"`
+		Friends   []profile         `json:"friends"`
+		CreatedAt time.Time         `json:"created_at"`
+	}
+	u := user{Name: "ann", Friends: []profile{{Bio: "hi"}}, CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name         string
+		policy       NullPolicy
+		expectedBody string
+	}{
+		{name: "null", policy: NullAsNull, expectedBody: `{"status":"success","data":{"name":"ann","tags":null,"labels":null,"profile":null,"friends":[{"bio":"hi"}],"created_at":"2024-01-02T00:00:00Z"}}`},
+		{name: "omit", policy: NullOmit, expectedBody: `{"status":"success","data":{"name":"ann","friends":[{"bio":"hi"}],"created_at":"2024-01-02T00:00:00Z"}}`},
+		{name: "empty", policy: NullAsEmpty, expectedBody: `{"status":"success","data":{"name":"ann","tags":[],"labels":{},"profile":null,"friends":[{"bio":"hi"}],"created_at":"2024-01-02T00:00:00Z"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithNullPolicy(tt.policy))
+
+			router := gin.New()
+			router.GET("/user", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (*user, error) {
+				return &u, nil
+			}))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user", nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+
+	// The returned data is left untouched
+	assert.Nil(t, u.Tags)
+	assert.Nil(t, u.Labels)
+}
@@ -0,0 +1,200 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+var (
+	bigIntTy   = reflect.TypeOf(big.Int{})
+	bigFloatTy = reflect.TypeOf(big.Float{})
+)
+
+// WithUseNumber decodes numbers in JSON bodies into json.Number instead of
+// float64 wherever the target is an interface, such as any fields and map
+// requests, so that 64-bit IDs and monetary values keep their precision
+func WithUseNumber() Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.useNumber = true
+	}
+}
+
+// numberJSONBinding is gin's JSON binding with json.Number decoding
+type numberJSONBinding struct{}
+
+func (numberJSONBinding) Name() string {
+	return "json"
+}
+
+func (numberJSONBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return fmt.Errorf("invalid request")
+	}
+	decoder := json.NewDecoder(req.Body)
+	decoder.UseNumber()
+	if binding.EnableDecoderDisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(obj); err != nil {
+		return err
+	}
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// shouldBind binds the request body into obj like ctx.ShouldBind, decoding
+// JSON numbers as json.Number when WithUseNumber is set
+func (builder *BasicFormBindingGinHandlerBuilder) shouldBind(ctx *gin.Context, obj any) error {
+	if builder.useNumber && ctx.ContentType() == binding.MIMEJSON {
+		return ctx.ShouldBindWith(obj, numberJSONBinding{})
+	}
+	return ctx.ShouldBind(obj)
+}
+
+// isBigNumber reports whether ty is big.Int or big.Float, or a pointer to one
+func isBigNumber(ty reflect.Type) bool {
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	return ty == bigIntTy || ty == bigFloatTy
+}
+
+// parseBigNumber parses s into a big.Int or big.Float value of type ty
+func parseBigNumber(s string, ty reflect.Type) (reflect.Value, error) {
+	switch ty {
+	case bigIntTy:
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("invalid integer %q", s)
+		}
+		return reflect.ValueOf(n).Elem(), nil
+	case bigFloatTy:
+		f, _, err := big.ParseFloat(s, 10, 0, big.ToNearestEven)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid number %q: %w", s, err)
+		}
+		return reflect.ValueOf(f).Elem(), nil
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported type conversion from %q to %s", s, ty)
+}
+
+// bigNumberFields returns the JSON keys of the top-level fields of the
+// struct type ty that hold a big number
+func bigNumberFields(ty reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if !sf.IsExported() || !isBigNumber(sf.Type) {
+			continue
+		}
+		key := jsonFieldName(sf)
+		if key == "-" {
+			continue
+		}
+		fieldTy := sf.Type
+		if fieldTy.Kind() == reflect.Pointer {
+			fieldTy = fieldTy.Elem()
+		}
+		fields[key] = fieldTy
+	}
+	return fields
+}
+
+// rewriteBigNumberJSON rewrites top-level body fields bound into big numbers,
+// so that both JSON numbers and numeric strings are accepted for big.Int and
+// big.Float alike
+func rewriteBigNumberJSON(ctx *gin.Context, ty reflect.Type) error {
+	if ctx.Request.Body == nil || ctx.ContentType() != binding.MIMEJSON {
+		return nil
+	}
+
+	fields := bigNumberFields(ty)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return err
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(body, &obj); err != nil {
+		// Leave malformed bodies to the regular JSON binding to report
+		return nil
+	}
+
+	rewritten := false
+	for key, raw := range obj {
+		fieldTy, ok := lookupBigNumberField(fields, key)
+		if !ok || string(raw) == "null" {
+			continue
+		}
+
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			var num json.Number
+			if err := json.Unmarshal(raw, &num); err != nil {
+				// Neither a number nor a string, left to the JSON binding
+				continue
+			}
+			s = num.String()
+		}
+
+		if obj[key], err = bigNumberJSON(s, fieldTy); err != nil {
+			return fmt.Errorf("failed to parse body field %q: %w", key, err)
+		}
+		rewritten = true
+	}
+
+	if !rewritten {
+		return nil
+	}
+
+	body, err = json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	ctx.Request.ContentLength = int64(len(body))
+
+	return nil
+}
+
+// bigNumberJSON validates the number s and encodes it as the JSON the
+// encoding/json support of the big type ty accepts
+func bigNumberJSON(s string, ty reflect.Type) (json.RawMessage, error) {
+	if _, err := parseBigNumber(s, ty); err != nil {
+		return nil, err
+	}
+	if ty == bigFloatTy {
+		return json.Marshal(s)
+	}
+	return json.RawMessage(s), nil
+}
+
+// lookupBigNumberField matches a JSON key to a field the way encoding/json
+// does: exact match first, then case-insensitive
+func lookupBigNumberField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if ty, ok := fields[key]; ok {
+		return ty, true
+	}
+	for k, ty := range fields {
+		if strings.EqualFold(k, key) {
+			return ty, true
+		}
+	}
+	return nil, false
+}
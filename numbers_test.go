@@ -0,0 +1,130 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithUseNumber(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var got interface{}
+	handler := func(c *gin.Context, req struct {
+		ID any `json:"id"`
+	}) error {
+		got = req.ID
+		return nil
+	}
+
+	router := gin.New()
+	for path, opts := range map[string][]Option{"/default": nil, "/number": {WithUseNumber()}} {
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, opts...)
+		ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+		assert.NoError(t, err)
+		router.POST(path, ginHandler)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/number", strings.NewReader(`{"id":9007199254740993}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, json.Number("9007199254740993"), got)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/default", strings.NewReader(`{"id":9007199254740993}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.IsType(t, float64(0), got)
+}
+
+func TestWithUseNumber_MapRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var got map[string]any
+	handler := func(c *gin.Context, req map[string]any) error {
+		got = req
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithUseNumber())
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/proxy", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/proxy", strings.NewReader(`{"id":9007199254740993}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, json.Number("9007199254740993"), got["id"])
+}
+
+func TestBigNumberBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		Account *big.Int   `path:"account"`
+		ID      *big.Int   `json:"id"`
+		Amount  *big.Float `json:"amount"`
+		Fee     big.Float  `json:"fee" default:"0.25"`
+	}
+
+	var got request
+	handler := func(c *gin.Context, req request) error {
+		got = req
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/accounts/:account", ginHandler)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "numbers", body: `{"id":123456789012345678901234567890,"amount":10.125}`, expectedStatus: http.StatusOK},
+		{name: "strings", body: `{"id":"123456789012345678901234567890","amount":"10.125"}`, expectedStatus: http.StatusOK},
+		{name: "invalid integer", body: `{"id":"12.5","amount":1}`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid number", body: `{"id":1,"amount":"ten"}`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = request{}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/accounts/98765432109876543210", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, "98765432109876543210", got.Account.String())
+			assert.Equal(t, "123456789012345678901234567890", got.ID.String())
+			assert.Equal(t, "10.125", got.Amount.Text('f', -1))
+			assert.Equal(t, "0.25", got.Fee.Text('f', -1))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// numberFormat holds the separators of localized numbers
+type numberFormat struct {
+	decimal rune
+	// groups holds the characters accepted as thousands separators
+	groups string
+}
+
+var (
+	commaDecimal = numberFormat{decimal: ',', groups: "."}
+	spaceGroups  = numberFormat{decimal: ',', groups: " \u00a0\u202f"}
+)
+
+// numberFormats maps the locales supported by the numfmt tag to their
+// separators. Locales with a region fall back to their base language.
+var numberFormats = map[string]numberFormat{
+	"en":    {decimal: '.', groups: ","},
+	"de":    commaDecimal,
+	"de-ch": {decimal: '.', groups: "'’"},
+	"es":    commaDecimal,
+	"it":    commaDecimal,
+	"nl":    commaDecimal,
+	"pt":    commaDecimal,
+	"da":    commaDecimal,
+	"id":    commaDecimal,
+	"tr":    commaDecimal,
+	"fr":    spaceGroups,
+	"sv":    spaceGroups,
+	"fi":    spaceGroups,
+	"nb":    spaceGroups,
+	"pl":    spaceGroups,
+	"cs":    spaceGroups,
+	"ru":    spaceGroups,
+	"uk":    spaceGroups,
+}
+
+// lookupNumberFormat returns the separators of locale, such as "de" or "fr-CA"
+func lookupNumberFormat(locale string) (numberFormat, bool) {
+	if format, ok := numberFormats[strings.ToLower(locale)]; ok {
+		return format, true
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return numberFormat{}, false
+	}
+	base, _ := tag.Base()
+	format, ok := numberFormats[base.String()]
+	return format, ok
+}
+
+// checkNumberFormatTags verifies at handler build time that numfmt tags name a
+// supported locale and are placed on numeric fields
+func checkNumberFormatTags(ty reflect.Type) error {
+	return walkTypeFields(ty, func(sf reflect.StructField) error {
+		locale, ok := sf.Tag.Lookup("numfmt")
+		if !ok {
+			return nil
+		}
+
+		fieldTy := sf.Type
+		if fieldTy.Kind() == reflect.Pointer {
+			fieldTy = fieldTy.Elem()
+		}
+		switch fieldTy.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			return fmt.Errorf("field %s: numfmt tag requires a numeric type, got %s", sf.Name, sf.Type)
+		}
+
+		if _, ok := lookupNumberFormat(locale); !ok {
+			return fmt.Errorf("field %s: unsupported number locale %q", sf.Name, locale)
+		}
+		return nil
+	})
+}
+
+// parseLocaleNumber converts the number s written in the format of locale,
+// such as "1.234,5" for "de", into the plain form strconv parses
+func parseLocaleNumber(s string, locale string) (string, error) {
+	format, ok := lookupNumberFormat(locale)
+	if !ok {
+		return "", fmt.Errorf("unsupported number locale %q", locale)
+	}
+
+	var b strings.Builder
+	decimals := 0
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case r == format.decimal:
+			decimals++
+			b.WriteByte('.')
+		case strings.ContainsRune(format.groups, r):
+			// Thousands separators carry no value
+		case r == '.' || r == ',':
+			return "", fmt.Errorf("invalid %s number %q", locale, s)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if decimals > 1 {
+		return "", fmt.Errorf("invalid %s number %q", locale, s)
+	}
+	return b.String(), nil
+}
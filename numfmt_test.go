@@ -0,0 +1,103 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberFormatTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		Price    float64  `path:"price" numfmt:"de"`
+		Quantity int      `form:"quantity" numfmt:"de-AT"`
+		Weight   *float64 `form:"weight" numfmt:"fr"`
+		Discount float64  `form:"discount" numfmt:"de" default:"0,5"`
+	}
+
+	var got request
+	handler := func(c *gin.Context, req request) error {
+		got = req
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/quote/:price", ginHandler)
+
+	weight := 1234.5
+	tests := []struct {
+		name           string
+		price          string
+		query          url.Values
+		expectedStatus int
+		expected       request
+	}{
+		{
+			name:           "localized",
+			price:          "1.234,56",
+			query:          url.Values{"quantity": {"1.000"}, "weight": {"1 234,5"}},
+			expectedStatus: http.StatusOK,
+			expected:       request{Price: 1234.56, Quantity: 1000, Weight: &weight, Discount: 0.5},
+		},
+		{
+			name:           "plain",
+			price:          "12",
+			query:          url.Values{"quantity": {"3"}},
+			expectedStatus: http.StatusOK,
+			expected:       request{Price: 12, Quantity: 3, Discount: 0.5},
+		},
+		{
+			name:           "two decimal separators",
+			price:          "1,2,3",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "foreign decimal separator",
+			price:          "1",
+			query:          url.Values{"weight": {"1.5"}},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = request{}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/quote/"+url.PathEscape(tt.price)+"?"+tt.query.Encode(), nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNumberFormatTag_BuildErrors(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Price string `form:"price" numfmt:"de"`
+	}) error {
+		return nil
+	})
+	assert.EqualError(t, err, "field Price: numfmt tag requires a numeric type, got string")
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Price float64 `form:"price" numfmt:"xx-invalid-locale"`
+	}) error {
+		return nil
+	})
+	assert.EqualError(t, err, `field Price: unsupported number locale "xx-invalid-locale"`)
+}
@@ -0,0 +1,87 @@
+package ginbinding
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// Option configures optional behaviour of a BasicFormBindingGinHandlerBuilder.
+// Options can be passed to NewBasicFormBindingGinHandlerBuilder to apply to
+// every handler, or to FormBindingGinHandlerFunc to apply to a single handler.
+type Option func(*BasicFormBindingGinHandlerBuilder)
+
+// WithTimeLocation sets the location used to interpret time strings without
+// zone information, such as "2006-01-02 15:04:05", bound from path parameters
+// and default values. The default is time.UTC.
+func WithTimeLocation(loc *time.Location) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		if loc == nil {
+			loc = time.UTC
+		}
+		builder.timeLocation = loc
+	}
+}
+
+// WithDefaultTag renames the struct tag holding default values, for code bases
+// that already use "default" for other tooling. An empty name restores the
+// "default" tag.
+func WithDefaultTag(name string) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		if name == "" {
+			name = "default"
+		}
+		builder.defaultTag = name
+	}
+}
+
+// WithValidator replaces the validator passed to
+// NewBasicFormBindingGinHandlerBuilder, typically in Clone to derive a
+// builder validating with other rules. A nil validator disables validation
+// by the builder.
+func WithValidator(validator binding.StructValidator) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.validator = validator
+	}
+}
+
+// WithResponseHandler replaces the response handler passed to
+// NewBasicFormBindingGinHandlerBuilder, typically in Clone to derive a
+// builder writing another response envelope. A nil handler restores the
+// DefaultResponseHandler.
+func WithResponseHandler(handler ResponseHandler) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		if handler == nil {
+			handler = NewDefaultResponseHandler()
+		}
+		builder.responseHandler = handler
+	}
+}
+
+// WithGinDefaultValidator uses gin's binding.Validator as the validator of the
+// builder when none is passed to NewBasicFormBindingGinHandlerBuilder. A
+// validator passed to the builder is kept.
+//
+// binding tags are enforced by gin's validator with or without the option,
+// which changes two things: path, query and header inputs tagged
+// binding:"required" are no longer checked for presence before binding, so
+// that missing ones fail validation like other fields rather than as missing
+// inputs, and the validator runs for request structs without binding tags
+// too, applying the struct-level validations registered on it.
+func WithGinDefaultValidator() Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		if builder.validator == nil {
+			builder.validator = binding.Validator
+		}
+	}
+}
+
+// WithValidationStatus sets the HTTP status code of the error response for
+// requests rejected by gin's validation of binding tags or by the builder's
+// validator, typically http.StatusUnprocessableEntity. The default is
+// http.StatusBadRequest.
+func WithValidationStatus(status int) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.validationStatus = status
+	}
+}
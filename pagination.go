@@ -0,0 +1,216 @@
+package ginbinding
+
+import (
+	"maps"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPageSize is the page size used when a request does not specify one
+	DefaultPageSize = 20
+	// DefaultMaxPageSize is the largest page size accepted unless configured otherwise
+	DefaultMaxPageSize = 100
+)
+
+// Pagination is an embeddable set of paging parameters. Clients may send
+// either page/page_size or offset/limit; after binding, all four fields are
+// normalized to consistent values using the builder's default page size and
+// maximum page size (see WithPagination).
+type Pagination struct {
+	Page     int `form:"page" json:"page"`
+	PageSize int `form:"page_size" json:"page_size"`
+	Offset   int `form:"offset" json:"offset"`
+	Limit    int `form:"limit" json:"limit"`
+}
+
+var paginationTy = reflect.TypeOf(Pagination{})
+
+// normalize fills in missing values and applies the page size bounds. A page
+// takes precedence over an offset; an offset sent alone is kept as is, even
+// when it is not a multiple of the page size.
+func (p *Pagination) normalize(defaultPageSize, maxPageSize int) {
+	if p.PageSize <= 0 {
+		p.PageSize = p.Limit
+	}
+	if p.PageSize <= 0 {
+		p.PageSize = defaultPageSize
+	}
+	if p.PageSize <= 0 {
+		p.PageSize = DefaultPageSize
+	}
+	if maxPageSize > 0 && p.PageSize > maxPageSize {
+		p.PageSize = maxPageSize
+	}
+
+	// Pages are capped so that their offset cannot overflow
+	maxPage := math.MaxInt / p.PageSize
+	switch {
+	case p.Page > 0:
+		p.Page = min(p.Page, maxPage)
+		p.Offset = (p.Page - 1) * p.PageSize
+	case p.Offset > 0:
+		// An offset sent without a page is kept, and the page is the one
+		// it falls on
+		p.Page = min(p.Offset/p.PageSize, maxPage-1) + 1
+	default:
+		p.Page, p.Offset = 1, 0
+	}
+	p.Limit = p.PageSize
+}
+
+// PageResponse is a list envelope carrying one page of items and the total count
+type PageResponse[T any] struct {
+	Items      []T   `json:"items"`
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalPages int   `json:"total_pages"`
+	// Truncated is set when WithResponseBudget dropped items of the page
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// NewPageResponse builds a PageResponse for items of the page described by p
+func NewPageResponse[T any](items []T, total int64, p Pagination) PageResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	totalPages := 0
+	if p.PageSize > 0 {
+		totalPages = int((total + int64(p.PageSize) - 1) / int64(p.PageSize))
+	}
+
+	return PageResponse[T]{
+		Items:      items,
+		Total:      total,
+		Page:       p.Page,
+		PageSize:   p.PageSize,
+		TotalPages: totalPages,
+	}
+}
+
+// PageInfo implements PagedResult
+func (r PageResponse[T]) PageInfo() (page, pageSize int, total int64) {
+	return r.Page, r.PageSize, r.Total
+}
+
+// PageItems implements PagedResult
+func (r PageResponse[T]) PageItems() any {
+	return r.Items
+}
+
+// PagedResult is a page of a list returned by a handler, such as a
+// PageResponse, whose pagination metadata WithPaginationMode can serve as
+// headers
+type PagedResult interface {
+	// PageInfo returns the 1-based page number, the page size and the total
+	// number of items of the list
+	PageInfo() (page, pageSize int, total int64)
+	// PageItems returns the items of the page, which make up the response
+	// body in PaginationHeaders mode
+	PageItems() any
+}
+
+// PaginationMode selects how the pagination metadata of the PagedResult
+// returned by handlers is served
+type PaginationMode int
+
+const (
+	// PaginationEnvelope serves the PagedResult as is, e.g. the fields of a
+	// PageResponse in the body
+	PaginationEnvelope PaginationMode = iota
+	// PaginationHeaders serves the items of the page as the body and the
+	// metadata as X-Total-Count and Link headers
+	PaginationHeaders
+	// PaginationEnvelopeAndHeaders serves the PagedResult as is along with
+	// X-Total-Count and Link headers
+	PaginationEnvelopeAndHeaders
+)
+
+// WithPaginationMode selects how handlers returning a PagedResult, such as a
+// PageResponse, serve its pagination metadata. The headers modes set
+// X-Total-Count to the total number of items, and Link (RFC 8288) to the first,
+// prev, next and last pages of the list, which are the request URL with the
+// page and page_size query parameters replaced:
+//
+//	Link: </users?page=1&page_size=20>; rel="first", </users?page=3&page_size=20>; rel="next", ...
+func WithPaginationMode(mode PaginationMode) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.paginationMode = mode
+	}
+}
+
+// paginationHeaders adds the pagination headers of the PagedResult data to
+// headers, and returns the data to serve and the headers to set
+func (builder *BasicFormBindingGinHandlerBuilder) paginationHeaders(ctx *gin.Context, data any, headers map[string]string) (any, map[string]string) {
+	result, ok := data.(PagedResult)
+	if !ok {
+		return data, headers
+	}
+
+	page, pageSize, total := result.PageInfo()
+	pageHeaders := map[string]string{"X-Total-Count": strconv.FormatInt(total, 10)}
+	if pageSize > 0 {
+		lastPage := max(int((total+int64(pageSize)-1)/int64(pageSize)), 1)
+
+		var links []string
+		link := func(page int, rel string) {
+			query := ctx.Request.URL.Query()
+			query.Del("offset")
+			query.Del("limit")
+			query.Set("page", strconv.Itoa(page))
+			query.Set("page_size", strconv.Itoa(pageSize))
+			u := url.URL{Path: ctx.Request.URL.Path, RawQuery: query.Encode()}
+			links = append(links, "<"+u.String()+`>; rel="`+rel+`"`)
+		}
+		link(1, "first")
+		if page > 1 {
+			link(min(page-1, lastPage), "prev")
+		}
+		if page < lastPage {
+			link(page+1, "next")
+		}
+		link(lastPage, "last")
+		pageHeaders["Link"] = strings.Join(links, ", ")
+	}
+
+	// Headers returned WithHeaders take precedence
+	maps.Copy(pageHeaders, headers)
+
+	if builder.paginationMode == PaginationHeaders {
+		data = result.PageItems()
+	}
+	return data, pageHeaders
+}
+
+// WithPagination configures the page size used when a request omits it and
+// the maximum page size a request can ask for. A maxPageSize of 0 disables the
+// cap, and a defaultPageSize of 0 or less falls back to DefaultPageSize.
+func WithPagination(defaultPageSize, maxPageSize int) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.defaultPageSize = defaultPageSize
+		builder.maxPageSize = maxPageSize
+	}
+}
+
+// normalizePagination normalizes every Pagination value in the request struct
+func (builder *BasicFormBindingGinHandlerBuilder) normalizePagination(val reflect.Value) error {
+	if !typeInfoOf(val.Type()).pagination {
+		return nil
+	}
+
+	return walkFields(val, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		if sf.Type == paginationTy {
+			fieldVal.Addr().Interface().(*Pagination).normalize(builder.defaultPageSize, builder.maxPageSize)
+		} else if sf.Type == reflect.PointerTo(paginationTy) && !fieldVal.IsNil() {
+			fieldVal.Interface().(*Pagination).normalize(builder.defaultPageSize, builder.maxPageSize)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,225 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginationNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Pagination
+		expected Pagination
+	}{
+		{
+			name:     "defaults",
+			input:    Pagination{},
+			expected: Pagination{Page: 1, PageSize: 20, Offset: 0, Limit: 20},
+		},
+		{
+			name:     "page and page size",
+			input:    Pagination{Page: 3, PageSize: 10},
+			expected: Pagination{Page: 3, PageSize: 10, Offset: 20, Limit: 10},
+		},
+		{
+			name:     "offset and limit",
+			input:    Pagination{Offset: 50, Limit: 25},
+			expected: Pagination{Page: 3, PageSize: 25, Offset: 50, Limit: 25},
+		},
+		{
+			name:     "page size capped",
+			input:    Pagination{Page: 2, PageSize: 1000},
+			expected: Pagination{Page: 2, PageSize: 100, Offset: 100, Limit: 100},
+		},
+		{
+			name:     "offset within a page",
+			input:    Pagination{Offset: 5, Limit: 20},
+			expected: Pagination{Page: 1, PageSize: 20, Offset: 5, Limit: 20},
+		},
+		{
+			name:     "page over offset",
+			input:    Pagination{Page: 2, Offset: 5, Limit: 20},
+			expected: Pagination{Page: 2, PageSize: 20, Offset: 20, Limit: 20},
+		},
+		{
+			name:     "page overflowing offset",
+			input:    Pagination{Page: math.MaxInt, PageSize: 100},
+			expected: Pagination{Page: math.MaxInt / 100, PageSize: 100, Offset: (math.MaxInt/100 - 1) * 100, Limit: 100},
+		},
+		{
+			name:     "largest offset",
+			input:    Pagination{Offset: math.MaxInt, Limit: 1},
+			expected: Pagination{Page: math.MaxInt, PageSize: 1, Offset: math.MaxInt, Limit: 1},
+		},
+		{
+			name:     "negative values",
+			input:    Pagination{Page: -1, PageSize: -5},
+			expected: Pagination{Page: 1, PageSize: 20, Offset: 0, Limit: 20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.input
+			p.normalize(DefaultPageSize, DefaultMaxPageSize)
+			assert.Equal(t, tt.expected, p)
+		})
+	}
+}
+
+func TestPaginationBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type ListUsersRequest struct {
+		Pagination
+		Query string `form:"query"`
+	}
+
+	handler := func(c *gin.Context, req ListUsersRequest) (interface{}, error) {
+		users := []string{"alice", "bob"}
+		return NewPageResponse(users, 42, req.Pagination), nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithPagination(10, 50))
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/users", ginHandler)
+
+	tests := []struct {
+		name             string
+		query            string
+		expectedPage     float64
+		expectedPageSize float64
+		expectedPages    float64
+	}{
+		{name: "builder defaults", query: "", expectedPage: 1, expectedPageSize: 10, expectedPages: 5},
+		{name: "explicit page", query: "?page=2&page_size=20", expectedPage: 2, expectedPageSize: 20, expectedPages: 3},
+		{name: "builder cap", query: "?page_size=500", expectedPage: 1, expectedPageSize: 50, expectedPages: 1},
+		{name: "offset and limit", query: "?offset=30&limit=15", expectedPage: 3, expectedPageSize: 15, expectedPages: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/users"+tt.query, nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			data := response["data"].(map[string]interface{})
+			assert.Equal(t, []interface{}{"alice", "bob"}, data["items"])
+			assert.Equal(t, float64(42), data["total"])
+			assert.Equal(t, tt.expectedPage, data["page"])
+			assert.Equal(t, tt.expectedPageSize, data["page_size"])
+			assert.Equal(t, tt.expectedPages, data["total_pages"])
+		})
+	}
+}
+
+func TestWithPaginationInvalidDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, defaultPageSize := range []int{0, -10} {
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithPagination(defaultPageSize, 50))
+		router := gin.New()
+		router.GET("/users", builder.MustFormBindingGinHandlerFunc(func(req struct{ Pagination }) (Pagination, error) {
+			return req.Pagination, nil
+		}))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users?offset=40", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status":"success","data":{"page":3,"page_size":20,"offset":40,"limit":20}}`, w.Body.String())
+	}
+}
+
+func TestNewPageResponseEmptyItems(t *testing.T) {
+	resp := NewPageResponse[int](nil, 0, Pagination{Page: 1, PageSize: 20})
+
+	data, err := json.Marshal(resp)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"items":[],"total":0,"page":1,"page_size":20,"total_pages":0}`, string(data))
+}
+
+func TestWithPaginationMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type listRequest struct {
+		Pagination
+		Query string `form:"q"`
+	}
+	handler := func(c *gin.Context, req listRequest) (interface{}, error) {
+		items := []string{"a", "b"}
+		return NewPageResponse(items, 45, req.Pagination), nil
+	}
+
+	serve := func(mode PaginationMode, target string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithPaginationMode(mode))
+		router := gin.New()
+		router.GET("/items", builder.MustFormBindingGinHandlerFunc(handler))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", target, nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("envelope", func(t *testing.T) {
+		w, response := serve(PaginationEnvelope, "/items?page=2&page_size=10")
+		assert.Empty(t, w.Header().Get("X-Total-Count"))
+		assert.Empty(t, w.Header().Get("Link"))
+		assert.Equal(t, float64(45), response["data"].(map[string]interface{})["total"])
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		w, response := serve(PaginationHeaders, "/items?q=x&page=2&page_size=10")
+		assert.Equal(t, "45", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, `</items?page=1&page_size=10&q=x>; rel="first", `+
+			`</items?page=1&page_size=10&q=x>; rel="prev", `+
+			`</items?page=3&page_size=10&q=x>; rel="next", `+
+			`</items?page=5&page_size=10&q=x>; rel="last"`, w.Header().Get("Link"))
+		assert.Equal(t, []interface{}{"a", "b"}, response["data"])
+	})
+
+	t.Run("envelope and headers", func(t *testing.T) {
+		w, response := serve(PaginationEnvelopeAndHeaders, "/items?offset=40&limit=10")
+		assert.Equal(t, "45", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, `</items?page=1&page_size=10>; rel="first", `+
+			`</items?page=4&page_size=10>; rel="prev", `+
+			`</items?page=5&page_size=10>; rel="last"`, w.Header().Get("Link"))
+		assert.Equal(t, float64(5), response["data"].(map[string]interface{})["page"])
+	})
+
+	t.Run("other data", func(t *testing.T) {
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithPaginationMode(PaginationHeaders))
+		router := gin.New()
+		router.GET("/items", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+			return WithHeaders([]string{"a"}, map[string]string{"X-Total-Count": "1"}), nil
+		}))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/items", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+		assert.Empty(t, w.Header().Get("Link"))
+	})
+}
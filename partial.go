@@ -0,0 +1,136 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// WithPartialValidation only enforces the validation rules of fields present
+// in the request, so that PATCH handlers can share request structs with POST
+// handlers: a `binding:"required"` field left out of a PATCH body is not
+// reported, while one sent as null or empty still is. Fields are present when
+// their path parameter, query parameter, form field, header or JSON key,
+// including nested JSON keys, is sent. Rules of absent fields set by default
+// tags are not enforced either; require tags always are.
+func WithPartialValidation() Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.partialValidation = true
+	}
+}
+
+// requestPresence tracks which fields of the request struct were sent
+type requestPresence struct {
+	ctx *gin.Context
+	// body is the decoded JSON object of the body, nil for other bodies
+	body map[string]json.RawMessage
+	// opaqueBody is set when the request has a body of another media type,
+	// such as a form or XML, whose sent fields are not tracked
+	opaqueBody bool
+}
+
+// newRequestPresence captures the inputs sent with the request of ctx. JSON
+// bodies are read ahead of binding and put back for it.
+func newRequestPresence(ctx *gin.Context) (*requestPresence, error) {
+	p := &requestPresence{ctx: ctx}
+	if ctx.Request.Body == nil || ctx.Request.Body == http.NoBody {
+		return p, nil
+	}
+	if ctx.ContentType() != binding.MIMEJSON {
+		p.opaqueBody = ctx.Request.ContentLength != 0
+		return p, nil
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Malformed bodies are left to the JSON binding to report
+	_ = json.Unmarshal(body, &p.body)
+	return p, nil
+}
+
+// has reports whether the field at the dotted Go field path of the struct
+// type ty was sent. Fields that cannot be resolved count as sent, so that
+// their rules are never skipped by mistake.
+func (p *requestPresence) has(ty reflect.Type, path []string) bool {
+	obj := p.body
+	for i, name := range path {
+		name, _, indexed := strings.Cut(name, "[")
+
+		for ty.Kind() == reflect.Pointer || ty.Kind() == reflect.Slice || ty.Kind() == reflect.Array || ty.Kind() == reflect.Map {
+			ty = ty.Elem()
+		}
+		if ty.Kind() != reflect.Struct {
+			return true
+		}
+		sf, ok := ty.FieldByName(name)
+		if !ok {
+			return true
+		}
+		ty = sf.Type
+
+		// Path, query and header inputs are flat
+		if i == 0 {
+			if source, key, ok := requiredInputSource(sf); ok && hasInput(p.ctx, source, key) {
+				return true
+			}
+		}
+
+		// The keys of embedded structs are part of the enclosing object
+		if sf.Anonymous && jsonFieldName(sf) == sf.Name {
+			continue
+		}
+
+		raw, ok := lookupRawField(obj, jsonFieldName(sf))
+		if !ok {
+			return false
+		}
+		// Elements of a sent array or map are sent
+		if indexed || i == len(path)-1 {
+			return true
+		}
+		obj = nil
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return true
+		}
+	}
+	return true
+}
+
+// filter drops the validation errors of fields of ty absent from the request,
+// returning nil if none remain. Other errors are returned unchanged, as are
+// all errors when p is nil.
+func (p *requestPresence) filter(ty reflect.Type, err error) error {
+	errs, ok := err.(validator.ValidationErrors)
+	if p == nil || !ok {
+		return err
+	}
+
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+
+	var kept validator.ValidationErrors
+	for _, fe := range errs {
+		// The namespace starts with the name of named request types
+		path := strings.TrimPrefix(fe.StructNamespace(), ty.Name()+".")
+		if p.has(ty, strings.Split(path, ".")) {
+			kept = append(kept, fe)
+		}
+	}
+
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
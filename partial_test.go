@@ -0,0 +1,74 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+)
+
+type partialUserRequest struct {
+	ID      int    `path:"id" binding:"required"`
+	Notify  bool   `form:"notify"`
+	Name    string `json:"name" binding:"required"`
+	Email   string `json:"email" binding:"required,email"`
+	Age     int    `json:"age" binding:"min=18"`
+	Address struct {
+		City string `json:"city" binding:"required"`
+		Zip  string `json:"zip" binding:"required,len=5"`
+	} `json:"address"`
+}
+
+func TestWithPartialValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		validator      binding.StructValidator
+		partial        bool
+		method         string
+		body           string
+		expectedStatus int
+	}{
+		{name: "full validation", method: "PATCH", body: `{"age":30}`, expectedStatus: http.StatusBadRequest},
+		{
+			name:           "full request",
+			method:         "POST",
+			body:           `{"name":"Ada","email":"ada@example.com","age":30,"address":{"city":"London","zip":"12345"}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{name: "absent fields", partial: true, method: "PATCH", body: `{"age":30}`, expectedStatus: http.StatusOK},
+		{name: "invalid present field", partial: true, method: "PATCH", body: `{"age":10}`, expectedStatus: http.StatusBadRequest},
+		{name: "empty present field", partial: true, method: "PATCH", body: `{"name":""}`, expectedStatus: http.StatusBadRequest},
+		{name: "null present field", partial: true, method: "PATCH", body: `{"email":null}`, expectedStatus: http.StatusBadRequest},
+		{name: "nested absent field", partial: true, method: "PATCH", body: `{"address":{"city":"Paris"}}`, expectedStatus: http.StatusOK},
+		{name: "nested invalid field", partial: true, method: "PATCH", body: `{"address":{"zip":"1"}}`, expectedStatus: http.StatusBadRequest},
+		{name: "builder validator", validator: binding.Validator, partial: true, method: "PATCH", body: `{"age":30}`, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.partial {
+				opts = append(opts, WithPartialValidation())
+			}
+			builder := NewBasicFormBindingGinHandlerBuilder(tt.validator, nil, opts...)
+
+			router := gin.New()
+			router.Handle(tt.method, "/users/:id", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req *partialUserRequest) error {
+				return nil
+			}))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, "/users/1?notify=true", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+var patternCache sync.Map // map[string]*regexp.Regexp
+
+// compilePattern returns the compiled regular expression of a "pattern" tag,
+// compiling it once for all the fields and handlers sharing it
+func compilePattern(tag string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Load(tag); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(tag)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := patternCache.LoadOrStore(tag, re)
+	return actual.(*regexp.Regexp), nil
+}
+
+// checkPatterns verifies every "pattern" tag of ty at handler build time,
+// compiling its regular expression
+func checkPatterns(ty reflect.Type) error {
+	return walkTypeFields(ty, func(sf reflect.StructField) error {
+		tag, ok := sf.Tag.Lookup("pattern")
+		if !ok {
+			return nil
+		}
+		if _, err := compilePattern(tag); err != nil {
+			return fmt.Errorf("field %s: invalid pattern: %w", sf.Name, err)
+		}
+		if !isModifiableType(sf.Type) {
+			return fmt.Errorf("field %s: pattern tag requires a string, *string or []string field, got %s", sf.Name, sf.Type)
+		}
+		return nil
+	})
+}
+
+// applyPatterns rejects non-empty values of "pattern" tagged fields that do
+// not match their regular expression. Empty values are left to defaults and
+// required checks.
+func applyPatterns(val reflect.Value) error {
+	if !typeInfoOf(val.Type()).patterns {
+		return nil
+	}
+
+	return walkFields(val, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		tag, ok := sf.Tag.Lookup("pattern")
+		if !ok {
+			return nil
+		}
+		re, err := compilePattern(tag)
+		if err != nil {
+			return fmt.Errorf("field %s: invalid pattern: %w", path, err)
+		}
+		return checkPatternValue(fieldVal, re, path)
+	})
+}
+
+func checkPatternValue(val reflect.Value, re *regexp.Regexp, path string) error {
+	switch val.Kind() {
+	case reflect.Pointer:
+		if val.IsNil() {
+			return nil
+		}
+		return checkPatternValue(val.Elem(), re, path)
+	case reflect.Slice, reflect.Array:
+		for j := 0; j < val.Len(); j++ {
+			if err := checkPatternValue(val.Index(j), re, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	s := val.String()
+	if s == "" || re.MatchString(s) {
+		return nil
+	}
+	return &fieldError{path: path, err: fmt.Errorf("field %s: value %q does not match pattern %s", path, s, re), value: s}
+}
@@ -0,0 +1,134 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatternTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Slug  string   `form:"slug" pattern:"^[a-z0-9-]+$"`
+		Code  *string  `json:"code" pattern:"^[A-Z]{3}$"`
+		Tags  []string `json:"tags" pattern:"^#\\w+$"`
+		Inner struct {
+			Ref string `json:"ref" pattern:"^ref-[0-9]+$"`
+		} `json:"inner"`
+	}) (interface{}, error) {
+		return gin.H{"slug": req.Slug}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/items", ginHandler)
+
+	tests := []struct {
+		name           string
+		query          string
+		body           string
+		expectedStatus int
+		expectedMsg    string
+		expectedField  string
+	}{
+		{
+			name:           "matching values",
+			query:          "?slug=hello-world-2",
+			body:           `{"code":"ABC","tags":["#go","#web"],"inner":{"ref":"ref-12"}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "zero values are skipped",
+			body:           `{}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid string",
+			query:          "?slug=Hello%20World",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Slug: value "Hello World" does not match pattern ^[a-z0-9-]+$`,
+			expectedField:  "slug",
+		},
+		{
+			name:           "invalid pointer",
+			body:           `{"code":"abcd"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Code: value "abcd" does not match pattern ^[A-Z]{3}$`,
+			expectedField:  "code",
+		},
+		{
+			name:           "invalid slice element",
+			body:           `{"tags":["#go","web"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Tags: value "web" does not match pattern ^#\w+$`,
+			expectedField:  "tags",
+		},
+		{
+			name:           "invalid nested field",
+			body:           `{"inner":{"ref":"12"}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Inner.Ref: value "12" does not match pattern ^ref-[0-9]+$`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/items"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, response["message"])
+			}
+			if tt.expectedField != "" {
+				assert.Equal(t, tt.expectedField, response["field"])
+			}
+		})
+	}
+}
+
+func TestPatternTagInvalidAtBuildTime(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Slug string `json:"slug" pattern:"^[a-z+$"`
+	}) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "field Slug: invalid pattern")
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Count int `json:"count" pattern:"^[0-9]+$"`
+	}) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "pattern tag requires a string, *string or []string field")
+}
+
+func TestCompilePatternCache(t *testing.T) {
+	re1, err := compilePattern("^[a-z]+$")
+	assert.NoError(t, err)
+	re2, err := compilePattern("^[a-z]+$")
+	assert.NoError(t, err)
+	assert.Same(t, re1, re2)
+}
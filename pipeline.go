@@ -0,0 +1,260 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Names of the built-in stages of a Pipeline
+const (
+	// StageBind decodes the path, query, header, host, TLS and body inputs
+	// into the request struct
+	StageBind = "bind"
+	// StageDefaults applies default tags and pagination defaults to the
+	// fields left zero by the request
+	StageDefaults = "defaults"
+	// StageMutate normalizes strings WithUnicodeNormalization, then applies
+	// mod tag transformations and sanitize tags
+	StageMutate = "mutate"
+	// StageValidate checks enum, pattern, idsafe, sort and filter fields,
+	// enforces binding tags with gin's validator and runs the builder's
+	// validator
+	StageValidate = "validate"
+	// StageHandle calls the handler function
+	StageHandle = "handle"
+)
+
+// StageFunc is a custom Pipeline stage. req is a pointer to the bound request
+// struct, which the stage may modify; handlers with a separate body struct run
+// the stages once for each of their structs. A returned error rejects the
+// request through the response handler unwrapped, so it can choose its status
+// with StatusCode or a sentinel error.
+type StageFunc func(ctx *gin.Context, req any) error
+
+// Pipeline is the sequence of stages a request goes through:
+//
+//	Bind → Defaults → Mutate → Validate → Handle
+//
+// Defaults are therefore applied before validation, so default values are
+// validated like values sent by the client. Custom stages can be inserted
+// between Bind and Handle, and the stages between them reordered, e.g. to
+// validate the request as it was sent before defaults are applied:
+//
+//	pipeline := ginbinding.NewPipeline().
+//		MoveBefore(ginbinding.StageValidate, ginbinding.StageDefaults).
+//		InsertAfter(ginbinding.StageBind, "tenant", scopeToTenant)
+//
+// The methods modify the pipeline and panic on unknown or duplicate stage
+// names and on attempts to place a stage before Bind or after Handle.
+type Pipeline struct {
+	stages []pipelineStage
+}
+
+// pipelineStage is a stage of a Pipeline. fn is nil for built-in stages.
+type pipelineStage struct {
+	name string
+	fn   StageFunc
+}
+
+// defaultPipeline is used by builders without WithPipeline. It is never
+// modified.
+var defaultPipeline = NewPipeline()
+
+// NewPipeline returns a pipeline with the built-in stages in their default
+// order
+func NewPipeline() *Pipeline {
+	return &Pipeline{stages: []pipelineStage{
+		{name: StageBind},
+		{name: StageDefaults},
+		{name: StageMutate},
+		{name: StageValidate},
+		{name: StageHandle},
+	}}
+}
+
+// Stages returns the names of the stages in the order they run
+func (p *Pipeline) Stages() []string {
+	names := make([]string, len(p.stages))
+	for i, stage := range p.stages {
+		names[i] = stage.name
+	}
+	return names
+}
+
+// InsertBefore inserts the custom stage fn named name before the stage named
+// stage
+func (p *Pipeline) InsertBefore(stage, name string, fn StageFunc) *Pipeline {
+	p.insert(p.index(stage), name, fn)
+	return p
+}
+
+// InsertAfter inserts the custom stage fn named name after the stage named
+// stage
+func (p *Pipeline) InsertAfter(stage, name string, fn StageFunc) *Pipeline {
+	p.insert(p.index(stage)+1, name, fn)
+	return p
+}
+
+// MoveBefore moves the stage named stage before the stage named before
+func (p *Pipeline) MoveBefore(stage, before string) *Pipeline {
+	moved := p.remove(stage)
+	p.insertStage(p.index(before), moved)
+	return p
+}
+
+// MoveAfter moves the stage named stage after the stage named after
+func (p *Pipeline) MoveAfter(stage, after string) *Pipeline {
+	moved := p.remove(stage)
+	p.insertStage(p.index(after)+1, moved)
+	return p
+}
+
+// WithPipeline runs requests through a copy of p instead of the default
+// pipeline. Changes made to p afterwards have no effect on the builder.
+func WithPipeline(p *Pipeline) Option {
+	pipeline := &Pipeline{stages: slices.Clone(p.stages)}
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.pipeline = pipeline
+	}
+}
+
+func (p *Pipeline) index(name string) int {
+	for i, stage := range p.stages {
+		if stage.name == name {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("ginbinding: unknown pipeline stage %q", name))
+}
+
+func (p *Pipeline) insert(i int, name string, fn StageFunc) {
+	if fn == nil {
+		panic(fmt.Sprintf("ginbinding: nil function for pipeline stage %q", name))
+	}
+	if slices.Contains(p.Stages(), name) {
+		panic(fmt.Sprintf("ginbinding: duplicate pipeline stage %q", name))
+	}
+	p.insertStage(i, pipelineStage{name: name, fn: fn})
+}
+
+func (p *Pipeline) insertStage(i int, stage pipelineStage) {
+	if i == 0 || i == len(p.stages) {
+		panic(fmt.Sprintf("ginbinding: pipeline stage %q must run between %q and %q", stage.name, StageBind, StageHandle))
+	}
+	p.stages = slices.Insert(p.stages, i, stage)
+}
+
+func (p *Pipeline) remove(name string) pipelineStage {
+	if name == StageBind || name == StageHandle {
+		panic(fmt.Sprintf("ginbinding: pipeline stage %q cannot be moved", name))
+	}
+	i := p.index(name)
+	stage := p.stages[i]
+	p.stages = slices.Delete(p.stages, i, i+1)
+	return stage
+}
+
+// runStages runs the stages between Bind and Handle on the bound value form
+// of type ty. Validation errors of fields absent from the request are dropped
+// when present is not nil. Custom stages are skipped when ctx is nil, for
+// in-process calls.
+func (builder *BasicFormBindingGinHandlerBuilder) runStages(ctx *gin.Context, ty reflect.Type, form reflect.Value, present *requestPresence) error {
+	pipeline := builder.pipeline
+	if pipeline == nil {
+		pipeline = defaultPipeline
+	}
+
+	val := form
+	if val.Kind() == reflect.Pointer {
+		val = val.Elem()
+	}
+
+	stages := pipeline.stages
+	for _, stage := range stages[1 : len(stages)-1] {
+		var err error
+		switch stage.name {
+		case StageDefaults:
+			err = builder.applyDefaults(val)
+		case StageMutate:
+			err = builder.normalizeStrings(val)
+			if err == nil {
+				err = applyModifiers(val)
+			}
+			if err == nil {
+				err = builder.applySanitizers(val)
+			}
+		case StageValidate:
+			err = builder.validate(ty, form, present)
+		default:
+			if ctx == nil {
+				continue
+			}
+			if err = stage.fn(ctx, val.Addr().Interface()); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return builder.bindingError(ty, err)
+		}
+	}
+	return nil
+}
+
+// applyDefaults applies the default tags and pagination defaults of the
+// struct val
+func (builder *BasicFormBindingGinHandlerBuilder) applyDefaults(val reflect.Value) error {
+	if err := builder.applyDefaultValues(val); err != nil {
+		return err
+	}
+	return builder.normalizePagination(val)
+}
+
+// validate checks the enum, pattern, idsafe, sort and filter fields of the
+// bound value form and its binding tags. Validator failures are returned in a
+// ValidationError.
+func (builder *BasicFormBindingGinHandlerBuilder) validate(ty reflect.Type, form reflect.Value, present *requestPresence) error {
+	val := reflect.Indirect(form)
+	if err := applyEnums(val); err != nil {
+		return err
+	}
+	if err := applyPatterns(val); err != nil {
+		return err
+	}
+	if err := applyIDSafe(val); err != nil {
+		return err
+	}
+	if err := builder.checkSortFields(val); err != nil {
+		return err
+	}
+	if err := builder.checkFilterFields(val); err != nil {
+		return err
+	}
+
+	// binding tags are always enforced by gin's validator, which only runs
+	// here for types with binding tags unless it is the builder's validator,
+	// as with WithGinDefaultValidator
+	if binding.Validator != nil && builder.validator != binding.Validator && typeInfoOf(val.Type()).validated {
+		if err := present.filter(ty, binding.Validator.ValidateStruct(val.Addr().Interface())); err != nil {
+			return &ValidationError{Err: err, Status: builder.validationStatus}
+		}
+	}
+
+	if builder.validator != nil {
+		if err := present.filter(ty, builder.validator.ValidateStruct(form.Interface())); err != nil {
+			return &ValidationError{Err: err, Status: builder.validationStatus}
+		}
+	}
+	return nil
+}
+
+// isValidationErrors reports whether err is the validation failure of a
+// request that was otherwise bound
+func isValidationErrors(err error) bool {
+	_, ok := err.(validator.ValidationErrors)
+	return ok
+}
@@ -0,0 +1,116 @@
+package ginbinding
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_Stages(t *testing.T) {
+	noop := func(c *gin.Context, req any) error { return nil }
+
+	assert.Equal(t, []string{StageBind, StageDefaults, StageMutate, StageValidate, StageHandle}, NewPipeline().Stages())
+
+	pipeline := NewPipeline().
+		MoveBefore(StageValidate, StageDefaults).
+		InsertAfter(StageBind, "tenant", noop).
+		InsertBefore(StageHandle, "audit", noop)
+	assert.Equal(t, []string{StageBind, "tenant", StageValidate, StageDefaults, StageMutate, "audit", StageHandle}, pipeline.Stages())
+
+	pipeline.MoveAfter("tenant", StageMutate)
+	assert.Equal(t, []string{StageBind, StageValidate, StageDefaults, StageMutate, "tenant", "audit", StageHandle}, pipeline.Stages())
+
+	assert.Panics(t, func() { NewPipeline().MoveBefore("unknown", StageValidate) })
+	assert.Panics(t, func() { NewPipeline().InsertAfter(StageBind, StageDefaults, noop) })
+	assert.Panics(t, func() { NewPipeline().InsertBefore(StageBind, "early", noop) })
+	assert.Panics(t, func() { NewPipeline().InsertAfter(StageHandle, "late", noop) })
+	assert.Panics(t, func() { NewPipeline().MoveAfter(StageBind, StageValidate) })
+	assert.Panics(t, func() { NewPipeline().InsertAfter(StageBind, "nil", nil) })
+}
+
+type pipelineRequest struct {
+	Limit  int    `form:"limit" default:"500" binding:"max=100"`
+	Tenant string `header:"X-Tenant"`
+}
+
+func TestWithPipeline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tenantStage := func(c *gin.Context, req any) error {
+		r := req.(*pipelineRequest)
+		if r.Tenant == "" {
+			return fmt.Errorf("no tenant: %w", ErrForbidden)
+		}
+		r.Tenant = "tenant-" + r.Tenant
+		return nil
+	}
+
+	tests := []struct {
+		name           string
+		pipeline       *Pipeline
+		tenant         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "defaults before validation",
+			pipeline:       NewPipeline(),
+			tenant:         "acme",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"code":"VALIDATION_FAILED"`,
+		},
+		{
+			name:           "validation before defaults",
+			pipeline:       NewPipeline().MoveBefore(StageValidate, StageDefaults),
+			tenant:         "acme",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"limit":500`,
+		},
+		{
+			name:           "custom stage",
+			pipeline:       NewPipeline().MoveBefore(StageValidate, StageDefaults).InsertAfter(StageBind, "tenant", tenantStage),
+			tenant:         "acme",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"tenant":"tenant-acme"`,
+		},
+		{
+			name:           "custom stage error",
+			pipeline:       NewPipeline().InsertAfter(StageBind, "tenant", tenantStage),
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `"code":"FORBIDDEN"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithPipeline(tt.pipeline))
+
+			router := gin.New()
+			router.GET("/items", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req pipelineRequest) (any, error) {
+				return gin.H{"limit": req.Limit, "tenant": req.Tenant}, nil
+			}))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/items", nil)
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant", tt.tenant)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestWithPipeline_CopiesPipeline(t *testing.T) {
+	pipeline := NewPipeline()
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithPipeline(pipeline))
+
+	pipeline.MoveBefore(StageValidate, StageDefaults)
+	assert.Equal(t, []string{StageBind, StageDefaults, StageMutate, StageValidate, StageHandle}, builder.pipeline.Stages())
+}
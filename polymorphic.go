@@ -0,0 +1,187 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// WithDiscriminator registers the concrete types of the interface I for
+// polymorphic body binding. Interface fields of type I tagged with
+// `kind:"type"` are decoded into the type registered under the value of the
+// "type" key of their JSON object:
+//
+//	ginbinding.WithDiscriminator(map[string]Payment{
+//		"card": CardPayment{},
+//		"bank": &BankPayment{},
+//	})
+//
+// Registered values only select the type, which is bound as a value or a
+// pointer like the value. It panics if I is not an interface type or a
+// registered value is nil.
+func WithDiscriminator[I any](types map[string]I) Option {
+	ifaceTy := reflect.TypeOf((*I)(nil)).Elem()
+	if ifaceTy.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("ginbinding: WithDiscriminator needs an interface type, got %s", ifaceTy))
+	}
+
+	registered := make(map[string]reflect.Type, len(types))
+	for kind, v := range types {
+		ty := reflect.TypeOf(any(v))
+		if ty == nil {
+			panic(fmt.Sprintf("ginbinding: WithDiscriminator: nil value registered for %q", kind))
+		}
+		registered[kind] = ty
+	}
+
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		// Copy so that per-handler registrations never leak into the builder
+		// the handler was copied from
+		discriminators := maps.Clone(builder.discriminators)
+		if discriminators == nil {
+			discriminators = map[reflect.Type]map[string]reflect.Type{}
+		}
+		discriminators[ifaceTy] = registered
+		builder.discriminators = discriminators
+	}
+}
+
+// checkDiscriminators verifies at handler build time that every field with a
+// kind tag is an interface type registered with WithDiscriminator
+func (builder *BasicFormBindingGinHandlerBuilder) checkDiscriminators(ty reflect.Type) error {
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		key, ok := sf.Tag.Lookup("kind")
+		if !ok {
+			continue
+		}
+		if key == "" {
+			return fmt.Errorf("field %s: kind tag needs the name of the discriminator key", sf.Name)
+		}
+		if sf.Type.Kind() != reflect.Interface {
+			return fmt.Errorf("field %s: kind tag requires an interface type, got %s", sf.Name, sf.Type)
+		}
+		if _, ok := builder.discriminators[sf.Type]; !ok {
+			return fmt.Errorf("field %s: no discriminator registered for %s", sf.Name, sf.Type)
+		}
+	}
+	return nil
+}
+
+// discriminatedField is an interface field prepared for decoding into its
+// concrete type
+type discriminatedField struct {
+	index int
+	ptr   reflect.Value
+	// value is set when the registered type is not a pointer, in which case
+	// the decoded value replaces the pointer
+	value bool
+}
+
+// prepareDiscriminated points the kind-tagged interface fields of the struct
+// pointed to by val at new values of the concrete types selected by the JSON
+// body, so that the regular JSON binding decodes into them
+func (builder *BasicFormBindingGinHandlerBuilder) prepareDiscriminated(ctx *gin.Context, val reflect.Value) ([]discriminatedField, error) {
+	if ctx.Request.Body == nil || ctx.ContentType() != binding.MIMEJSON {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(body, &obj); err != nil {
+		// Leave malformed bodies to the regular JSON binding to report
+		return nil, nil
+	}
+
+	var fields []discriminatedField
+	ty := val.Type().Elem()
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		kindKey, ok := sf.Tag.Lookup("kind")
+		if !ok {
+			continue
+		}
+
+		raw, ok := lookupRawField(obj, jsonFieldName(sf))
+		if !ok || string(raw) == "null" {
+			continue
+		}
+
+		var inner map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &inner); err != nil {
+			return nil, &fieldError{path: sf.Name, err: fmt.Errorf("field %s: %w", sf.Name, err)}
+		}
+
+		var kind string
+		if kindRaw, ok := inner[kindKey]; !ok {
+			return nil, &fieldError{path: sf.Name, err: fmt.Errorf("field %s: missing discriminator %q", sf.Name, kindKey)}
+		} else if err := json.Unmarshal(kindRaw, &kind); err != nil {
+			return nil, &fieldError{path: sf.Name, err: fmt.Errorf("field %s: discriminator %q must be a string", sf.Name, kindKey)}
+		}
+
+		concrete, ok := builder.discriminators[sf.Type][kind]
+		if !ok {
+			return nil, &fieldError{path: sf.Name, err: fmt.Errorf("field %s: unknown %s %q", sf.Name, kindKey, kind)}
+		}
+
+		field := discriminatedField{index: i}
+		if concrete.Kind() == reflect.Pointer {
+			field.ptr = reflect.New(concrete.Elem())
+		} else {
+			field.ptr = reflect.New(concrete)
+			field.value = true
+		}
+		val.Elem().Field(i).Set(field.ptr)
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// finishDiscriminated replaces the pointers of fields registered as values
+// with the decoded values
+func finishDiscriminated(val reflect.Value, fields []discriminatedField) {
+	for _, field := range fields {
+		if field.value {
+			val.Elem().Field(field.index).Set(field.ptr.Elem())
+		}
+	}
+}
+
+// jsonFieldName returns the JSON key of sf
+func jsonFieldName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" {
+		return sf.Name
+	}
+	return name
+}
+
+// lookupRawField finds the value of key in obj the way encoding/json matches
+// keys: exact match first, then case-insensitive
+func lookupRawField(obj map[string]json.RawMessage, key string) (json.RawMessage, bool) {
+	if raw, ok := obj[key]; ok {
+		return raw, true
+	}
+	for k, raw := range obj {
+		if strings.EqualFold(k, key) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,134 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPayment interface {
+	Method() string
+}
+
+type testCardPayment struct {
+	Number string `json:"number"`
+}
+
+func (p testCardPayment) Method() string { return "card:" + p.Number }
+
+type testBankPayment struct {
+	IBAN string `json:"iban"`
+}
+
+func (p *testBankPayment) Method() string { return "bank:" + p.IBAN }
+
+func TestDiscriminatorBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Amount  int         `json:"amount"`
+		Payment testPayment `json:"payment" kind:"type"`
+	}) (interface{}, error) {
+		if req.Payment == nil {
+			return gin.H{"amount": req.Amount}, nil
+		}
+		return gin.H{"amount": req.Amount, "method": req.Payment.Method()}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithDiscriminator(map[string]testPayment{
+		"card": testCardPayment{},
+		"bank": &testBankPayment{},
+	}))
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/payments", ginHandler)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedMethod interface{}
+	}{
+		{
+			name:           "value type",
+			body:           `{"amount":10,"payment":{"type":"card","number":"4242"}}`,
+			expectedStatus: http.StatusOK,
+			expectedMethod: "card:4242",
+		},
+		{
+			name:           "pointer type",
+			body:           `{"amount":10,"payment":{"type":"bank","iban":"DE89"}}`,
+			expectedStatus: http.StatusOK,
+			expectedMethod: "bank:DE89",
+		},
+		{
+			name:           "absent",
+			body:           `{"amount":10}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown type",
+			body:           `{"amount":10,"payment":{"type":"cash"}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing discriminator",
+			body:           `{"amount":10,"payment":{"number":"4242"}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/payments", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			data := response["data"].(map[string]interface{})
+			assert.Equal(t, float64(10), data["amount"])
+			assert.Equal(t, tt.expectedMethod, data["method"])
+		})
+	}
+}
+
+func TestDiscriminatorBinding_BuildErrors(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Payment testPayment `json:"payment" kind:"type"`
+	}) error {
+		return nil
+	})
+	assert.EqualError(t, err, "field Payment: no discriminator registered for ginbinding.testPayment")
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Payment testCardPayment `json:"payment" kind:"type"`
+	}) error {
+		return nil
+	})
+	assert.EqualError(t, err, "field Payment: kind tag requires an interface type, got ginbinding.testCardPayment")
+}
+
+func TestWithDiscriminator_PanicsOnNonInterface(t *testing.T) {
+	assert.Panics(t, func() {
+		WithDiscriminator(map[string]testCardPayment{"card": {}})
+	})
+}
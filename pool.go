@@ -0,0 +1,117 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Resetter is implemented by request structs, through a pointer receiver,
+// that can be reused across requests to save their allocation on hot
+// endpoints. Their instances are taken from a sync.Pool for binding, and
+// Reset is called once the response has been written before they are put
+// back. Reset must return the struct to its zero state, but may keep the
+// capacity of its slices and maps:
+//
+//	func (r *SearchRequest) Reset() {
+//		*r = SearchRequest{Tags: r.Tags[:0]}
+//	}
+//
+// Handlers must not keep the request, or slices and maps of it, after they
+// return. With WithDebug, requests are verified to be reset and the handler
+// panics if they are not. Requests are not pooled for handlers with a
+// timeout, whose function may still run after the response was written.
+type Resetter interface {
+	Reset()
+}
+
+var resetterTy = reflect.TypeOf((*Resetter)(nil)).Elem()
+
+// newRequestPool returns the pool of the struct type ty, or nil if ty does
+// not implement Resetter
+func newRequestPool(ty reflect.Type) *sync.Pool {
+	if !reflect.PointerTo(ty).Implements(resetterTy) {
+		return nil
+	}
+	return &sync.Pool{New: func() any { return reflect.New(ty).Interface() }}
+}
+
+// isPooledRequest reports whether the request parameter type ty is pooled by
+// the handlers of the builder
+func (builder *BasicFormBindingGinHandlerBuilder) isPooledRequest(ty reflect.Type) bool {
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	return builder.timeout <= 0 && ty.Kind() == reflect.Struct && typeInfoOf(ty).pool != nil
+}
+
+// newRequest returns a pointer to a zero request struct of type ty
+func newRequest(ty reflect.Type) reflect.Value {
+	if pool := typeInfoOf(ty).pool; pool != nil {
+		return reflect.ValueOf(pool.Get())
+	}
+	return reflect.New(ty)
+}
+
+// releaseRequestAfter releases form, once running is closed if the handler
+// function outlived its timeout and may still use it
+func (builder *BasicFormBindingGinHandlerBuilder) releaseRequestAfter(form reflect.Value, running <-chan struct{}) {
+	if running == nil {
+		builder.releaseRequest(form)
+		return
+	}
+	go func() {
+		<-running
+		builder.releaseRequest(form)
+	}()
+}
+
+// releaseRequest resets the bound request form and puts it back in the pool
+// of its type
+func (builder *BasicFormBindingGinHandlerBuilder) releaseRequest(form reflect.Value) {
+	if !form.IsValid() {
+		return
+	}
+	ptr := form
+	if ptr.Kind() != reflect.Pointer {
+		ptr = ptr.Addr()
+	} else if ptr.IsNil() {
+		return
+	}
+
+	r := ptr.Interface().(Resetter)
+	r.Reset()
+	if builder.debugWriter != nil {
+		if path, ok := notReset(ptr.Elem(), ""); !ok {
+			panic(fmt.Sprintf("ginbinding: %s.Reset left %s set", ptr.Type(), path))
+		}
+	}
+	typeInfoOf(ptr.Type().Elem()).pool.Put(r)
+}
+
+// notReset returns the path of the first field of v that is not zero, other
+// than empty slices and maps, which may be kept for their capacity
+func notReset(v reflect.Value, path string) (string, bool) {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return path, v.Len() == 0
+	case reflect.Struct:
+		if v.Type() == timeTy {
+			return path, v.IsZero()
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if p, ok := notReset(v.Field(i), joinFieldPath(path, v.Type().Field(i).Name)); !ok {
+				return p, false
+			}
+		}
+		return path, true
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if p, ok := notReset(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); !ok {
+				return p, false
+			}
+		}
+		return path, true
+	}
+	return path, v.IsZero()
+}
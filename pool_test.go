@@ -0,0 +1,92 @@
+package ginbinding
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var pooledResets atomic.Int32
+
+type pooledSearchRequest struct {
+	Query string   `form:"q"`
+	Tags  []string `form:"tag"`
+}
+
+func (r *pooledSearchRequest) Reset() {
+	pooledResets.Add(1)
+	*r = pooledSearchRequest{Tags: r.Tags[:0]}
+}
+
+type leakyRequest struct {
+	Query string `form:"q"`
+	Page  int    `form:"page"`
+}
+
+func (r *leakyRequest) Reset() {
+	r.Query = ""
+}
+
+func TestRequestPool(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var seen []pooledSearchRequest
+	handler := func(c *gin.Context, req *pooledSearchRequest) error {
+		seen = append(seen, pooledSearchRequest{Query: req.Query, Tags: append([]string(nil), req.Tags...)})
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.GET("/search", builder.MustFormBindingGinHandlerFunc(handler))
+	router.GET("/slow", builder.MustFormBindingGinHandlerFunc(handler, WithTimeout(time.Second)))
+
+	pooledResets.Store(0)
+	for _, target := range []string{"/search?q=go&tag=a&tag=b", "/search?q=rust", "/search"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", target, nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	assert.Equal(t, int32(3), pooledResets.Load())
+	assert.Equal(t, []pooledSearchRequest{
+		{Query: "go", Tags: []string{"a", "b"}},
+		{Query: "rust"},
+		{},
+	}, seen)
+
+	t.Run("not pooled with a timeout", func(t *testing.T) {
+		pooledResets.Store(0)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/slow?q=go", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, int32(0), pooledResets.Load())
+	})
+}
+
+func TestRequestPoolVerifiesReset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req leakyRequest) error {
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithDebug(io.Discard))
+	router := gin.New()
+	router.GET("/search", builder.MustFormBindingGinHandlerFunc(handler))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/search?q=go&page=2", nil)
+	assert.PanicsWithValue(t, "ginbinding: *ginbinding.leakyRequest.Reset left Page set", func() {
+		router.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,53 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Provide registers services that handlers can declare as parameters after
+// the request struct, such as func(*gin.Context, Req, UserService). A
+// parameter receives the service of exactly its type, or, for interface
+// parameters, the only registered service implementing the interface.
+// Services registered later replace earlier services of the same type.
+// Provide must be called before the handlers using the services are built.
+func (builder *BasicFormBindingGinHandlerBuilder) Provide(services ...any) *BasicFormBindingGinHandlerBuilder {
+	for _, service := range services {
+		if service == nil {
+			panic("ginbinding: Provide called with nil service")
+		}
+		builder.providers = append(builder.providers, reflect.ValueOf(service))
+	}
+	return builder
+}
+
+// provided returns the registered service for a parameter of type ty
+func (builder *BasicFormBindingGinHandlerBuilder) provided(ty reflect.Type) (reflect.Value, bool, error) {
+	for i := len(builder.providers) - 1; i >= 0; i-- {
+		if builder.providers[i].Type() == ty {
+			return builder.providers[i], true, nil
+		}
+	}
+
+	if ty.Kind() != reflect.Interface {
+		return reflect.Value{}, false, nil
+	}
+
+	var match reflect.Value
+	for _, service := range builder.providers {
+		if !service.Type().Implements(ty) {
+			continue
+		}
+		if match.IsValid() && match.Type() != service.Type() {
+			return reflect.Value{}, false, fmt.Errorf("ambiguous providers for %s: %s and %s", ty, match.Type(), service.Type())
+		}
+		match = service
+	}
+	if !match.IsValid() {
+		return reflect.Value{}, false, nil
+	}
+
+	ret := reflect.New(ty).Elem()
+	ret.Set(match)
+	return ret, true, nil
+}
@@ -0,0 +1,94 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type provideTestUserService interface {
+	Name(id int) string
+}
+
+type provideTestUsers map[int]string
+
+func (u provideTestUsers) Name(id int) string { return u[id] }
+
+type provideTestClock struct{ now string }
+
+func TestProvide(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		ID int `path:"id"`
+	}, users provideTestUserService, clock *provideTestClock) (interface{}, error) {
+		return gin.H{"name": users.Name(req.ID), "now": clock.now}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil).
+		Provide(provideTestUsers{1: "alice"}, &provideTestClock{now: "noon"})
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/users/:id", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/1", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "alice", "now": "noon"}, response["data"])
+}
+
+func TestProvideWithoutRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, clock *provideTestClock) (interface{}, error) {
+		return gin.H{"now": clock.now}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil).
+		Provide(&provideTestClock{now: "noon"}, &provideTestClock{now: "midnight"})
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/now", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/now", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"now":"midnight"`)
+}
+
+func TestProvideErrors(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct{}, users provideTestUserService) error {
+		return nil
+	})
+	assert.EqualError(t, err, "parameter 3: no provider registered for ginbinding.provideTestUserService")
+
+	builder.Provide(provideTestUsers{}, provideTestOtherUsers{})
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct{}, users provideTestUserService) error {
+		return nil
+	})
+	assert.EqualError(t, err, "parameter 3: ambiguous providers for ginbinding.provideTestUserService: ginbinding.provideTestUsers and ginbinding.provideTestOtherUsers")
+
+	assert.Panics(t, func() { builder.Provide(nil) })
+}
+
+type provideTestOtherUsers struct{}
+
+func (provideTestOtherUsers) Name(int) string { return "" }
@@ -0,0 +1,90 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rewriteQuery converts the query values of top-level fields that gin's query
+// and form binding cannot parse on its own, such as "10MB" for unit:"bytes",
+// "1.234,5" for numfmt:"de" or "yes" for a bool, into values it can. The returned function restores the
+// original query once binding is done.
+func (builder *BasicFormBindingGinHandlerBuilder) rewriteQuery(ctx *gin.Context, ty reflect.Type) (func(), error) {
+	// Fill gin's query cache from the original query so that handlers keep
+	// seeing the values as sent
+	ctx.GetQuery("")
+
+	query := ctx.Request.URL.Query()
+	rewritten := false
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		tag, ok := queryTag(sf)
+		if !ok {
+			continue
+		}
+		key, _, _ := strings.Cut(tag, ",")
+		for j, v := range query[key] {
+			converted, err := builder.queryValue(sf, v)
+			if err != nil {
+				return func() {}, &fieldError{path: sf.Name, err: fmt.Errorf("failed to parse query parameter %q: %w", key, err), value: v}
+			}
+			if converted != v {
+				query[key][j] = converted
+				rewritten = true
+			}
+		}
+	}
+
+	if !rewritten {
+		return func() {}, nil
+	}
+
+	rawQuery := ctx.Request.URL.RawQuery
+	ctx.Request.URL.RawQuery = query.Encode()
+	return func() {
+		ctx.Request.URL.RawQuery = rawQuery
+		// Parsed lazily again from the original query when needed
+		ctx.Request.Form = nil
+	}, nil
+}
+
+// queryValue converts the query value v of the field sf into the form gin
+// parses
+func (builder *BasicFormBindingGinHandlerBuilder) queryValue(sf reflect.StructField, v string) (string, error) {
+	if unit, ok := sf.Tag.Lookup("unit"); ok {
+		return parseUnit(v, unit)
+	}
+	if locale, ok := sf.Tag.Lookup("numfmt"); ok {
+		return parseLocaleNumber(v, locale)
+	}
+	if isBoolField(sf) {
+		// Values outside the vocabulary are left to gin, which accepts the
+		// values of strconv.ParseBool
+		if b, err := builder.parseBool(v); err == nil {
+			return strconv.FormatBool(b), nil
+		}
+	}
+	return v, nil
+}
+
+// rewritesQuery reports whether the query value of sf may need rewriting
+func rewritesQuery(sf reflect.StructField) bool {
+	if _, ok := queryTag(sf); !ok {
+		return false
+	}
+	_, unit := sf.Tag.Lookup("unit")
+	_, numfmt := sf.Tag.Lookup("numfmt")
+	return unit || numfmt || isBoolField(sf)
+}
+
+func isBoolField(sf reflect.StructField) bool {
+	ty := sf.Type
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	return ty.Kind() == reflect.Bool
+}
@@ -0,0 +1,69 @@
+package ginbinding
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryLimits bounds the query strings of requests, see WithQueryLimits. Zero
+// fields mean no limit.
+type QueryLimits struct {
+	// MaxLength is the maximum length of the raw query string in bytes
+	MaxLength int
+	// MaxParams is the maximum number of query parameters, counting every
+	// value of repeated parameters
+	MaxParams int
+}
+
+// WithQueryLimits rejects requests whose query string exceeds limits before
+// anything is bound, e.g. for endpoints exposed to untrusted crawlers. Query
+// strings too long fail with ErrURITooLong (414) through the response handler,
+// and query strings with too many parameters with a BindingError (400):
+//
+//	r.GET("/search", builder.MustFormBindingGinHandlerFunc(search,
+//		ginbinding.WithQueryLimits(ginbinding.QueryLimits{MaxLength: 2048, MaxParams: 20})))
+func WithQueryLimits(limits QueryLimits) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		if limits == (QueryLimits{}) {
+			builder.queryLimits = nil
+			return
+		}
+		builder.queryLimits = &limits
+	}
+}
+
+// checkQueryLimits checks the query string of ctx against the query limits of
+// the builder
+func (builder *BasicFormBindingGinHandlerBuilder) checkQueryLimits(ctx *gin.Context) error {
+	limits := builder.queryLimits
+	if limits == nil {
+		return nil
+	}
+
+	query := ctx.Request.URL.RawQuery
+	if limits.MaxLength > 0 && len(query) > limits.MaxLength {
+		return fmt.Errorf("query string of %d bytes exceeds the limit of %d bytes: %w", len(query), limits.MaxLength, ErrURITooLong)
+	}
+
+	if limits.MaxParams > 0 {
+		// Count the parameters the way url.ParseQuery splits them, without
+		// decoding them
+		n := 0
+		for query != "" {
+			var param string
+			param, query, _ = strings.Cut(query, "&")
+			if param == "" {
+				continue
+			}
+			if n++; n > limits.MaxParams {
+				return &BindingError{
+					Err:    fmt.Errorf("query string has more than %d parameters", limits.MaxParams),
+					Source: SourceQuery,
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithQueryLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	bound := false
+	handler := func(c *gin.Context, req struct {
+		Q    string   `form:"q"`
+		Tags []string `form:"tag"`
+	}) error {
+		bound = true
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.GET("/search", builder.MustFormBindingGinHandlerFunc(handler,
+		WithQueryLimits(QueryLimits{MaxLength: 32, MaxParams: 3})))
+	router.GET("/unlimited", builder.MustFormBindingGinHandlerFunc(handler))
+
+	tests := []struct {
+		name           string
+		target         string
+		expectedStatus int
+		expectedCode   string
+		expectedMsg    string
+	}{
+		{
+			name:           "within limits",
+			target:         "/search?q=go&tag=a&tag=b",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "empty parameters are not counted",
+			target:         "/search?q=go&&tag=a&&tag=b&",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "too long",
+			target:         "/search?q=a-query-string-that-is-too-long",
+			expectedStatus: http.StatusRequestURITooLong,
+			expectedCode:   CodeURITooLong,
+			expectedMsg:    "query string of 33 bytes exceeds the limit of 32 bytes: URI too long",
+		},
+		{
+			name:           "too many parameters",
+			target:         "/search?tag=a&tag=b&tag=c&tag=d",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeBindingFailed,
+			expectedMsg:    "query string has more than 3 parameters",
+		},
+		{
+			name:           "other handlers are not limited",
+			target:         "/unlimited?tag=a&tag=b&tag=c&tag=d&q=a-query-string-that-is-too-long",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bound = false
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.target, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedStatus == http.StatusOK, bound)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedCode, response["code"])
+				assert.Equal(t, tt.expectedMsg, response["message"])
+			}
+			if tt.expectedCode == CodeBindingFailed {
+				assert.Equal(t, "query", response["source"])
+			}
+		})
+	}
+}
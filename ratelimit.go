@@ -0,0 +1,144 @@
+package ginbinding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimiter decides whether requests are within their rate limit.
+// Implementations must be safe for concurrent use.
+type RateLimiter interface {
+	// Allow counts a request for key and returns the state of its limit
+	Allow(ctx context.Context, key string) (RateLimit, error)
+}
+
+// RateLimit is the state of the rate limit of a key after a request
+type RateLimit struct {
+	// Allowed reports whether the request is within the limit
+	Allowed bool
+	// Limit is the number of requests allowed per window
+	Limit int
+	// Remaining is the number of requests left in the current window
+	Remaining int
+	// Reset is the time until the current window ends
+	Reset time.Duration
+}
+
+// RateLimitKeyFunc returns the rate limit key of a request, given its bound
+// request struct, or nil for handlers without a request. Requests with an
+// empty key are not limited.
+type RateLimitKeyFunc func(ctx *gin.Context, req any) string
+
+// WithRateLimit limits the requests to the handler per key, as counted by
+// limiter. Since the key is computed from the bound request, it can use the
+// API key header or the user ID path parameter of the request struct;
+// keyFunc defaults to the client IP. Responses carry X-RateLimit-Limit,
+// X-RateLimit-Remaining and X-RateLimit-Reset headers, and requests over the
+// limit are rejected with ErrTooManyRequests (429) and a Retry-After header
+// through the response handler before the handler function runs.
+//
+//	byAPIKey := func(c *gin.Context, req any) string { return req.(ReportRequest).APIKey }
+//	r.GET("/reports", builder.MustFormBindingGinHandlerFunc(listReports,
+//		ginbinding.WithRateLimit(ginbinding.NewFixedWindowLimiter(100, time.Minute), byAPIKey)))
+func WithRateLimit(limiter RateLimiter, keyFunc RateLimitKeyFunc) Option {
+	if keyFunc == nil {
+		keyFunc = func(ctx *gin.Context, _ any) string { return ctx.ClientIP() }
+	}
+	rl := &rateLimit{limiter: limiter, key: keyFunc}
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.rateLimit = rl
+	}
+}
+
+// rateLimit is the configuration of WithRateLimit
+type rateLimit struct {
+	limiter RateLimiter
+	key     RateLimitKeyFunc
+}
+
+// check counts the request of ctx against its limit and sets the rate limit
+// headers
+func (rl *rateLimit) check(ctx *gin.Context, req any) error {
+	key := rl.key(ctx, req)
+	if key == "" {
+		return nil
+	}
+
+	limit, err := rl.limiter.Allow(ctx.Request.Context(), key)
+	if err != nil {
+		return err
+	}
+
+	reset := strconv.Itoa(int(math.Ceil(limit.Reset.Seconds())))
+	ctx.Header("X-RateLimit-Limit", strconv.Itoa(limit.Limit))
+	ctx.Header("X-RateLimit-Remaining", strconv.Itoa(max(limit.Remaining, 0)))
+	ctx.Header("X-RateLimit-Reset", reset)
+	if limit.Allowed {
+		return nil
+	}
+
+	ctx.Header("Retry-After", reset)
+	return fmt.Errorf("rate limit of %d requests exceeded, retry in %ss: %w", limit.Limit, reset, ErrTooManyRequests)
+}
+
+// FixedWindowLimiter is an in-memory RateLimiter allowing a number of
+// requests per key in consecutive windows of a fixed duration
+type FixedWindowLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu      sync.Mutex
+	windows map[string]*limitWindow
+	// swept is when the windows that ended were last dropped
+	swept time.Time
+}
+
+type limitWindow struct {
+	start time.Time
+	count int
+}
+
+// NewFixedWindowLimiter returns a limiter allowing limit requests per key in
+// every window
+func NewFixedWindowLimiter(limit int, window time.Duration) *FixedWindowLimiter {
+	return &FixedWindowLimiter{limit: limit, window: window, windows: map[string]*limitWindow{}}
+}
+
+// Allow implements RateLimiter
+func (l *FixedWindowLimiter) Allow(_ context.Context, key string) (RateLimit, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop the windows that ended, once per window, so that the map does not
+	// grow with every key ever seen
+	if now.Sub(l.swept) >= l.window {
+		for k, w := range l.windows {
+			if now.Sub(w.start) >= l.window {
+				delete(l.windows, k)
+			}
+		}
+		l.swept = now
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		w = &limitWindow{start: now}
+		l.windows[key] = w
+	}
+	w.count++
+
+	return RateLimit{
+		Allowed:   w.count <= l.limit,
+		Limit:     l.limit,
+		Remaining: l.limit - w.count,
+		Reset:     w.start.Add(l.window).Sub(now),
+	}, nil
+}
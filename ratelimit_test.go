@@ -0,0 +1,73 @@
+package ginbinding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type reportRequest struct {
+		APIKey string `header:"X-API-Key"`
+	}
+	byAPIKey := func(c *gin.Context, req any) string {
+		return req.(reportRequest).APIKey
+	}
+
+	calls := 0
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	router := gin.New()
+	router.GET("/reports", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req reportRequest) (any, error) {
+		calls++
+		return nil, nil
+	}, WithRateLimit(NewFixedWindowLimiter(2, time.Minute), byAPIKey)))
+
+	get := func(apiKey string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/reports", nil)
+		req.Header.Set("X-API-Key", apiKey)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("a")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("X-RateLimit-Reset"))
+
+	assert.Equal(t, http.StatusOK, get("a").Code)
+
+	w = get("a")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), CodeTooManyRequests)
+
+	// Keys are limited separately
+	assert.Equal(t, http.StatusOK, get("b").Code)
+	assert.Equal(t, 3, calls)
+}
+
+func TestFixedWindowLimiter(t *testing.T) {
+	limiter := NewFixedWindowLimiter(1, 10*time.Millisecond)
+
+	limit, err := limiter.Allow(context.Background(), "k")
+	assert.NoError(t, err)
+	assert.True(t, limit.Allowed)
+
+	limit, _ = limiter.Allow(context.Background(), "k")
+	assert.False(t, limit.Allowed)
+
+	time.Sleep(15 * time.Millisecond)
+	limit, _ = limiter.Allow(context.Background(), "k")
+	assert.True(t, limit.Allowed)
+}
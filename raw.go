@@ -0,0 +1,95 @@
+package ginbinding
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	bytesTy  = reflect.TypeOf([]byte(nil))
+	stringTy = reflect.TypeOf("")
+	readerTy = reflect.TypeOf((*io.Reader)(nil)).Elem()
+)
+
+// WithRawResponse writes the []byte, string or io.Reader data returned by the
+// handler as the response body as is, with contentType, instead of passing it
+// to the response handler, e.g. for proxies and webhook echoes whose
+// responses must not be wrapped. A Content-Type set by the handler, such as
+// through WithHeaders, takes precedence. Readers implementing io.Closer are
+// closed once written. The null policy, sparse fieldsets and the response
+// budget do not apply to raw data, and handlers declaring other data types
+// are rejected when built; data of other types returned as any is served by
+// the response handler.
+//
+//	r.POST("/webhooks/echo", builder.MustFormBindingGinHandlerFunc(echo,
+//		ginbinding.WithRawResponse("application/json")))
+func WithRawResponse(contentType string) Option {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.rawContentType = contentType
+	}
+}
+
+// checkRawResponseType verifies that the handler data of type ty, nil for
+// handlers returning only an error, can be written by WithRawResponse
+func checkRawResponseType(ty reflect.Type) error {
+	switch {
+	case ty == nil, ty == bytesTy, ty == stringTy, ty.Implements(readerTy):
+		return nil
+	case ty.Kind() == reflect.Interface && ty.NumMethod() == 0:
+		return nil
+	}
+	return fmt.Errorf("WithRawResponse needs a handler returning []byte, string or io.Reader, got %s", ty)
+}
+
+// isRawData reports whether data is written as is by WithRawResponse
+func isRawData(data any) bool {
+	switch data.(type) {
+	case []byte, string, io.Reader:
+		return true
+	}
+	return false
+}
+
+// writeRaw writes the raw data as the body of a 200 response. It returns the
+// number of bytes written and the error that ended the body, if any, in
+// which case the response is left unfinished unless nothing was written.
+func (builder *BasicFormBindingGinHandlerBuilder) writeRaw(ctx *gin.Context, data any) (int, error) {
+	header := ctx.Writer.Header()
+	setType := header.Get("Content-Type") == ""
+	if setType {
+		header.Set("Content-Type", builder.rawContentType)
+	}
+	ctx.Status(http.StatusOK)
+
+	var n int
+	var err error
+	switch v := data.(type) {
+	case []byte:
+		n, err = ctx.Writer.Write(v)
+	case string:
+		n, err = ctx.Writer.WriteString(v)
+	case io.Reader:
+		if closer, ok := v.(io.Closer); ok {
+			defer closer.Close()
+		}
+		var written int64
+		written, err = io.Copy(ctx.Writer, v)
+		n = int(written)
+	}
+
+	if err != nil && n == 0 && setType {
+		// The error response chooses its own content type
+		header.Del("Content-Type")
+	}
+	if err == nil {
+		ctx.Writer.WriteHeaderNow()
+	}
+	return n, err
+}
@@ -0,0 +1,158 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// closeRecorder records whether it was closed
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestWithRawResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type Event struct {
+		ID int `json:"id"`
+	}
+
+	var hookResp any
+	var hookErr error
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithNullPolicy(NullAsEmpty)).
+		OnSuccess(func(c *gin.Context, req any, resp any) { hookResp = resp }).
+		OnError(func(c *gin.Context, req any, err error) { hookErr = err })
+	closer := &closeRecorder{Reader: strings.NewReader("from reader")}
+
+	router := gin.New()
+	router.POST("/echo", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) ([]byte, error) {
+		return io.ReadAll(c.Request.Body)
+	}, WithRawResponse("application/json")))
+	router.GET("/text", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (string, error) {
+		return "plain text", nil
+	}, WithRawResponse("text/plain; charset=utf-8")))
+	router.GET("/reader", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (io.Reader, error) {
+		return closer, nil
+	}, WithRawResponse("")))
+	router.GET("/proxy", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return WithHeaders(bytes.NewBufferString("<svg/>"), map[string]string{"Content-Type": "image/svg+xml"}), nil
+	}, WithRawResponse("application/octet-stream")))
+	router.GET("/struct", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return Event{ID: 1}, nil
+	}, WithRawResponse("application/octet-stream")))
+	router.GET("/fail-first", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (io.Reader, error) {
+		return &failingReader{r: strings.NewReader(""), err: errors.New("upstream gone")}, nil
+	}, WithRawResponse("text/csv")))
+	router.GET("/fail-later", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (io.Reader, error) {
+		return &failingReader{r: strings.NewReader("a,b\n"), err: errors.New("upstream gone")}, nil
+	}, WithRawResponse("text/csv")))
+	router.GET("/wrapped", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (string, error) {
+		return "wrapped", nil
+	}))
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("bytes", func(t *testing.T) {
+		w := serve("POST", "/echo", `{"event":"ping","data":null}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Equal(t, `{"event":"ping","data":null}`, w.Body.String())
+		assert.Equal(t, []byte(`{"event":"ping","data":null}`), hookResp)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		w := serve("GET", "/text", "")
+
+		assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, "plain text", w.Body.String())
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		w := serve("GET", "/reader", "")
+
+		assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+		assert.Equal(t, "from reader", w.Body.String())
+		assert.True(t, closer.closed)
+	})
+
+	t.Run("content type set by the handler", func(t *testing.T) {
+		w := serve("GET", "/proxy", "")
+
+		assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+		assert.Equal(t, "<svg/>", w.Body.String())
+	})
+
+	t.Run("other data", func(t *testing.T) {
+		w := serve("GET", "/struct", "")
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, map[string]interface{}{"id": float64(1)}, response["data"])
+	})
+
+	t.Run("reader failing before the body", func(t *testing.T) {
+		w := serve("GET", "/fail-first", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "upstream gone")
+	})
+
+	t.Run("reader failing during the body", func(t *testing.T) {
+		hookErr = nil
+		w := serve("GET", "/fail-later", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "a,b\n", w.Body.String())
+		assert.EqualError(t, hookErr, "upstream gone")
+	})
+
+	t.Run("without the option", func(t *testing.T) {
+		w := serve("GET", "/wrapped", "")
+
+		assert.JSONEq(t, `{"status":"success","data":"wrapped"}`, w.Body.String())
+	})
+}
+
+func TestWithRawResponseHandlerTypes(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	for _, handler := range []any{
+		func(*gin.Context) error { return nil },
+		func(*gin.Context) ([]byte, error) { return nil, nil },
+		func(*gin.Context) (string, error) { return "", nil },
+		func(*gin.Context) (io.ReadCloser, error) { return nil, nil },
+		func(*gin.Context) (*bytes.Buffer, error) { return nil, nil },
+		func(*gin.Context) (any, error) { return nil, nil },
+	} {
+		_, err := builder.FormBindingGinHandlerFunc(handler, WithRawResponse("text/plain"))
+		assert.NoError(t, err)
+	}
+
+	_, err := builder.FormBindingGinHandlerFunc(func(*gin.Context) (map[string]string, error) {
+		return nil, nil
+	}, WithRawResponse("text/plain"))
+	assert.EqualError(t, err, "WithRawResponse needs a handler returning []byte, string or io.Reader, got map[string]string")
+}
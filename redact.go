@@ -0,0 +1,88 @@
+package ginbinding
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// redactedValue replaces bound values in redacted error messages
+const redactedValue = `"[redacted]"`
+
+// quotedString matches the Go-quoted strings that binding errors use to show
+// the values they failed on, such as `invalid duration "abc"`
+var quotedString = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+
+// WithRedactedErrors removes the values bound from the request from the
+// messages of binding and validation errors in error responses, so that
+// tokens and other sensitive input never leak into them. Field names and
+// parameter keys are kept, handler errors are left unchanged, and errmsg tags
+// still take precedence.
+func WithRedactedErrors() Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.redactErrors = true
+	}
+}
+
+// redactedError replaces the client message of err with its redacted message
+type redactedError struct {
+	err     error
+	message string
+}
+
+func (e *redactedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// PublicMessage implements PublicMessager
+func (e *redactedError) PublicMessage() string {
+	return e.message
+}
+
+// redactError attaches a client message to the binding or validation error
+// err of a request of type ty in which every quoted string except the names
+// of its fields and keys is redacted
+func redactError(ty reflect.Type, err error) error {
+	if _, ok := err.(*messageError); ok {
+		return err
+	}
+
+	keys := requestKeys(ty)
+	message := quotedString.ReplaceAllStringFunc(err.Error(), func(quoted string) string {
+		if s, unquoteErr := strconv.Unquote(quoted); unquoteErr == nil && keys[s] {
+			return quoted
+		}
+		return redactedValue
+	})
+	return &redactedError{err: err, message: message}
+}
+
+// requestKeys returns the field names and tag keys of the request type ty,
+// which are safe to show in error messages
+func requestKeys(ty reflect.Type) map[string]bool {
+	keys := map[string]bool{}
+	for ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	if ty.Kind() != reflect.Struct {
+		return keys
+	}
+
+	_ = walkTypeFields(ty, func(sf reflect.StructField) error {
+		keys[sf.Name] = true
+		keys[jsonFieldName(sf)] = true
+		for _, tag := range []string{"path", "uri", "form", "query", "header", "kind"} {
+			if key, ok := sf.Tag.Lookup(tag); ok {
+				key, _, _ = strings.Cut(key, ",")
+				keys[key] = true
+			}
+		}
+		return nil
+	})
+	return keys
+}
@@ -0,0 +1,72 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRedactedErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		Token   string        `path:"token"`
+		Timeout time.Duration `path:"timeout"`
+		Retry   time.Duration `form:"retry" unit:"duration" errmsg:"retry must be a duration"`
+	}
+	handler := func(c *gin.Context, req request) error {
+		return nil
+	}
+
+	tests := []struct {
+		name            string
+		opts            []Option
+		target          string
+		expectedMessage string
+	}{
+		{
+			name:            "not redacted",
+			target:          "/secret-token-123/abc",
+			expectedMessage: `failed to parse path parameter "timeout": invalid duration "abc": time: invalid duration "abc"`,
+		},
+		{
+			name:            "redacted",
+			opts:            []Option{WithRedactedErrors()},
+			target:          "/secret-token-123/secret-token-123",
+			expectedMessage: `failed to parse path parameter "timeout": invalid duration "[redacted]": time: invalid duration "[redacted]"`,
+		},
+		{
+			name:            "errmsg wins",
+			opts:            []Option{WithRedactedErrors()},
+			target:          "/t/1s?retry=abc",
+			expectedMessage: "retry must be a duration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, tt.opts...)
+			ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+			assert.NoError(t, err)
+
+			router := gin.New()
+			router.GET("/:token/:timeout", ginHandler)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.target, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response map[string]interface{}
+			err = json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedMessage, response["message"])
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package ginbinding
+
+import (
+	"fmt"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// checkRequireTags verifies every "require" tag of ty at handler build time
+func checkRequireTags(ty reflect.Type) error {
+	return walkTypeFields(ty, func(sf reflect.StructField) error {
+		tag, ok := sf.Tag.Lookup("require")
+		if !ok {
+			return nil
+		}
+		if _, err := strconv.ParseBool(tag); err != nil {
+			return fmt.Errorf("field %s: invalid require tag %q", sf.Name, tag)
+		}
+		if _, _, ok := requiredInputSource(sf); !ok {
+			return fmt.Errorf("field %s: require tag needs a path, form or header tag", sf.Name)
+		}
+		return nil
+	})
+}
+
+// isRequiredInput reports whether sf must be present in its request source.
+// Fields are required when tagged require:"true", or when tagged
+// binding:"required" without a default value, the builder has no validator to
+// enforce it and validation is not partial.
+func (builder *BasicFormBindingGinHandlerBuilder) isRequiredInput(sf reflect.StructField) bool {
+	if tag, ok := sf.Tag.Lookup("require"); ok {
+		required, _ := strconv.ParseBool(tag)
+		return required
+	}
+
+	// Defaults are applied before validation, so they satisfy binding:"required"
+	if _, ok := sf.Tag.Lookup(builder.defaultTag); ok {
+		return false
+	}
+
+	if builder.validator == nil && !builder.partialValidation {
+		for _, rule := range strings.Split(sf.Tag.Get("binding"), ",") {
+			if strings.TrimSpace(rule) == "required" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// checkRequiredInputs fails when a required path, query or header input is
+// missing from the request. It runs before any binding so that the error names
+// the exact field and source instead of a zero value being bound silently.
+func (builder *BasicFormBindingGinHandlerBuilder) checkRequiredInputs(ctx *gin.Context, ty reflect.Type) error {
+	if !typeInfoOf(ty).required {
+		return nil
+	}
+
+	return walkTypeFields(ty, func(sf reflect.StructField) error {
+		if !builder.isRequiredInput(sf) {
+			return nil
+		}
+
+		source, key, ok := requiredInputSource(sf)
+		if !ok || hasInput(ctx, source, key) {
+			return nil
+		}
+
+		return &fieldError{path: sf.Name, err: fmt.Errorf("field %s: missing required %s %q", sf.Name, source, key), missing: true}
+	})
+}
+
+// requiredInputSource returns the request source and key a field is bound from
+func requiredInputSource(sf reflect.StructField) (source string, key string, ok bool) {
+	if key, ok := pathTag(sf); ok {
+		return "path parameter", key, true
+	}
+	if tag, ok := queryTag(sf); ok {
+		key, _, _ := strings.Cut(tag, ",")
+		if key != "" && key != "-" {
+			return "query parameter", key, true
+		}
+	}
+	if key, ok := sf.Tag.Lookup("header"); ok {
+		key, _, _ = strings.Cut(key, ",")
+		return "header", canonicalHeaderName(key), true
+	}
+	return "", "", false
+}
+
+func hasInput(ctx *gin.Context, source string, key string) bool {
+	switch source {
+	case "path parameter":
+		return ctx.Param(key) != ""
+	case "query parameter":
+		if _, ok := ctx.GetQuery(key); ok {
+			return true
+		}
+		// Form fields may also be posted in a form body
+		switch ctx.ContentType() {
+		case binding.MIMEPOSTForm, binding.MIMEMultipartPOSTForm:
+			_, ok := ctx.GetPostForm(key)
+			return ok
+		}
+		return false
+	default:
+		if prefix, isWildcard := strings.CutSuffix(key, "*"); isWildcard {
+			for name := range ctx.Request.Header {
+				if strings.HasPrefix(textproto.CanonicalMIMEHeaderKey(name), prefix) {
+					return true
+				}
+			}
+			return false
+		}
+		values := headerValuesFold(ctx.Request.Header, key)
+		return len(values) > 0 && values[0] != ""
+	}
+}
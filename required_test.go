@@ -0,0 +1,162 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Version string `path:"version" require:"true"`
+		Page    int    `form:"page" require:"true"`
+		Token   string `header:"X-Api-Key" require:"true"`
+		Search  string `form:"search" require:"false"`
+	}) (interface{}, error) {
+		return gin.H{"page": req.Page}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/api/*version", ginHandler)
+
+	tests := []struct {
+		name           string
+		url            string
+		apiKey         string
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:           "all inputs present",
+			url:            "/api/v1?page=0",
+			apiKey:         "secret",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing query parameter",
+			url:            "/api/v1",
+			apiKey:         "secret",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Page: missing required query parameter "page"`,
+		},
+		{
+			name:           "missing header",
+			url:            "/api/v1?page=1",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Token: missing required header "X-Api-Key"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.apiKey != "" {
+				req.Header.Set("X-Api-Key", tt.apiKey)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, response["message"])
+			}
+		})
+	}
+}
+
+func TestRequireTagMissingPathParameter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		ID string `path:"id" require:"true"`
+	}) (interface{}, error) {
+		return gin.H{"id": req.ID}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	// The route does not declare :id, so the parameter is always missing
+	router := gin.New()
+	router.GET("/users", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `field ID: missing required path parameter \"id\"`)
+}
+
+func TestBindingRequiredEnforcedWithoutValidator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Tenant string `header:"X-Tenant" binding:"required"`
+		Name   string `json:"name"`
+	}) (interface{}, error) {
+		return gin.H{"tenant": req.Tenant}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/test", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/test", strings.NewReader(`{"name":"john"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `field Tenant: missing required header \"X-Tenant\"`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/test", strings.NewReader(`{"name":"john"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant", "acme")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRequireTagInvalidAtBuildTime(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Name string `json:"name" require:"true"`
+	}) error {
+		return nil
+	})
+	assert.EqualError(t, err, "field Name: require tag needs a path, form or header tag")
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Page int `form:"page" require:"always"`
+	}) error {
+		return nil
+	})
+	assert.EqualError(t, err, `field Page: invalid require tag "always"`)
+}
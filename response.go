@@ -1,13 +1,52 @@
 package ginbinding
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"regexp"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+// ErrorMode selects how much of an error DefaultResponseHandler reveals in
+// the error response
+type ErrorMode int
+
+const (
+	// ErrorModeDefault returns the error message, or the message chosen by
+	// errors implementing PublicMessager
+	ErrorModeDefault ErrorMode = iota
+	// ErrorModeRelease returns the generic status text of the status code
+	// instead of error messages, except those chosen through PublicMessager.
+	// The details are logged with an error_id that is also returned.
+	ErrorModeRelease
+	// ErrorModeDebug returns the error message along with every message in
+	// the error chain
+	ErrorModeDebug
 )
 
 // DefaultResponseHandler provides a standard JSON response handler
-type DefaultResponseHandler struct{}
+type DefaultResponseHandler struct {
+	// Mode selects the verbosity of error responses
+	Mode ErrorMode
+	// ErrorLog receives the details of errors hidden in ErrorModeRelease.
+	// The default is gin.DefaultErrorWriter.
+	ErrorLog io.Writer
+	// JSONPCallback is the query parameter naming the JavaScript function GET
+	// responses are wrapped in, e.g. "callback", for legacy clients loading
+	// the API with script tags. Requests naming an invalid function get plain
+	// JSON. JSONP is disabled when it is empty.
+	JSONPCallback string
+	// KeyCase rewrites the keys of every JSON object of responses, e.g. to
+	// camelCase for frontends when structs are serialized with snake_case
+	// tags. Keys are kept as serialized with KeyCaseDefault.
+	KeyCase KeyCase
+}
 
 // NewDefaultResponseHandler creates a new default response handler
 func NewDefaultResponseHandler() *DefaultResponseHandler {
@@ -17,40 +56,178 @@ func NewDefaultResponseHandler() *DefaultResponseHandler {
 // HandleSuccess sends a JSON response with the provided data
 func (h *DefaultResponseHandler) HandleSuccess(ctx *gin.Context, data interface{}) {
 	if data == nil {
-		ctx.JSON(http.StatusOK, gin.H{"status": "success"})
+		h.render(ctx, http.StatusOK, gin.H{"status": "success"})
 	} else {
-		ctx.JSON(http.StatusOK, gin.H{"status": "success", "data": data})
+		h.render(ctx, http.StatusOK, gin.H{"status": "success", "data": data})
 	}
 }
 
 // HandleError sends a JSON error response with appropriate HTTP status code
 func (h *DefaultResponseHandler) HandleError(ctx *gin.Context, err error) {
-	statusCode := http.StatusInternalServerError
-	message := "Internal server error"
+	statusCode, message := errorStatusAndMessage(err)
 
-	// Check if it's a binding error
-	if bindingErr, ok := err.(*BindingError); ok {
-		statusCode = http.StatusBadRequest
-		message = bindingErr.Error()
-	} else {
-		// For other errors, try to determine appropriate status code
-		switch err.Error() {
-		case "record not found":
-			statusCode = http.StatusNotFound
-			message = err.Error()
-		case "unauthorized":
-			statusCode = http.StatusUnauthorized
-			message = err.Error()
-		case "forbidden":
-			statusCode = http.StatusForbidden
-			message = err.Error()
-		default:
-			message = err.Error()
-		}
+	if abortClientClosedRequest(ctx, statusCode) {
+		return
 	}
 
-	ctx.JSON(statusCode, gin.H{
+	body := gin.H{
 		"status":  "error",
+		"code":    ErrorCode(err),
 		"message": message,
-	})
+	}
+
+	// Binding errors tell the client which input to fix
+	var bindingErr *BindingError
+	if errors.As(err, &bindingErr) {
+		for key, v := range map[string]string{
+			"source": bindingErr.Source,
+			"field":  bindingErr.Field,
+			"value":  bindingErr.Value,
+		} {
+			if v != "" {
+				body[key] = v
+			}
+		}
+	}
+
+	// Schema errors list every value of the wrong type
+	var schemaErr *SchemaError
+	if errors.As(err, &schemaErr) {
+		violations := make([]gin.H, 0, len(schemaErr.Violations))
+		for _, v := range schemaErr.Violations {
+			violations = append(violations, gin.H{"field": v.Field, "expected": v.Expected, "actual": v.Actual})
+		}
+		body["violations"] = violations
+	}
+
+	switch h.Mode {
+	case ErrorModeRelease:
+		if !hasPublicMessage(err) {
+			body["message"] = http.StatusText(statusCode)
+		}
+		// The value is as sensitive as the message it was taken from
+		delete(body, "value")
+		errorID := newErrorID()
+		body["error_id"] = errorID
+		h.logError(ctx, errorID, err)
+	case ErrorModeDebug:
+		body["errors"] = errorChain(err)
+	}
+
+	h.render(ctx, statusCode, body)
+}
+
+// jsonpCallbackPattern matches JavaScript function names, including dotted
+// property paths such as "jQuery.cb_1"
+var jsonpCallbackPattern = regexp.MustCompile(`^[A-Za-z_$][\w$]*(\.[A-Za-z_$][\w$]*)*$`)
+
+// render writes body as JSON, or as JSONP for GET requests naming a callback,
+// with its keys in the configured case
+func (h *DefaultResponseHandler) render(ctx *gin.Context, statusCode int, body any) {
+	if h.KeyCase != KeyCaseDefault {
+		transformed, err := h.KeyCase.transformKeys(body)
+		if err != nil {
+			_ = ctx.Error(err)
+			ctx.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		body = transformed
+	}
+
+	if h.JSONPCallback != "" && ctx.Request.Method == http.MethodGet {
+		if callback := ctx.Query(h.JSONPCallback); len(callback) <= 128 && jsonpCallbackPattern.MatchString(callback) {
+			ctx.Render(statusCode, render.JsonpJSON{Callback: callback, Data: body})
+			return
+		}
+	}
+	ctx.JSON(statusCode, body)
+}
+
+// logError writes the details of err to the error log of h
+func (h *DefaultResponseHandler) logError(ctx *gin.Context, errorID string, err error) {
+	w := h.ErrorLog
+	if w == nil {
+		w = gin.DefaultErrorWriter
+	}
+	fmt.Fprintf(w, "[GIN-binding] error %s: %s %s: %v\n", errorID, ctx.Request.Method, ctx.Request.URL.Path, err)
+}
+
+// hasPublicMessage reports whether an error in err's chain chooses its client
+// message through PublicMessager
+func hasPublicMessage(err error) bool {
+	var publicMessager PublicMessager
+	return errors.As(err, &publicMessager)
+}
+
+// newErrorID returns a random identifier correlating an error response with
+// its log entry
+func newErrorID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// errorChain returns the messages of err and the errors it wraps, depth
+// first. Wrappers that do not add to the message of the error they wrap are
+// skipped.
+func errorChain(err error) []string {
+	var chain []string
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			inner := e.Unwrap()
+			if inner == nil || inner.Error() != err.Error() {
+				chain = append(chain, err.Error())
+			}
+			if inner != nil {
+				walk(inner)
+			}
+		case interface{ Unwrap() []error }:
+			chain = append(chain, err.Error())
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		default:
+			chain = append(chain, err.Error())
+		}
+	}
+	walk(err)
+	return chain
+}
+
+// errorStatusAndMessage determines the HTTP status code and client message for err.
+// Errors anywhere in the chain implementing StatusCoder or PublicMessager take
+// precedence over the built-in mappings.
+func errorStatusAndMessage(err error) (int, string) {
+	statusCode := http.StatusInternalServerError
+	message := err.Error()
+
+	// Check if the error chooses its own status code, then if it's a binding
+	// error, then if it wraps one of the sentinel errors
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		statusCode = coder.StatusCode()
+	} else if _, ok := err.(*BindingError); ok {
+		statusCode = http.StatusBadRequest
+	} else if sentinelCode, ok := sentinelStatusCode(err); ok {
+		statusCode = sentinelCode
+	}
+
+	var publicMessager PublicMessager
+	if errors.As(err, &publicMessager) {
+		message = publicMessager.PublicMessage()
+	}
+
+	return statusCode, message
+}
+
+// abortClientClosedRequest aborts without writing a body when the client has
+// canceled the request, since nobody is left to read it
+func abortClientClosedRequest(ctx *gin.Context, statusCode int) bool {
+	if statusCode != StatusClientClosedRequest {
+		return false
+	}
+	ctx.AbortWithStatus(statusCode)
+	return true
 }
@@ -0,0 +1,274 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// quotaError is a domain error that controls its own HTTP representation
+type quotaError struct {
+	used, limit int
+}
+
+func (e *quotaError) Error() string {
+	return fmt.Sprintf("quota exceeded: %d of %d used by account 42", e.used, e.limit)
+}
+
+func (e *quotaError) StatusCode() int {
+	return http.StatusPaymentRequired
+}
+
+func (e *quotaError) PublicMessage() string {
+	return "quota exceeded"
+}
+
+// conflictError only chooses the status code
+type conflictError struct{}
+
+func (conflictError) Error() string   { return "version conflict" }
+func (conflictError) StatusCode() int { return http.StatusConflict }
+
+// maskedError only chooses the public message
+type maskedError struct{}
+
+func (maskedError) Error() string         { return "dial tcp 10.0.0.1:5432: connection refused" }
+func (maskedError) PublicMessage() string { return "service temporarily unavailable" }
+
+func TestErrorInterfaces(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		err             error
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:            "status code and public message",
+			err:             &quotaError{used: 10, limit: 10},
+			expectedStatus:  http.StatusPaymentRequired,
+			expectedMessage: "quota exceeded",
+		},
+		{
+			name:            "wrapped status code",
+			err:             fmt.Errorf("update order: %w", conflictError{}),
+			expectedStatus:  http.StatusConflict,
+			expectedMessage: "update order: version conflict",
+		},
+		{
+			name:            "public message only",
+			err:             maskedError{},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "service temporarily unavailable",
+		},
+		{
+			name:            "plain error",
+			err:             errors.New("boom"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(c *gin.Context) (interface{}, error) {
+				return nil, tt.err
+			}
+
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+			ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+			assert.NoError(t, err)
+
+			router := gin.New()
+			router.GET("/test", ginHandler)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/test", nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err = json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			assert.Equal(t, "error", response["status"])
+			assert.Equal(t, tt.expectedMessage, response["message"])
+		})
+	}
+}
+
+func TestDefaultResponseHandler_ErrorModes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	errDB := errors.New("pq: connection refused to 10.0.0.5")
+
+	var log bytes.Buffer
+	tests := []struct {
+		name     string
+		mode     ErrorMode
+		target   string
+		expected map[string]interface{}
+	}{
+		{
+			name:   "default",
+			mode:   ErrorModeDefault,
+			target: "/items/1",
+			expected: map[string]interface{}{
+				"status":  "error",
+				"code":    "HANDLER_ERROR",
+				"message": "loading item: pq: connection refused to 10.0.0.5",
+			},
+		},
+		{
+			name:   "debug handler error",
+			mode:   ErrorModeDebug,
+			target: "/items/1",
+			expected: map[string]interface{}{
+				"status":  "error",
+				"code":    "HANDLER_ERROR",
+				"message": "loading item: pq: connection refused to 10.0.0.5",
+				"errors": []interface{}{
+					"loading item: pq: connection refused to 10.0.0.5",
+					"pq: connection refused to 10.0.0.5",
+				},
+			},
+		},
+		{
+			name:   "debug binding error",
+			mode:   ErrorModeDebug,
+			target: "/items/abc",
+			expected: map[string]interface{}{
+				"status":  "error",
+				"code":    "BINDING_INVALID_TYPE",
+				"message": `failed to parse path parameter "id": strconv.ParseInt: parsing "abc": invalid syntax`,
+				"errors": []interface{}{
+					`failed to parse path parameter "id": strconv.ParseInt: parsing "abc": invalid syntax`,
+					`strconv.ParseInt: parsing "abc": invalid syntax`,
+					"invalid syntax",
+				},
+				"source": "path",
+				"field":  "id",
+				"value":  "abc",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &DefaultResponseHandler{Mode: tt.mode, ErrorLog: &log}
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, handler)
+			ginHandler, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+				ID int `path:"id"`
+			}) error {
+				return fmt.Errorf("loading item: %w", errDB)
+			})
+			assert.NoError(t, err)
+
+			router := gin.New()
+			router.GET("/items/:id", ginHandler)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.target, nil)
+			router.ServeHTTP(w, req)
+
+			var response map[string]interface{}
+			err = json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+}
+
+func TestDefaultResponseHandler_ReleaseMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var log bytes.Buffer
+	handler := &DefaultResponseHandler{Mode: ErrorModeRelease, ErrorLog: &log}
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, handler)
+
+	router := gin.New()
+	router.GET("/internal", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) error {
+		return errors.New("pq: connection refused to 10.0.0.5")
+	}))
+	router.GET("/public", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) error {
+		return maskedError{}
+	}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/internal", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Internal Server Error", response["message"])
+	assert.Len(t, response["error_id"], 16)
+	assert.Contains(t, log.String(), response["error_id"].(string))
+	assert.Contains(t, log.String(), "GET /internal: pq: connection refused to 10.0.0.5")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/public", nil)
+	router.ServeHTTP(w, req)
+
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "service temporarily unavailable", response["message"])
+}
+
+func TestDefaultResponseHandler_JSONP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, &DefaultResponseHandler{JSONPCallback: "callback"})
+
+	router := gin.New()
+	router.GET("/items/:id", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		ID int `uri:"id"`
+	}) (any, error) {
+		if req.ID == 0 {
+			return nil, ErrNotFound
+		}
+		return gin.H{"id": req.ID}, nil
+	}))
+	router.POST("/items/:id", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (any, error) {
+		return gin.H{"id": 1}, nil
+	}))
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedType   string
+		expectedBody   string
+	}{
+		{name: "success", method: http.MethodGet, path: "/items/1?callback=app.onItem", expectedStatus: http.StatusOK, expectedType: "application/javascript; charset=utf-8", expectedBody: `app.onItem({"data":{"id":1},"status":"success"});`},
+		{name: "error", method: http.MethodGet, path: "/items/0?callback=onItem", expectedStatus: http.StatusNotFound, expectedType: "application/javascript; charset=utf-8", expectedBody: `onItem({"code":"NOT_FOUND","message":"record not found","status":"error"});`},
+		{name: "no callback", method: http.MethodGet, path: "/items/1", expectedStatus: http.StatusOK, expectedType: "application/json; charset=utf-8", expectedBody: `{"data":{"id":1},"status":"success"}`},
+		{name: "invalid callback", method: http.MethodGet, path: "/items/1?callback=alert(1)", expectedStatus: http.StatusOK, expectedType: "application/json; charset=utf-8", expectedBody: `{"data":{"id":1},"status":"success"}`},
+		{name: "not GET", method: http.MethodPost, path: "/items/1?callback=onItem", expectedStatus: http.StatusOK, expectedType: "application/json; charset=utf-8", expectedBody: `{"data":{"id":1},"status":"success"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package ginbinding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleResolver returns the roles of the caller of a request, e.g. from the
+// claims stored in the context by an authentication middleware
+type RoleResolver func(ctx *gin.Context) []string
+
+// RolePolicy selects how requests supplying fields guarded by requires_role
+// tags are handled when the caller lacks the role
+type RolePolicy int
+
+const (
+	// RoleReject rejects the request with an error wrapping ErrForbidden
+	RoleReject RolePolicy = iota
+	// RoleDrop resets the fields to their zero values, as if they were not
+	// sent, so that defaults apply to them
+	RoleDrop
+)
+
+// WithRoleResolver guards the request fields tagged with requires_role, such
+// as `json:"is_admin" requires_role:"admin"`, against mass assignment: fields
+// supplied by callers that resolver returns none of the listed roles for are
+// rejected or dropped according to policy. Several roles can be listed
+// separated by commas, any of which grants access.
+//
+//	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil,
+//		ginbinding.WithRoleResolver(func(c *gin.Context) []string {
+//			return c.GetStringSlice("roles")
+//		}, ginbinding.RoleReject))
+//
+// Handlers of request types with requires_role tags cannot be built without
+// a resolver.
+func WithRoleResolver(resolver RoleResolver, policy RolePolicy) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.roleResolver = resolver
+		builder.rolePolicy = policy
+	}
+}
+
+// roleFields returns the fields of the struct type ty guarded by
+// requires_role tags, with the tags as values
+func roleFields(ty reflect.Type) []taggedField {
+	return taggedFields(ty, false, func(sf reflect.StructField) (string, bool) {
+		roles, ok := sf.Tag.Lookup("requires_role")
+		return roles, ok && roles != ""
+	})
+}
+
+// checkRoleFields verifies at handler build time that the requires_role tags
+// of the request type ty can be checked
+func (builder *BasicFormBindingGinHandlerBuilder) checkRoleFields(ty reflect.Type) error {
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	if builder.roleResolver == nil && typeInfoOf(ty).roleFields != nil {
+		return errors.New("requires_role tags need a role resolver, see WithRoleResolver")
+	}
+	return nil
+}
+
+// checkRoles rejects or drops the fields of the bound request form of the
+// struct type ty that were sent by a caller lacking their roles. The fields
+// of bodies other than JSON count as sent when they are bound to a non-zero
+// value.
+func (builder *BasicFormBindingGinHandlerBuilder) checkRoles(ctx *gin.Context, ty reflect.Type, form reflect.Value, sent *requestPresence) error {
+	var roles []string
+	resolved := false
+	for _, f := range typeInfoOf(ty).roleFields {
+		if !sent.has(ty, f.path) && !(sent.opaqueBody && !isZeroField(reflect.Indirect(form), f.path)) {
+			continue
+		}
+
+		if !resolved {
+			roles, resolved = builder.roleResolver(ctx), true
+		}
+		if slices.ContainsFunc(strings.Split(f.value, ","), func(role string) bool {
+			return slices.Contains(roles, strings.TrimSpace(role))
+		}) {
+			continue
+		}
+
+		if builder.rolePolicy == RoleReject {
+			return fmt.Errorf("field %q requires role %s: %w", f.name, f.value, ErrForbidden)
+		}
+		dropField(reflect.Indirect(form), f.path)
+	}
+	return nil
+}
+
+// dropField resets the field at the Go field path of the struct val to its
+// zero value, unless a pointer on the way is nil
+func dropField(val reflect.Value, path []string) {
+	if field, ok := fieldValueByPath(val, path); ok {
+		field.Set(reflect.Zero(field.Type()))
+	}
+}
+
+// isZeroField reports whether the field at the Go field path of the struct
+// val is zero, or unreachable through a nil pointer
+func isZeroField(val reflect.Value, path []string) bool {
+	field, ok := fieldValueByPath(val, path)
+	return !ok || field.IsZero()
+}
+
+// fieldValueByPath returns the field at the Go field path of the struct val,
+// and false when a pointer on the way is nil
+func fieldValueByPath(val reflect.Value, path []string) (reflect.Value, bool) {
+	for _, name := range path {
+		for val.Kind() == reflect.Pointer {
+			if val.IsNil() {
+				return reflect.Value{}, false
+			}
+			val = val.Elem()
+		}
+		val = val.FieldByName(name)
+	}
+	return val, true
+}
@@ -0,0 +1,205 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type roleAccount struct {
+	Plan string `json:"plan" requires_role:"billing, admin"`
+}
+
+type roleRequest struct {
+	Name    string       `json:"name"`
+	IsAdmin bool         `json:"is_admin" requires_role:"admin"`
+	Role    string       `json:"role" requires_role:"admin" default:"member"`
+	Account *roleAccount `json:"account"`
+	Tenant  string       `header:"X-Tenant" json:"-" requires_role:"admin"`
+}
+
+func TestWithRoleResolver(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	resolver := func(c *gin.Context) []string {
+		if roles := c.GetHeader("X-Roles"); roles != "" {
+			return strings.Split(roles, ",")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name           string
+		policy         RolePolicy
+		roles          string
+		tenant         string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "unguarded fields",
+			body:           `{"name":"Ann"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann","is_admin":false,"role":"member","account":null}`,
+		},
+		{
+			name:           "role granted",
+			roles:          "admin",
+			tenant:         "acme",
+			body:           `{"name":"Ann","is_admin":true,"role":"owner","account":{"plan":"pro"}}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann","is_admin":true,"role":"owner","account":{"plan":"pro"}}`,
+		},
+		{
+			name:           "any listed role",
+			roles:          "billing",
+			body:           `{"account":{"plan":"pro"}}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"plan":"pro"`,
+		},
+		{
+			name:           "rejected",
+			roles:          "billing",
+			body:           `{"name":"Ann","is_admin":false}`,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `field \"is_admin\" requires role admin: forbidden`,
+		},
+		{
+			name:           "rejected header",
+			tenant:         "acme",
+			body:           `{}`,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `field \"X-Tenant\" requires role admin`,
+		},
+		{
+			name:           "dropped",
+			policy:         RoleDrop,
+			roles:          "user",
+			tenant:         "acme",
+			body:           `{"name":"Ann","is_admin":true,"role":"owner","account":{"plan":"pro"}}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann","is_admin":false,"role":"member","account":{"plan":""}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithRoleResolver(resolver, tt.policy))
+			router := gin.New()
+			router.POST("/users", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, req roleRequest) (any, error) {
+				c.Header("X-Bound-Tenant", req.Tenant)
+				return req, nil
+			}))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.roles != "" {
+				req.Header.Set("X-Roles", tt.roles)
+			}
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant", tt.tenant)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			if tt.policy == RoleDrop {
+				assert.Empty(t, w.Header().Get("X-Bound-Tenant"))
+			}
+		})
+	}
+}
+
+func TestRoleFieldsNeedResolver(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	_, err := builder.FormBindingGinHandlerFunc(func(req *roleRequest) error {
+		return nil
+	})
+	assert.EqualError(t, err, "requires_role tags need a role resolver, see WithRoleResolver")
+}
+
+func TestWithRoleResolverOtherBodies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	resolver := func(c *gin.Context) []string {
+		if roles := c.GetHeader("X-Roles"); roles != "" {
+			return strings.Split(roles, ",")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name           string
+		policy         RolePolicy
+		roles          string
+		contentType    string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "form unguarded fields",
+			contentType:    "application/x-www-form-urlencoded",
+			body:           "Name=Ann",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann","is_admin":false,"role":"member","account":null}`,
+		},
+		{
+			name:           "form rejected",
+			contentType:    "application/x-www-form-urlencoded",
+			body:           "Name=Ann&IsAdmin=true",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `field \"is_admin\" requires role admin: forbidden`,
+		},
+		{
+			name:           "form role granted",
+			roles:          "admin",
+			contentType:    "application/x-www-form-urlencoded",
+			body:           "Name=Ann&IsAdmin=true",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann","is_admin":true,"role":"member","account":null}`,
+		},
+		{
+			name:           "xml rejected",
+			contentType:    "application/xml",
+			body:           `<user><Name>Ann</Name><IsAdmin>true</IsAdmin></user>`,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `field \"is_admin\" requires role admin: forbidden`,
+		},
+		{
+			name:           "xml dropped",
+			policy:         RoleDrop,
+			contentType:    "application/xml",
+			body:           `<user><Name>Ann</Name><IsAdmin>true</IsAdmin><Role>owner</Role><Account><Plan>pro</Plan></Account></user>`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann","is_admin":false,"role":"member","account":{"plan":""}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithRoleResolver(resolver, tt.policy))
+			router := gin.New()
+			router.POST("/users", builder.MustFormBindingGinHandlerFunc(func(req roleRequest) (any, error) {
+				return req, nil
+			}))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.roles != "" {
+				req.Header.Set("X-Roles", tt.roles)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
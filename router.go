@@ -0,0 +1,187 @@
+package ginbinding
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routerMethods are the methods a Router answers on every path it knows, so
+// that it can reply to methods without a handler itself
+var routerMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// Router registers handler functions built by a builder on a gin engine or
+// route group. On every path it registers, OPTIONS requests without a handler
+// are answered with 204 No Content and an Allow header listing the methods of
+// the path, and requests using any other method without a handler fail with
+// ErrMethodNotAllowed through the builder's response handler, with the same
+// Allow header. With WithCORS, it also answers CORS preflight requests and
+// adds CORS headers to the other responses:
+//
+//	r := ginbinding.NewRouter(engine.Group("/api"), builder)
+//	r.GET("/users/:id", getUser)
+//	r.PUT("/users/:id", updateUser)
+//
+// The registration methods panic if a handler function has an invalid
+// signature or a method is registered twice for the same path. Routes must be
+// registered before the router serves requests.
+type Router struct {
+	builder *BasicFormBindingGinHandlerBuilder
+	group   gin.IRouter
+	// routes holds the handlers of each path, relative to group
+	routes map[string]*route
+}
+
+// route holds the handlers registered for a path by method
+type route struct {
+	handlers map[string]gin.HandlerFunc
+	// cors holds the CORS configuration of each handler, nil for handlers
+	// built without WithCORS
+	cors map[string]*CORSConfig
+	// allow is the value of the Allow header of the path
+	allow string
+}
+
+// NewRouter returns a Router registering handlers built by builder on group,
+// which is a *gin.Engine or a *gin.RouterGroup
+func NewRouter(group gin.IRouter, builder *BasicFormBindingGinHandlerBuilder) *Router {
+	return &Router{
+		builder: builder,
+		group:   group,
+		routes:  map[string]*route{},
+	}
+}
+
+// Group returns a Router registering handlers on a route group of r for the
+// relative path with the given middleware
+func (r *Router) Group(relativePath string, middleware ...gin.HandlerFunc) *Router {
+	return NewRouter(r.group.Group(relativePath, middleware...), r.builder)
+}
+
+// Handle registers the handler function i for method and relativePath, with
+// opts applied to it like FormBindingGinHandlerFunc does. A WithCORS option
+// among opts applies to the requests of that method, and to the preflight
+// requests asking for it.
+func (r *Router) Handle(method, relativePath string, i any, opts ...Option) *Router {
+	builder := r.builder
+	if len(opts) > 0 {
+		builder = builder.Clone(opts...)
+	}
+	h := builder.MustFormBindingGinHandlerFunc(i)
+	method = strings.ToUpper(method)
+
+	rt, ok := r.routes[relativePath]
+	if !ok {
+		rt = &route{handlers: map[string]gin.HandlerFunc{}, cors: map[string]*CORSConfig{}}
+		r.routes[relativePath] = rt
+		for _, m := range routerMethods {
+			r.group.Handle(m, relativePath, r.serve(rt))
+		}
+	}
+	if _, ok := rt.handlers[method]; ok {
+		panic(fmt.Sprintf("ginbinding: %s %s is already registered", method, relativePath))
+	}
+	if !slices.Contains(routerMethods, method) {
+		r.group.Handle(method, relativePath, r.serve(rt))
+	}
+
+	rt.handlers[method] = h
+	rt.cors[method] = builder.cors
+	rt.allow = allowHeader(rt.handlers)
+	r.builder.addRoute(method, joinRoutePath(r.basePath(), relativePath), i)
+	return r
+}
+
+// basePath returns the path of the group of r
+func (r *Router) basePath() string {
+	if g, ok := r.group.(interface{ BasePath() string }); ok {
+		return g.BasePath()
+	}
+	return "/"
+}
+
+// GET registers the handler function i for GET requests to relativePath
+func (r *Router) GET(relativePath string, i any, opts ...Option) *Router {
+	return r.Handle(http.MethodGet, relativePath, i, opts...)
+}
+
+// POST registers the handler function i for POST requests to relativePath
+func (r *Router) POST(relativePath string, i any, opts ...Option) *Router {
+	return r.Handle(http.MethodPost, relativePath, i, opts...)
+}
+
+// PUT registers the handler function i for PUT requests to relativePath
+func (r *Router) PUT(relativePath string, i any, opts ...Option) *Router {
+	return r.Handle(http.MethodPut, relativePath, i, opts...)
+}
+
+// PATCH registers the handler function i for PATCH requests to relativePath
+func (r *Router) PATCH(relativePath string, i any, opts ...Option) *Router {
+	return r.Handle(http.MethodPatch, relativePath, i, opts...)
+}
+
+// DELETE registers the handler function i for DELETE requests to relativePath
+func (r *Router) DELETE(relativePath string, i any, opts ...Option) *Router {
+	return r.Handle(http.MethodDelete, relativePath, i, opts...)
+}
+
+// serve dispatches the requests to the path of rt to their handler
+func (r *Router) serve(rt *route) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if isPreflight(ctx) {
+			if cors := rt.corsConfig(r.builder, ctx.GetHeader("Access-Control-Request-Method")); cors != nil {
+				cors.setHeaders(ctx, rt.allow, true)
+				ctx.Header("Allow", rt.allow)
+				ctx.Status(http.StatusNoContent)
+				return
+			}
+		} else if cors := rt.corsConfig(r.builder, ctx.Request.Method); cors != nil {
+			cors.setHeaders(ctx, rt.allow, false)
+		}
+
+		if h, ok := rt.handlers[ctx.Request.Method]; ok {
+			h(ctx)
+			return
+		}
+
+		ctx.Header("Allow", rt.allow)
+		if ctx.Request.Method == http.MethodOptions {
+			ctx.Status(http.StatusNoContent)
+			return
+		}
+		r.builder.handleError(ctx, reflect.Value{}, fmt.Errorf("%s %s: %w", ctx.Request.Method, ctx.FullPath(), ErrMethodNotAllowed))
+	}
+}
+
+// corsConfig returns the CORS configuration of the handler of rt for method,
+// or that of builder for methods without a handler
+func (rt *route) corsConfig(builder *BasicFormBindingGinHandlerBuilder, method string) *CORSConfig {
+	if _, ok := rt.handlers[method]; ok {
+		return rt.cors[method]
+	}
+	return builder.cors
+}
+
+// allowHeader lists the methods of handlers, and OPTIONS, sorted
+func allowHeader(handlers map[string]gin.HandlerFunc) string {
+	methods := []string{http.MethodOptions}
+	for method := range handlers {
+		if method != http.MethodOptions {
+			methods = append(methods, method)
+		}
+	}
+	slices.Sort(methods)
+	return strings.Join(methods, ", ")
+}
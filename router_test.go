@@ -0,0 +1,70 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type itemRequest struct {
+		ID string `uri:"id"`
+	}
+	getItem := func(c *gin.Context, req itemRequest) (string, error) {
+		return "get " + req.ID, nil
+	}
+	deleteItem := func(c *gin.Context, req itemRequest) error {
+		return nil
+	}
+
+	engine := gin.New()
+	r := NewRouter(engine, NewBasicFormBindingGinHandlerBuilder(nil, nil)).Group("/api")
+	r.GET("/items/:id", getItem).DELETE("/items/:id", deleteItem)
+	r.POST("/items", func(c *gin.Context) error { return nil })
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedAllow  string
+	}{
+		{name: "registered", method: http.MethodGet, path: "/api/items/7", expectedStatus: http.StatusOK},
+		{name: "options", method: http.MethodOptions, path: "/api/items/7", expectedStatus: http.StatusNoContent, expectedAllow: "DELETE, GET, OPTIONS"},
+		{name: "not allowed", method: http.MethodPut, path: "/api/items/7", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "DELETE, GET, OPTIONS"},
+		{name: "head not registered", method: http.MethodHead, path: "/api/items", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "OPTIONS, POST"},
+		{name: "unknown path", method: http.MethodGet, path: "/api/other", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedAllow, w.Header().Get("Allow"))
+		})
+	}
+
+	t.Run("envelope", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/api/items/7", nil)
+		engine.ServeHTTP(w, req)
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, CodeMethodNotAllowed, body["code"])
+	})
+
+	t.Run("duplicate method", func(t *testing.T) {
+		assert.Panics(t, func() { r.GET("/items/:id", getItem) })
+	})
+}
@@ -0,0 +1,224 @@
+package ginbinding
+
+import (
+	"path"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteInfo describes a route registered through a Router, for
+// documentation and client generators
+type RouteInfo struct {
+	// Method is the HTTP method of the route
+	Method string
+	// Path is the full path of the route in gin syntax, e.g. /api/users/:id
+	Path string
+	// Handler is the name of the handler function as reported by the
+	// runtime, e.g. example.com/app.getUser or example.com/app.(*Users).Get-fm
+	Handler string
+	HandlerMetadata
+	// Fields are the inputs of the request and body structs, in field order,
+	// or nil for map requests and Stream bodies
+	Fields []RouteField
+}
+
+// RouteField is an input of the request of a route
+type RouteField struct {
+	// Source is the request input the field is bound from, one of the Source
+	// constants
+	Source string
+	// Name is the key of the field within Source, such as the path parameter
+	// or header name, or the JSON key of a body field
+	Name string
+	// Field is the name of the struct field, dotted for fields of embedded
+	// structs with their own tags
+	Field string
+	// Type is the Go type of the field
+	Type reflect.Type
+	// Required is set for fields with a binding:"required" tag
+	Required bool
+	// Default is the default value of the field, or empty
+	Default string
+	// Example is an example value of the field from its example tag, Doc its
+	// description from its doc tag, and Deprecated is set by a deprecated tag
+	// such as deprecated:"true" or deprecated:"use new_name", whose note is
+	// DeprecationNote
+	Example         string
+	Doc             string
+	Deprecated      bool
+	DeprecationNote string
+	// Tag is the struct tag of the field
+	Tag reflect.StructTag
+}
+
+// routeTable holds the routes registered through the routers of a builder
+type routeTable struct {
+	mu     sync.Mutex
+	routes []RouteInfo
+}
+
+// Routes returns the routes registered through the routers of the builder, in
+// registration order. Clones have routes of their own.
+func (builder *BasicFormBindingGinHandlerBuilder) Routes() []RouteInfo {
+	if builder.routes == nil {
+		return nil
+	}
+	builder.routes.mu.Lock()
+	defer builder.routes.mu.Unlock()
+	return slices.Clone(builder.routes.routes)
+}
+
+// RoutesHandlerFunc returns a handler writing the routes of the builder
+// through its response handler, typically mounted as a debug endpoint:
+//
+//	engine.GET("/_routes", builder.RoutesHandlerFunc())
+//
+// Each route is rendered with its method, path, the names of its request,
+// body and response types and its fields, with their examples, descriptions
+// and deprecation.
+func (builder *BasicFormBindingGinHandlerBuilder) RoutesHandlerFunc() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		routes := builder.Routes()
+		out := make([]gin.H, 0, len(routes))
+		for _, rt := range routes {
+			fields := make([]gin.H, 0, len(rt.Fields))
+			for _, f := range rt.Fields {
+				field := gin.H{"source": f.Source, "name": f.Name, "field": f.Field, "type": f.Type.String()}
+				if f.Required {
+					field["required"] = true
+				}
+				if f.Default != "" {
+					field["default"] = f.Default
+				}
+				if f.Example != "" {
+					field["example"] = f.Example
+				}
+				if f.Doc != "" {
+					field["doc"] = f.Doc
+				}
+				if f.Deprecated {
+					field["deprecated"] = true
+				}
+				if f.DeprecationNote != "" {
+					field["deprecation_note"] = f.DeprecationNote
+				}
+				fields = append(fields, field)
+			}
+			out = append(out, gin.H{
+				"method":   rt.Method,
+				"path":     rt.Path,
+				"request":  typeName(rt.RequestType),
+				"body":     typeName(rt.BodyType),
+				"response": typeName(rt.ResponseType),
+				"fields":   fields,
+			})
+		}
+		builder.responseHandler.HandleSuccess(ctx, out)
+	}
+}
+
+func typeName(ty reflect.Type) string {
+	if ty == nil {
+		return ""
+	}
+	return ty.String()
+}
+
+// addRoute records the route of the handler function i
+func (builder *BasicFormBindingGinHandlerBuilder) addRoute(method, fullPath string, i any) {
+	if builder.routes == nil {
+		return
+	}
+	meta, err := builder.Describe(i)
+	if err != nil {
+		return
+	}
+
+	rt := RouteInfo{Method: method, Path: fullPath, HandlerMetadata: meta}
+	if fn := runtime.FuncForPC(reflect.ValueOf(i).Pointer()); fn != nil {
+		rt.Handler = fn.Name()
+	}
+	for _, ty := range []reflect.Type{meta.RequestType, meta.BodyType} {
+		if ty == nil || ty == mapAnyTy {
+			continue
+		}
+		if ty.Kind() == reflect.Pointer {
+			ty = ty.Elem()
+		}
+		if isStreamType(ty) {
+			continue
+		}
+		rt.Fields = builder.appendRouteFields(rt.Fields, ty, "")
+	}
+
+	builder.routes.mu.Lock()
+	defer builder.routes.mu.Unlock()
+	builder.routes.routes = append(builder.routes.routes, rt)
+}
+
+// appendRouteFields appends the inputs of the struct type ty to fields.
+// Untagged embedded structs contribute their own fields.
+func (builder *BasicFormBindingGinHandlerBuilder) appendRouteFields(fields []RouteField, ty reflect.Type, prefix string) []RouteField {
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if !sf.IsExported() || sf.Tag.Get("json") == "-" {
+			continue
+		}
+
+		if sf.Anonymous && fieldTagless(sf) {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = builder.appendRouteFields(fields, embedded, joinFieldPath(prefix, sf.Name))
+				continue
+			}
+		}
+
+		source, name, _ := strings.Cut(fieldSource(sf), ":")
+		deprecated, note := deprecation(sf)
+		fields = append(fields, RouteField{
+			Source:          source,
+			Name:            name,
+			Field:           joinFieldPath(prefix, sf.Name),
+			Type:            sf.Type,
+			Required:        slices.Contains(strings.Split(sf.Tag.Get("binding"), ","), "required"),
+			Default:         sf.Tag.Get(builder.defaultTag),
+			Example:         sf.Tag.Get("example"),
+			Doc:             sf.Tag.Get("doc"),
+			Deprecated:      deprecated,
+			DeprecationNote: note,
+			Tag:             sf.Tag,
+		})
+	}
+	return fields
+}
+
+// fieldTagless reports whether sf has none of the tags naming an input
+func fieldTagless(sf reflect.StructField) bool {
+	for _, tag := range []string{"path", "uri", "form", "query", "header", "host", "tls", "pseudo", "trailer", "file", "part", "json", "ifmatch"} {
+		if _, ok := sf.Tag.Lookup(tag); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// joinRoutePath joins the base path of a route group and a relative path like
+// gin does, keeping a trailing slash of the relative path
+func joinRoutePath(base, relative string) string {
+	if relative == "" {
+		return base
+	}
+	joined := path.Join(base, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+	return joined
+}
@@ -0,0 +1,102 @@
+package ginbinding
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type RoutePaging struct {
+	Page int `form:"page" default:"1"`
+}
+
+type routeListRequest struct {
+	RoutePaging
+	OrgID  string `path:"org" binding:"required"`
+	Token  string `header:"Authorization"`
+	Search string `form:"q" example:"ann" doc:"Matches user names" deprecated:"true"`
+}
+
+type routeCreateBody struct {
+	Name     string `json:"name" binding:"required"`
+	Internal string `json:"-"`
+}
+
+func TestRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	r := NewRouter(engine.Group("/api"), builder)
+
+	r.GET("/orgs/:org/users", func(ctx context.Context, req routeListRequest) ([]string, error) {
+		return nil, nil
+	})
+	r.Group("/v2").POST("/orgs/:org/users/", func(ctx context.Context, req struct {
+		OrgID string `uri:"org"`
+	}, body routeCreateBody) error {
+		return nil
+	})
+	r.DELETE("/cache", func(c *gin.Context, req map[string]any) error {
+		return nil
+	})
+	engine.GET("/_routes", builder.RoutesHandlerFunc())
+
+	routes := builder.Routes()
+	assert.Len(t, routes, 3)
+
+	assert.Equal(t, RouteInfo{
+		Method:  http.MethodGet,
+		Path:    "/api/orgs/:org/users",
+		Handler: "github.com/zgs225/gin-form-binding.TestRoutes.func1",
+		HandlerMetadata: HandlerMetadata{
+			RequestType:  reflect.TypeOf(routeListRequest{}),
+			ResponseType: reflect.TypeOf([]string{}),
+		},
+		Fields: []RouteField{
+			{Source: SourceQuery, Name: "page", Field: "RoutePaging.Page", Type: reflect.TypeOf(0), Default: "1", Tag: `form:"page" default:"1"`},
+			{Source: SourcePath, Name: "org", Field: "OrgID", Type: reflect.TypeOf(""), Required: true, Tag: `path:"org" binding:"required"`},
+			{Source: SourceHeader, Name: "Authorization", Field: "Token", Type: reflect.TypeOf(""), Tag: `header:"Authorization"`},
+			{
+				Source: SourceQuery, Name: "q", Field: "Search", Type: reflect.TypeOf(""),
+				Example: "ann", Doc: "Matches user names", Deprecated: true,
+				Tag: `form:"q" example:"ann" doc:"Matches user names" deprecated:"true"`,
+			},
+		},
+	}, routes[0])
+
+	assert.Equal(t, "/api/v2/orgs/:org/users/", routes[1].Path)
+	assert.Equal(t, []RouteField{
+		{Source: SourcePath, Name: "org", Field: "OrgID", Type: reflect.TypeOf(""), Tag: `uri:"org"`},
+		{Source: SourceBody, Name: "name", Field: "Name", Type: reflect.TypeOf(""), Required: true, Tag: `json:"name" binding:"required"`},
+	}, routes[1].Fields)
+
+	assert.Equal(t, "/api/cache", routes[2].Path)
+	assert.Nil(t, routes[2].Fields)
+
+	assert.Empty(t, builder.Clone().Routes())
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/_routes", nil)
+	engine.ServeHTTP(w, req)
+
+	var response struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Data, 3)
+	assert.Equal(t, "ginbinding.routeListRequest", response.Data[0]["request"])
+	assert.Equal(t, map[string]interface{}{
+		"source": "path", "name": "org", "field": "OrgID", "type": "string", "required": true,
+	}, response.Data[0]["fields"].([]interface{})[1])
+	assert.Equal(t, map[string]interface{}{
+		"source": "query", "name": "q", "field": "Search", "type": "string",
+		"example": "ann", "doc": "Matches user names", "deprecated": true,
+	}, response.Data[0]["fields"].([]interface{})[3])
+}
@@ -0,0 +1,196 @@
+package ginbinding
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Sanitizer turns a bound string into a string safe to store and render, e.g.
+// by removing HTML markup
+type Sanitizer func(s string) string
+
+// defaultSanitizers are the policies of the "sanitize" tag available to every
+// builder
+var defaultSanitizers = map[string]Sanitizer{
+	"html":   SanitizeHTML,
+	"strict": SanitizeStrict,
+}
+
+// WithSanitizer registers fn as the sanitizer of the policy name of the
+// "sanitize" tag, replacing the built-in html and strict policies if name is
+// one of them, e.g. to use a dedicated HTML sanitizer library:
+//
+//	policy := bluemonday.UGCPolicy()
+//	builder := ginbinding.NewBasicFormBindingGinHandlerBuilder(nil, nil,
+//		ginbinding.WithSanitizer("html", policy.Sanitize))
+func WithSanitizer(name string, fn Sanitizer) Option {
+	if fn == nil {
+		panic(fmt.Sprintf("ginbinding: nil sanitizer %q", name))
+	}
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		// Always reallocate so that per-handler sanitizers never leak into
+		// the builder the handler was copied from
+		sanitizers := maps.Clone(builder.sanitizers)
+		if sanitizers == nil {
+			sanitizers = map[string]Sanitizer{}
+		}
+		sanitizers[name] = fn
+		builder.sanitizers = sanitizers
+	}
+}
+
+// sanitizer returns the sanitizer of the policy name
+func (builder *BasicFormBindingGinHandlerBuilder) sanitizer(name string) (Sanitizer, bool) {
+	if fn, ok := builder.sanitizers[name]; ok {
+		return fn, true
+	}
+	fn, ok := defaultSanitizers[name]
+	return fn, ok
+}
+
+// checkSanitizeTags verifies at handler build time that the "sanitize" tags of
+// the request type ty name known policies of string fields
+func (builder *BasicFormBindingGinHandlerBuilder) checkSanitizeTags(ty reflect.Type) error {
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	return walkTypeFields(ty, func(sf reflect.StructField) error {
+		name, ok := sf.Tag.Lookup("sanitize")
+		if !ok {
+			return nil
+		}
+		if _, ok := builder.sanitizer(name); !ok {
+			return fmt.Errorf("field %s: unknown sanitize policy %q", sf.Name, name)
+		}
+		if !isModifiableType(sf.Type) {
+			return fmt.Errorf("field %s: sanitize tag requires a string, *string or []string field, got %s", sf.Name, sf.Type)
+		}
+		return nil
+	})
+}
+
+// applySanitizers runs the string fields of val with "sanitize" tags through
+// the sanitizers of their policies
+func (builder *BasicFormBindingGinHandlerBuilder) applySanitizers(val reflect.Value) error {
+	if !typeInfoOf(val.Type()).sanitized {
+		return nil
+	}
+
+	return walkFields(val, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		name, ok := sf.Tag.Lookup("sanitize")
+		if !ok {
+			return nil
+		}
+		fn, ok := builder.sanitizer(name)
+		if !ok {
+			return fmt.Errorf("field %s: unknown sanitize policy %q", path, name)
+		}
+		modifyValue(fieldVal, []func(string) string{fn})
+		return nil
+	})
+}
+
+// sanitizedTags are the tags SanitizeHTML keeps, with their allowed attributes
+var sanitizedTags = map[string][]string{
+	"a": {"href", "title"}, "b": nil, "blockquote": nil, "br": nil, "code": nil,
+	"em": nil, "i": nil, "li": nil, "ol": nil, "p": nil, "pre": nil,
+	"s": nil, "strong": nil, "u": nil, "ul": nil,
+}
+
+// droppedContentTags are the tags whose content is removed along with them
+var droppedContentTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true,
+	"embed": true, "noscript": true, "template": true, "textarea": true,
+}
+
+// textEscaper escapes the characters of text that HTML parses as markup
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// SanitizeHTML is the html policy of the "sanitize" tag. It keeps basic
+// formatting tags such as p, em, strong, lists and links to http, https and
+// mailto URLs, removes every other tag, attribute and comment, as well as the
+// content of scripts and styles, and escapes the text. The result is HTML safe
+// to render unescaped.
+func SanitizeHTML(s string) string {
+	return sanitizeMarkup(s, true)
+}
+
+// SanitizeStrict is the strict policy of the "sanitize" tag. Like SanitizeHTML,
+// but it removes all tags, keeping only the escaped text.
+func SanitizeStrict(s string) string {
+	return sanitizeMarkup(s, false)
+}
+
+// sanitizeMarkup removes the markup of the HTML fragment s, keeping the tags
+// of sanitizedTags if keepTags is set
+func sanitizeMarkup(s string, keepTags bool) string {
+	// Plain text is returned as is
+	if !strings.ContainsAny(s, "<>&") {
+		return s
+	}
+
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(s))
+	// dropped is the nesting depth of tags whose content is removed
+	dropped := 0
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return b.String()
+		case html.TextToken:
+			if dropped == 0 {
+				textEscaper.WriteString(&b, string(z.Text()))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if droppedContentTags[tok.Data] {
+				if tt == html.StartTagToken {
+					dropped++
+				}
+				continue
+			}
+			attrs, ok := sanitizedTags[tok.Data]
+			if !keepTags || dropped > 0 || !ok {
+				continue
+			}
+			b.WriteString("<" + tok.Data)
+			for _, attr := range tok.Attr {
+				if attr.Namespace != "" || !slices.Contains(attrs, attr.Key) || attr.Key == "href" && !safeURL(attr.Val) {
+					continue
+				}
+				b.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+			}
+			b.WriteString(">")
+		case html.EndTagToken:
+			tok := z.Token()
+			if droppedContentTags[tok.Data] {
+				dropped = max(dropped-1, 0)
+				continue
+			}
+			if _, ok := sanitizedTags[tok.Data]; keepTags && dropped == 0 && ok && tok.Data != "br" {
+				b.WriteString("</" + tok.Data + ">")
+			}
+		}
+	}
+}
+
+// safeURL reports whether the link target u is relative or uses the http,
+// https or mailto scheme
+func safeURL(u string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(u))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https", "mailto":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		html   string
+		strict string
+	}{
+		{name: "plain text", input: "Tom's notes", html: "Tom's notes", strict: "Tom's notes"},
+		{
+			name:   "formatting",
+			input:  `<p class="x">Hi <strong>there</strong><br/></p>`,
+			html:   `<p>Hi <strong>there</strong><br></p>`,
+			strict: `Hi there`,
+		},
+		{
+			name:   "script",
+			input:  `<b>x</b><script>alert(1)</script><img src=x onerror=alert(1)>`,
+			html:   `<b>x</b>`,
+			strict: `x`,
+		},
+		{
+			name:   "links",
+			input:  `<a href="https://example.com" onclick="steal()">ok</a><a href=" JavaScript:alert(1)">bad</a>`,
+			html:   `<a href="https://example.com">ok</a><a>bad</a>`,
+			strict: `okbad`,
+		},
+		{
+			name:   "entities",
+			input:  `1 &lt; 2 & "quoted"`,
+			html:   `1 &lt; 2 &amp; "quoted"`,
+			strict: `1 &lt; 2 &amp; "quoted"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.html, SanitizeHTML(tt.input))
+			assert.Equal(t, tt.strict, SanitizeStrict(tt.input))
+		})
+	}
+}
+
+type sanitizeRequest struct {
+	Title string   `json:"title" mod:"trim" sanitize:"strict"`
+	Body  *string  `json:"body" sanitize:"html"`
+	Tags  []string `json:"tags" sanitize:"shout"`
+	Name  string   `json:"name" sanitize:"strict" binding:"required"`
+}
+
+func TestSanitizeTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithGinDefaultValidator(), WithSanitizer("shout", strings.ToUpper))
+	router := gin.New()
+	router.POST("/posts", builder.MustFormBindingGinHandlerFunc(func(req sanitizeRequest) (any, error) {
+		return req, nil
+	}))
+
+	send := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/posts", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(`{"title":"  <i>Hello</i> ","body":"<em>hi</em><script>x</script>","tags":["go"],"name":"Ann"}`)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `{"title":"Hello","body":"\u003cem\u003ehi\u003c/em\u003e","tags":["GO"],"name":"Ann"}`)
+
+	// Sanitizing runs before validation
+	w = send(`{"name":"<script>x</script>"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"name"`)
+
+	t.Run("unknown policy", func(t *testing.T) {
+		_, err := NewBasicFormBindingGinHandlerBuilder(nil, nil).FormBindingGinHandlerFunc(func(req sanitizeRequest) error {
+			return nil
+		})
+		assert.EqualError(t, err, `field Tags: unknown sanitize policy "shout"`)
+	})
+
+	t.Run("non-string field", func(t *testing.T) {
+		_, err := builder.FormBindingGinHandlerFunc(func(req struct {
+			Count int `form:"count" sanitize:"strict"`
+		}) error {
+			return nil
+		})
+		assert.EqualError(t, err, "field Count: sanitize tag requires a string, *string or []string field, got int")
+	})
+}
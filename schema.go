@@ -0,0 +1,349 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+var (
+	jsonUnmarshalerTy = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerTy = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// WithStrictSchema checks JSON request bodies against the schema derived from
+// the request struct before decoding them. Instead of failing on the first
+// value of the wrong type, a request is rejected with a SchemaError listing
+// every such value, e.g. a string sent for an int field. Fields of types with
+// their own JSON or text decoding, such as time.Time, accept any value, and
+// unknown keys are ignored like encoding/json does.
+//
+// In gin's debug mode, the JSON encoding of the data returned by handlers is
+// also checked against the schema of their declared response type, so that
+// responses clients could not decode into that type, such as the output of a
+// MarshalJSON method without matching UnmarshalJSON, fail with 500 during
+// development.
+func WithStrictSchema() Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.strictSchema = true
+	}
+}
+
+// SchemaViolation is a value of a JSON document whose JSON type does not match
+// the Go field it is decoded into
+type SchemaViolation struct {
+	// Field is the path of the value, e.g. items[0].price, or empty for the
+	// document itself
+	Field string
+	// Expected is the JSON type of the field: boolean, integer, number,
+	// string, array or object
+	Expected string
+	// Actual is the JSON type of the value
+	Actual string
+}
+
+// SchemaError reports the values of a JSON document that do not match the
+// schema of its Go type. It is reported within a BindingError for request
+// bodies, and DefaultResponseHandler lists the violations under "violations".
+type SchemaError struct {
+	Violations []SchemaViolation
+}
+
+// Error implements the error interface
+func (e *SchemaError) Error() string {
+	var b strings.Builder
+	b.WriteString("body does not match the schema: ")
+	for i, v := range e.Violations {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		field := v.Field
+		if field == "" {
+			field = "body"
+		}
+		fmt.Fprintf(&b, "%s must be %s, got %s", field, article(v.Expected), v.Actual)
+	}
+	return b.String()
+}
+
+// article prefixes the JSON type name with its indefinite article
+func article(name string) string {
+	if strings.ContainsRune("aeiou", rune(name[0])) {
+		return "an " + name
+	}
+	return "a " + name
+}
+
+// checkBodySchema checks the JSON body of ctx against the schema of the
+// request struct type ty, leaving the body to be decoded again
+func checkBodySchema(ctx *gin.Context, ty reflect.Type) error {
+	if ctx.Request.Body == nil || ctx.ContentType() != binding.MIMEJSON {
+		return nil
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return &inputError{source: SourceBody, err: err}
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var doc any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		// Leave empty and malformed bodies to the regular JSON binding to report
+		return nil
+	}
+
+	if violations := checkSchema(nil, ty, doc, ""); len(violations) > 0 {
+		return &SchemaError{Violations: violations}
+	}
+	return nil
+}
+
+// checkResponseSchema checks that the JSON encoding of data decodes back into
+// the declared response type ty, which clients rely on
+func checkResponseSchema(ty reflect.Type, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		// Left to the response handler to report
+		return nil
+	}
+
+	var doc any
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil
+	}
+
+	if violations := checkSchema(nil, ty, doc, ""); len(violations) > 0 {
+		return fmt.Errorf("ginbinding: response of type %s: %w", ty, &SchemaError{Violations: violations})
+	}
+	return nil
+}
+
+// checkSchema appends the violations of the decoded JSON value v of a field
+// of type ty at path to violations. Values of types with their own JSON or
+// text decoding are not checked.
+func checkSchema(violations []SchemaViolation, ty reflect.Type, v any, path string) []SchemaViolation {
+	// null leaves any field at its zero value
+	if v == nil {
+		return violations
+	}
+	for ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	for _, iface := range []reflect.Type{jsonUnmarshalerTy, textUnmarshalerTy} {
+		if ty.Implements(iface) || reflect.PointerTo(ty).Implements(iface) {
+			return violations
+		}
+	}
+
+	expected := schemaType(ty)
+	actual := jsonType(v)
+	if expected == "" {
+		return violations
+	}
+	if expected != actual && !(expected == "number" && actual == "integer") {
+		return append(violations, SchemaViolation{Field: path, Expected: expected, Actual: actual})
+	}
+
+	switch ty.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if strings.HasPrefix(v.(json.Number).String(), "-") {
+			return append(violations, SchemaViolation{Field: path, Expected: "non-negative integer", Actual: "negative integer"})
+		}
+	case reflect.Slice, reflect.Array:
+		if expected != "array" {
+			return violations
+		}
+		for i, elem := range v.([]any) {
+			violations = checkSchema(violations, ty.Elem(), elem, path+"["+strconv.Itoa(i)+"]")
+		}
+	case reflect.Map:
+		obj := v.(map[string]any)
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			violations = checkSchema(violations, ty.Elem(), obj[key], joinJSONPath(path, key))
+		}
+	case reflect.Struct:
+		fields := schemaFieldsOf(ty)
+		obj := v.(map[string]any)
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			elem := obj[key]
+			f, ok := fields.lookup(key)
+			if !ok {
+				continue
+			}
+			if f.quoted {
+				if _, isString := elem.(string); !isString && elem != nil {
+					violations = append(violations, SchemaViolation{Field: joinJSONPath(path, key), Expected: "string", Actual: jsonType(elem)})
+				}
+				continue
+			}
+			violations = checkSchema(violations, f.ty, elem, joinJSONPath(path, key))
+		}
+	}
+	return violations
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// schemaType returns the JSON type of the values decoded into ty, or "" if
+// any value is accepted
+func schemaType(ty reflect.Type) string {
+	switch ty.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice:
+		if ty.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded in base64
+			return "string"
+		}
+		return "array"
+	case reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return ""
+}
+
+// jsonType returns the JSON type of the decoded value v, telling integers
+// apart from other numbers
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return "integer"
+		}
+		if _, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	}
+	return "object"
+}
+
+// schemaField is a key of the JSON object of a struct type
+type schemaField struct {
+	ty reflect.Type
+	// quoted is set for fields with the string option, encoded as strings
+	quoted bool
+}
+
+// schemaFields maps the keys of the JSON object of a struct type to its fields
+type schemaFields map[string]schemaField
+
+var schemaFieldsCache sync.Map // map[reflect.Type]schemaFields
+
+// schemaFieldsOf returns the fields of the JSON object of the struct type ty,
+// including the fields promoted from embedded structs
+func schemaFieldsOf(ty reflect.Type) schemaFields {
+	if fields, ok := schemaFieldsCache.Load(ty); ok {
+		return fields.(schemaFields)
+	}
+
+	fields := schemaFields{}
+	fields.add(ty)
+	schemaFieldsCache.Store(ty, fields)
+	return fields
+}
+
+// add adds the fields of the struct type ty, keeping the fields added before,
+// which shadow the fields of embedded structs
+func (fields schemaFields) add(ty reflect.Type) {
+	var embedded []reflect.Type
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" {
+			fieldTy := sf.Type
+			if fieldTy.Kind() == reflect.Pointer {
+				fieldTy = fieldTy.Elem()
+			}
+			if fieldTy.Kind() == reflect.Struct {
+				embedded = append(embedded, fieldTy)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = schemaField{ty: sf.Type, quoted: strings.Contains(","+opts+",", ",string,") && quotable(sf.Type)}
+		}
+	}
+	for _, ty := range embedded {
+		fields.add(ty)
+	}
+}
+
+// quotable reports whether the string option of encoding/json applies to ty
+func quotable(ty reflect.Type) bool {
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	switch ty.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// lookup returns the field of key the way encoding/json matches keys: exact
+// match first, then case-insensitive
+func (fields schemaFields) lookup(key string) (schemaField, bool) {
+	if f, ok := fields[key]; ok {
+		return f, true
+	}
+	for name, f := range fields {
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return schemaField{}, false
+}
@@ -0,0 +1,161 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type schemaItem struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price"`
+	Count uint    `json:"count"`
+}
+
+type schemaAudit struct {
+	Note string `json:"note"`
+}
+
+type schemaRequest struct {
+	schemaAudit
+	Page    int               `form:"page"`
+	Name    string            `json:"name"`
+	Age     int               `json:"age"`
+	Active  *bool             `json:"active"`
+	Items   []schemaItem      `json:"items"`
+	Labels  map[string]string `json:"labels"`
+	Since   time.Time         `json:"since"`
+	Limit   int               `json:"limit,string"`
+	Payload []byte            `json:"payload"`
+	Extra   any               `json:"extra"`
+}
+
+// schemaShape encodes as an array, which does not decode back into it
+type schemaShape struct {
+	X, Y int
+}
+
+func (s schemaShape) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]int{s.X, s.Y})
+}
+
+type schemaResponse struct {
+	Name  string      `json:"name"`
+	Shape schemaShape `json:"shape"`
+	Since time.Time   `json:"since"`
+}
+
+func TestWithStrictSchema(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		strict         bool
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "valid",
+			strict:         true,
+			body:           `{"name":"Ann","age":30,"active":null,"items":[{"sku":"a","price":2,"count":1}],"labels":{"a":"b"},"since":"2024-01-02T00:00:00Z","limit":"5","payload":"aGk=","extra":[1],"note":"n","unknown":1}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "every wrong type",
+			strict:         true,
+			body:           `{"name":1,"age":1.5,"active":"yes","items":[{"sku":"a","price":"2","count":-1}],"labels":{"a":true},"limit":5,"Note":false}`,
+			expectedStatus: http.StatusBadRequest,
+			// Keys are checked in sorted order
+			expectedBody: `"field":"Note","message":"body does not match the schema: Note must be a string, got boolean; active must be a boolean, got string; ` +
+				`age must be an integer, got number; items[0].count must be a non-negative integer, got negative integer; ` +
+				`items[0].price must be a number, got string; labels.a must be a string, got boolean; limit must be a string, got integer; ` +
+				`name must be a string, got integer","source":"body","status":"error","violations":[` +
+				`{"actual":"boolean","expected":"string","field":"Note"},` +
+				`{"actual":"string","expected":"boolean","field":"active"},` +
+				`{"actual":"number","expected":"integer","field":"age"},` +
+				`{"actual":"negative integer","expected":"non-negative integer","field":"items[0].count"},` +
+				`{"actual":"string","expected":"number","field":"items[0].price"},` +
+				`{"actual":"boolean","expected":"string","field":"labels.a"},` +
+				`{"actual":"integer","expected":"string","field":"limit"},` +
+				`{"actual":"integer","expected":"string","field":"name"}]`,
+		},
+		{
+			name:           "document type",
+			strict:         true,
+			body:           `[1]`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"violations":[{"actual":"array","expected":"object","field":""}]`,
+		},
+		{
+			name:           "first error only",
+			body:           `{"name":1,"age":"x"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"field":"name"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.strict {
+				opts = append(opts, WithStrictSchema())
+			}
+			builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, opts...)
+
+			router := gin.New()
+			router.POST("/users", builder.MustFormBindingGinHandlerFunc(func(req schemaRequest) error {
+				return nil
+			}))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			if !tt.strict {
+				assert.NotContains(t, w.Body.String(), "violations")
+			}
+		})
+	}
+}
+
+func TestStrictResponseSchema(t *testing.T) {
+	defer gin.SetMode(gin.TestMode)
+
+	serve := func() *httptest.ResponseRecorder {
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithStrictSchema())
+		router := gin.New()
+		router.GET("/shape", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (*schemaResponse, error) {
+			return &schemaResponse{Name: "square", Shape: schemaShape{X: 1, Y: 2}, Since: time.Now()}, nil
+		}))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/shape", nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("debug mode", func(t *testing.T) {
+		gin.SetMode(gin.DebugMode)
+		w := serve()
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), `"violations":[{"actual":"array","expected":"object","field":"shape"}]`)
+	})
+
+	t.Run("release mode", func(t *testing.T) {
+		gin.SetMode(gin.ReleaseMode)
+		w := serve()
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
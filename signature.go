@@ -0,0 +1,348 @@
+package ginbinding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandlerMetadata describes the request and response types of a handler
+// function, for use by documentation generators and response validation
+type HandlerMetadata struct {
+	// RequestType is the declared type of the request parameter, a struct,
+	// pointer to struct or map[string]any, or nil when the handler takes no
+	// request
+	RequestType reflect.Type
+	// BodyType is the declared type of the body parameter of handlers that
+	// split the request into two structs, or the Stream type of handlers
+	// streaming the body, or nil
+	BodyType reflect.Type
+	// ResponseType is the declared type of the data returned by the handler,
+	// such as a concrete struct or an interface type like any, or nil when the
+	// handler only returns an error
+	ResponseType reflect.Type
+}
+
+// Describe validates the signature of the handler function i like
+// FormBindingGinHandlerFunc and returns its metadata
+func (builder *BasicFormBindingGinHandlerBuilder) Describe(i any) (HandlerMetadata, error) {
+	sig, err := builder.analyzeHandler(i)
+	if err != nil {
+		return HandlerMetadata{}, err
+	}
+	return sig.metadata(), nil
+}
+
+// handlerSignature is the validated shape of a handler function
+type handlerSignature struct {
+	funcVal  reflect.Value
+	funcType reflect.Type
+	ctxParam contextParam
+	// params holds the provided services at their parameter index
+	params []reflect.Value
+	// reqIndex is the index of the request parameter, or -1 when there is none
+	reqIndex int
+	// bodyIndex is the index of the body parameter of query + body handlers,
+	// or -1 when the request parameter binds the body as well
+	bodyIndex int
+	// stream is set when the body parameter is a Stream
+	stream bool
+	// reqType and bodyType are the types of the request and body parameters,
+	// or nil when there are none
+	reqType  reflect.Type
+	bodyType reflect.Type
+	// args reuses the arguments of calls, or is nil when every call allocates
+	// its own
+	args *sync.Pool
+	// direct is the handler function itself when it is a
+	// func(*gin.Context) (any, error), called without reflection
+	direct func(*gin.Context) (any, error)
+}
+
+// callArgs are the arguments of a handler call
+type callArgs struct {
+	in []reflect.Value
+	// reqCtx holds the context.Context argument, which in refers to through
+	// ctxVal so that passing it does not allocate
+	reqCtx context.Context
+	ctxVal reflect.Value
+}
+
+func newCallArgs(n int) *callArgs {
+	args := &callArgs{in: make([]reflect.Value, n)}
+	args.ctxVal = reflect.ValueOf(&args.reqCtx).Elem()
+	return args
+}
+
+// newArgs returns the arguments of a call for ctx with the context and the
+// provided services set
+func (sig *handlerSignature) newArgs(ctx *gin.Context) *callArgs {
+	var args *callArgs
+	if sig.args != nil {
+		args = sig.args.Get().(*callArgs)
+	} else {
+		args = newCallArgs(len(sig.params))
+	}
+	copy(args.in, sig.params)
+
+	switch sig.ctxParam {
+	case contextGin:
+		args.in[0] = reflect.ValueOf(ctx)
+	case contextStd:
+		args.reqCtx = ctx.Request.Context()
+		args.in[0] = args.ctxVal
+	}
+	return args
+}
+
+// releaseArgs returns the arguments of a finished call to the pool, dropping
+// the references to the request
+func (sig *handlerSignature) releaseArgs(args *callArgs) {
+	if sig.args == nil {
+		return
+	}
+	clear(args.in)
+	args.reqCtx = nil
+	sig.args.Put(args)
+}
+
+func (sig *handlerSignature) metadata() HandlerMetadata {
+	var meta HandlerMetadata
+	meta.RequestType = sig.reqType
+	meta.BodyType = sig.bodyType
+	if sig.funcType.NumOut() == 2 {
+		meta.ResponseType = sig.funcType.Out(0)
+	}
+	return meta
+}
+
+// analyzeHandler validates the signature of the handler function i and
+// resolves its provided services
+func (builder *BasicFormBindingGinHandlerBuilder) analyzeHandler(i any) (*handlerSignature, error) {
+	ity := reflect.TypeOf(i)
+
+	if ity == nil || ity.Kind() != reflect.Func {
+		return nil, errors.New("input must be a function")
+	}
+
+	// Check parameter and return value counts
+	inNum := ity.NumIn()
+	outNum := ity.NumOut()
+
+	if inNum == 0 {
+		return nil, errors.New("function must have at least one parameter")
+	}
+
+	if outNum == 0 {
+		return nil, errors.New("function must have at least one return value")
+	}
+
+	if outNum > 2 {
+		return nil, errors.New("function can have at most 2 return values")
+	}
+
+	// The first parameter is *gin.Context, context.Context or, for handlers
+	// without context, already the request
+	ctxParam := contextNone
+	switch in0Ty := ity.In(0); {
+	case in0Ty.Kind() == reflect.Pointer && in0Ty.Elem() == ginCtxTy:
+		ctxParam = contextGin
+	case in0Ty == stdCtxTy:
+		ctxParam = contextStd
+	}
+
+	// The parameter after the context is either a provided service or the
+	// request, which must be a struct, pointer to struct or map[string]any.
+	// A struct request may be followed by a second struct binding only the
+	// body, in which case the first binds only the other sources. Further
+	// parameters are services.
+	first := 1
+	if ctxParam == contextNone {
+		first = 0
+	}
+	params := make([]reflect.Value, inNum)
+	reqIndex, bodyIndex := -1, -1
+	stream := false
+	for n := first; n < inNum; n++ {
+		if service, ok, err := builder.provided(ity.In(n)); err != nil {
+			return nil, fmt.Errorf("parameter %d: %w", n+1, err)
+		} else if ok {
+			params[n] = service
+			continue
+		}
+		switch {
+		// A Stream replaces the request or the body struct
+		case isStreamType(ity.In(n)) && bodyIndex < 0 && (n == first || n == reqIndex+1 && isStructOrStructPointer(ity.In(reqIndex))):
+			bodyIndex, stream = n, true
+		case n == first:
+			reqIndex = n
+		case n == reqIndex+1 && bodyIndex < 0 && isStructOrStructPointer(ity.In(n)) && ity.In(reqIndex) != mapAnyTy && !isStreamType(ity.In(n)):
+			bodyIndex = n
+		default:
+			return nil, fmt.Errorf("parameter %d: no provider registered for %s", n+1, ity.In(n))
+		}
+	}
+
+	if reqIndex >= 0 && ity.In(reqIndex) != mapAnyTy {
+		if !isStructOrStructPointer(ity.In(reqIndex)) {
+			if reqIndex == 0 {
+				return nil, errors.New("first parameter must be *gin.Context, context.Context or a struct or pointer to struct")
+			}
+			return nil, errors.New("second parameter must be a struct or pointer to struct")
+		}
+		if err := checkRequestType(ity.In(reqIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.checkDiscriminators(ity.In(reqIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.checkVersionedTypes(ity.In(reqIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.checkRoleFields(ity.In(reqIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.checkSanitizeTags(ity.In(reqIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.checkDefaults(ity.In(reqIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.lintRequestType(ity.In(reqIndex)); err != nil {
+			return nil, err
+		}
+	}
+
+	if bodyIndex >= 0 && !stream {
+		if err := checkRequestType(ity.In(bodyIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.checkDiscriminators(ity.In(bodyIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.checkVersionedTypes(ity.In(bodyIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.checkRoleFields(ity.In(bodyIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.checkSanitizeTags(ity.In(bodyIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.checkDefaults(ity.In(bodyIndex)); err != nil {
+			return nil, err
+		}
+		if err := builder.lintRequestType(ity.In(bodyIndex)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check return value types
+	if outNum == 1 {
+		out0Ty := ity.Out(0)
+		if !out0Ty.Implements(errTy) {
+			return nil, errors.New("single return value must be error")
+		}
+	}
+
+	if outNum == 2 {
+		out1Ty := ity.Out(1)
+		if !out1Ty.Implements(errTy) {
+			return nil, errors.New("second return value must be error")
+		}
+	}
+
+	sig := &handlerSignature{
+		funcVal:   reflect.ValueOf(i),
+		funcType:  ity,
+		ctxParam:  ctxParam,
+		params:    params,
+		reqIndex:  reqIndex,
+		bodyIndex: bodyIndex,
+		stream:    stream,
+	}
+	if reqIndex >= 0 {
+		sig.reqType = ity.In(reqIndex)
+	}
+	if bodyIndex >= 0 {
+		sig.bodyType = ity.In(bodyIndex)
+	}
+	// Handlers with a timeout run through reflection on their own goroutine,
+	// and may still use their arguments after the call returned, so they are
+	// neither called directly nor reuse their arguments
+	if builder.timeout <= 0 {
+		if direct, ok := i.(func(*gin.Context) (any, error)); ok {
+			sig.direct = direct
+		} else {
+			sig.args = &sync.Pool{New: func() any { return newCallArgs(inNum) }}
+		}
+	}
+	return sig, nil
+}
+
+// invoke calls the handler with the arguments in and returns its data and
+// error. hasData is false for handlers returning only an error. A nil pointer
+// of a typed response is returned as nil data. running is closed once a
+// handler still running after its timeout returns, see call.
+func (builder *BasicFormBindingGinHandlerBuilder) invoke(ctx *gin.Context, sig *handlerSignature, in []reflect.Value) (data any, hasData bool, running <-chan struct{}, err error) {
+	if sig.direct != nil {
+		data, err = sig.direct(ctx)
+		return data, true, nil, err
+	}
+
+	out, running, err := builder.call(ctx, sig.funcVal, in, sig.ctxParam)
+	if err != nil {
+		return nil, false, running, err
+	}
+
+	if len(out) == 1 {
+		if outErr := out[0].Interface(); outErr != nil {
+			return nil, false, nil, outErr.(error)
+		}
+		return nil, false, nil, nil
+	}
+
+	if outErr := out[1].Interface(); outErr != nil {
+		return nil, true, nil, outErr.(error)
+	}
+	if out[0].Kind() != reflect.Pointer || !out[0].IsNil() {
+		data = out[0].Interface()
+	}
+	return data, true, nil, nil
+}
+
+func isStructOrStructPointer(ty reflect.Type) bool {
+	return ty.Kind() == reflect.Struct ||
+		(ty.Kind() == reflect.Pointer && ty.Elem().Kind() == reflect.Struct)
+}
+
+// checkRequestType verifies the tags of a request struct type at handler
+// build time
+func checkRequestType(ty reflect.Type) error {
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	for _, check := range []func(reflect.Type) error{
+		checkModifiers,
+		checkEnums,
+		checkPatterns,
+		checkIDSafeTags,
+		checkRequireTags,
+		checkIfMatchTags,
+		checkHostTags,
+		checkTLSTags,
+		checkPseudoTags,
+		checkMultipartTags,
+		checkUnitTags,
+		checkNumberFormatTags,
+	} {
+		if err := check(ty); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package ginbinding
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type signatureTestRequest struct {
+	ID int `path:"id"`
+}
+
+type signatureTestResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestTypedResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req signatureTestRequest) (*signatureTestResponse, error) {
+		if req.ID == 0 {
+			return nil, nil
+		}
+		return &signatureTestResponse{ID: req.ID, Name: "alice"}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/users/:id", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/3", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": float64(3), "name": "alice"}, response["data"])
+
+	// A nil typed response is treated as no data
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/users/0", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"success"}`, w.Body.String())
+}
+
+func TestDescribe(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	tests := []struct {
+		name     string
+		handler  any
+		expected HandlerMetadata
+	}{
+		{
+			name: "typed response",
+			handler: func(c *gin.Context, req signatureTestRequest) (signatureTestResponse, error) {
+				return signatureTestResponse{}, nil
+			},
+			expected: HandlerMetadata{
+				RequestType:  reflect.TypeOf(signatureTestRequest{}),
+				ResponseType: reflect.TypeOf(signatureTestResponse{}),
+			},
+		},
+		{
+			name: "any response without request",
+			handler: func(ctx context.Context) (any, error) {
+				return nil, nil
+			},
+			expected: HandlerMetadata{
+				ResponseType: reflect.TypeOf((*any)(nil)).Elem(),
+			},
+		},
+		{
+			name: "pointer request without data",
+			handler: func(req *signatureTestRequest) error {
+				return nil
+			},
+			expected: HandlerMetadata{
+				RequestType: reflect.TypeOf(&signatureTestRequest{}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := builder.Describe(tt.handler)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, meta)
+		})
+	}
+
+	_, err := builder.Describe(nil)
+	assert.EqualError(t, err, "input must be a function")
+}
+
+func TestQueryBodySplit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type query struct {
+		ID   int    `path:"id"`
+		Name string `form:"name"`
+	}
+	type body struct {
+		Name string `json:"name" default:"unnamed"`
+	}
+
+	handler := func(c *gin.Context, q query, b *body) (interface{}, error) {
+		return gin.H{"id": q.ID, "query_name": q.Name, "body_name": b.Name}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.PUT("/items/:id", ginHandler)
+
+	tests := []struct {
+		name     string
+		url      string
+		body     string
+		expected map[string]interface{}
+	}{
+		{
+			name:     "both sources",
+			url:      "/items/4?name=from-query",
+			body:     `{"name":"from-body"}`,
+			expected: map[string]interface{}{"id": float64(4), "query_name": "from-query", "body_name": "from-body"},
+		},
+		{
+			name:     "sources do not leak into each other",
+			url:      "/items/4",
+			body:     `{}`,
+			expected: map[string]interface{}{"id": float64(4), "query_name": "", "body_name": "unnamed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, response["data"])
+		})
+	}
+
+	meta, err := builder.Describe(handler)
+	assert.NoError(t, err)
+	assert.Equal(t, reflect.TypeOf(query{}), meta.RequestType)
+	assert.Equal(t, reflect.TypeOf(&body{}), meta.BodyType)
+}
+
+func TestDirectHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context) (any, error) {
+		if c.Query("fail") != "" {
+			return nil, ErrNotFound
+		}
+		return gin.H{"status": "up"}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	sig, err := builder.analyzeHandler(handler)
+	assert.NoError(t, err)
+	assert.NotNil(t, sig.direct)
+	assert.Nil(t, sig.args)
+
+	sig, err = builder.Clone(WithTimeout(time.Second)).analyzeHandler(handler)
+	assert.NoError(t, err)
+	assert.Nil(t, sig.direct)
+
+	router := gin.New()
+	router.GET("/health", builder.MustFormBindingGinHandlerFunc(handler))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/health", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"success","data":{"status":"up"}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/health?fail=1", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
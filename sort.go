@@ -0,0 +1,149 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SortDirection is the direction of a single sort key
+type SortDirection string
+
+const (
+	// SortAsc sorts in ascending order
+	SortAsc SortDirection = "asc"
+	// SortDesc sorts in descending order
+	SortDesc SortDirection = "desc"
+)
+
+// SortField is a single entry of a Sort parameter
+type SortField struct {
+	Field     string        `json:"field"`
+	Direction SortDirection `json:"direction"`
+}
+
+// Sort is an ordered list of sort keys parsed from a parameter such as
+// "-created_at,name", where a leading "-" sorts descending and an optional
+// leading "+" sorts ascending. Use WithSortFields to restrict the accepted keys.
+type Sort []SortField
+
+var sortTy = reflect.TypeOf(Sort{})
+
+// ParseSort parses a comma separated sort expression
+func ParseSort(s string) (Sort, error) {
+	var sort Sort
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := SortField{Field: part, Direction: SortAsc}
+		switch part[0] {
+		case '-':
+			field.Field, field.Direction = part[1:], SortDesc
+		case '+':
+			field.Field = part[1:]
+		}
+
+		if field.Field == "" {
+			return nil, fmt.Errorf("invalid sort expression %q", s)
+		}
+		sort = append(sort, field)
+	}
+
+	return sort, nil
+}
+
+// UnmarshalParam implements binding.BindUnmarshaler for query, form and path binding
+func (s *Sort) UnmarshalParam(param string) error {
+	sort, err := ParseSort(param)
+	if err != nil {
+		return err
+	}
+	*s = sort
+	return nil
+}
+
+// UnmarshalJSON accepts either a sort expression string or an array of sort
+// fields. Fields of the array need a name, and a direction of asc or desc,
+// which defaults to asc when omitted.
+func (s *Sort) UnmarshalJSON(data []byte) error {
+	var expr string
+	if err := json.Unmarshal(data, &expr); err == nil {
+		return s.UnmarshalParam(expr)
+	}
+
+	var fields []SortField
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for i, f := range fields {
+		if strings.TrimSpace(f.Field) == "" {
+			return fmt.Errorf("sort field %d has no name", i)
+		}
+		switch f.Direction {
+		case SortAsc, SortDesc:
+		case "":
+			fields[i].Direction = SortAsc
+		default:
+			return fmt.Errorf("invalid direction %q of sort field %q, want %s or %s", f.Direction, f.Field, SortAsc, SortDesc)
+		}
+	}
+	*s = fields
+	return nil
+}
+
+// String formats the sort back into its "-created_at,name" form
+func (s Sort) String() string {
+	parts := make([]string, len(s))
+	for i, f := range s {
+		if f.Direction == SortDesc {
+			parts[i] = "-" + f.Field
+		} else {
+			parts[i] = f.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// WithSortFields restricts the keys accepted by Sort fields of the request
+// struct. Requests using any other key are rejected with a BindingError.
+func WithSortFields(fields ...string) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.sortFields = fields
+	}
+}
+
+// checkSortFields rejects Sort values using keys outside the configured whitelist
+func (builder *BasicFormBindingGinHandlerBuilder) checkSortFields(val reflect.Value) error {
+	if len(builder.sortFields) == 0 || !typeInfoOf(val.Type()).sort {
+		return nil
+	}
+
+	return walkFields(val, "", func(path string, sf reflect.StructField, fieldVal reflect.Value) error {
+		fieldVal = reflect.Indirect(fieldVal)
+		if !fieldVal.IsValid() || fieldVal.Type() != sortTy {
+			return nil
+		}
+
+		for _, f := range fieldVal.Interface().(Sort) {
+			if !containsString(builder.sortFields, f.Field) {
+				return fmt.Errorf("field %s: unknown sort field %q, allowed: [%s]",
+					path, f.Field, strings.Join(builder.sortFields, ", "))
+			}
+		}
+		return nil
+	})
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
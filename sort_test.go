@@ -0,0 +1,175 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	sort, err := ParseSort("-created_at, name,+age")
+	assert.NoError(t, err)
+	assert.Equal(t, Sort{
+		{Field: "created_at", Direction: SortDesc},
+		{Field: "name", Direction: SortAsc},
+		{Field: "age", Direction: SortAsc},
+	}, sort)
+	assert.Equal(t, "-created_at,name,age", sort.String())
+
+	sort, err = ParseSort("")
+	assert.NoError(t, err)
+	assert.Empty(t, sort)
+
+	_, err = ParseSort("name,-")
+	assert.EqualError(t, err, `invalid sort expression "name,-"`)
+}
+
+func TestSortBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Sort Sort `form:"sort" default:"-created_at"`
+	}) (interface{}, error) {
+		return gin.H{"sort": req.Sort}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler, WithSortFields("created_at", "name"))
+	assert.NoError(t, err)
+
+	// Handler options must not leak into the shared builder
+	assert.Nil(t, builder.sortFields)
+
+	router := gin.New()
+	router.GET("/users", ginHandler)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedSort   []interface{}
+		expectedMsg    string
+	}{
+		{
+			name:           "explicit sort",
+			query:          "?sort=name,-created_at",
+			expectedStatus: http.StatusOK,
+			expectedSort: []interface{}{
+				map[string]interface{}{"field": "name", "direction": "asc"},
+				map[string]interface{}{"field": "created_at", "direction": "desc"},
+			},
+		},
+		{
+			name:           "default sort",
+			query:          "",
+			expectedStatus: http.StatusOK,
+			expectedSort: []interface{}{
+				map[string]interface{}{"field": "created_at", "direction": "desc"},
+			},
+		},
+		{
+			name:           "unknown sort field",
+			query:          "?sort=-password",
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `field Sort: unknown sort field "password", allowed: [created_at, name]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/users"+tt.query, nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			if tt.expectedSort != nil {
+				data := response["data"].(map[string]interface{})
+				assert.Equal(t, tt.expectedSort, data["sort"])
+			}
+			if tt.expectedMsg != "" {
+				assert.Equal(t, tt.expectedMsg, response["message"])
+			}
+		})
+	}
+}
+
+func TestSortBindingFromJSONBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Sort Sort `json:"sort"`
+	}) (interface{}, error) {
+		return gin.H{"sort": req.Sort.String()}, nil
+	}
+
+	// Without WithSortFields every key is accepted
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/search", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/search", strings.NewReader(`{"sort":"-score,anything"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"sort":"-score,anything"`)
+}
+
+func TestSortUnmarshalJSONArray(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expected    Sort
+		expectedErr string
+	}{
+		{
+			name:     "fields",
+			data:     `[{"field":"score","direction":"desc"},{"field":"name","direction":"asc"}]`,
+			expected: Sort{{Field: "score", Direction: SortDesc}, {Field: "name", Direction: SortAsc}},
+		},
+		{
+			name:     "omitted direction",
+			data:     `[{"field":"name"}]`,
+			expected: Sort{{Field: "name", Direction: SortAsc}},
+		},
+		{
+			name:        "invalid direction",
+			data:        `[{"field":"name","direction":"asc; DROP TABLE users"}]`,
+			expectedErr: `invalid direction "asc; DROP TABLE users" of sort field "name", want asc or desc`,
+		},
+		{
+			name:        "empty field",
+			data:        `[{"field":"","direction":"asc"}]`,
+			expectedErr: "sort field 0 has no name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sort Sort
+			err := json.Unmarshal([]byte(tt.data), &sort)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sort)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SparseFieldsParam is the query parameter selecting the fields of success
+// responses with WithSparseFieldsets
+const SparseFieldsParam = "fields"
+
+// WithSparseFieldsets lets clients select the fields of the success data with
+// the fields query parameter, e.g. ?fields=id,name,author.name. The JSON keys
+// of objects, or of each object of arrays, are pruned to the requested ones;
+// dotted paths select keys of nested objects. Only the listed fields may be
+// requested, and a field allows every key below it. Requests for any other
+// field are rejected with a BindingError before the handler is called.
+// Responses of requests without the parameter are left unchanged.
+func WithSparseFieldsets(allowed ...string) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.sparseFields = allowed
+	}
+}
+
+// fieldTree is a set of requested field paths. A nil subtree selects the
+// whole value of its key.
+type fieldTree map[string]fieldTree
+
+// sparseFieldset returns the fields requested by the request of ctx, or nil
+// when it does not select any
+func (builder *BasicFormBindingGinHandlerBuilder) sparseFieldset(ctx *gin.Context) (fieldTree, error) {
+	if builder.sparseFields == nil {
+		return nil, nil
+	}
+	param, ok := ctx.GetQuery(SparseFieldsParam)
+	if !ok {
+		return nil, nil
+	}
+
+	tree := fieldTree{}
+	for _, path := range strings.Split(param, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if !builder.sparseFieldAllowed(path) {
+			err := &BindingError{
+				Err:    fmt.Errorf("unknown field %q, allowed: [%s]", path, strings.Join(builder.sparseFields, ", ")),
+				Source: SourceQuery,
+				Field:  SparseFieldsParam,
+				Value:  path,
+			}
+			if builder.redactErrors {
+				err.Value = ""
+			}
+			return nil, err
+		}
+		tree.add(strings.Split(path, "."))
+	}
+	if len(tree) == 0 {
+		return nil, nil
+	}
+	return tree, nil
+}
+
+// sparseFieldAllowed reports whether the field path is, or is below, one of
+// the fields allowed by WithSparseFieldsets
+func (builder *BasicFormBindingGinHandlerBuilder) sparseFieldAllowed(path string) bool {
+	for _, allowed := range builder.sparseFields {
+		if path == allowed || strings.HasPrefix(path, allowed+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// add adds the field path to t
+func (t fieldTree) add(path []string) {
+	sub, ok := t[path[0]]
+	switch {
+	case ok && sub == nil:
+		// The whole value is already selected
+	case len(path) == 1:
+		t[path[0]] = nil
+	default:
+		if sub == nil {
+			sub = fieldTree{}
+			t[path[0]] = sub
+		}
+		sub.add(path[1:])
+	}
+}
+
+// prune returns the JSON representation of data reduced to the fields of t.
+// Streamed responses are returned unchanged, as is all data when t is nil.
+func (t fieldTree) prune(data any) (any, error) {
+	if t == nil || data == nil {
+		return data, nil
+	}
+	if _, ok := responseStream(data); ok {
+		return data, nil
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return t.pruneValue(v), nil
+}
+
+func (t fieldTree) pruneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		pruned := make(map[string]any, len(t))
+		for key, sub := range t {
+			field, ok := v[key]
+			if !ok {
+				continue
+			}
+			if sub != nil {
+				field = sub.pruneValue(field)
+			}
+			pruned[key] = field
+		}
+		return pruned
+	case []any:
+		for i := range v {
+			v[i] = t.pruneValue(v[i])
+		}
+		return v
+	}
+	return v
+}
@@ -0,0 +1,65 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSparseFieldsets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type author struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	type post struct {
+		ID       int64  `json:"id"`
+		Title    string `json:"title"`
+		Author   author `json:"author"`
+		Internal string `json:"internal"`
+	}
+	posts := []post{
+		{ID: 9007199254740993, Title: "Hello", Author: author{Name: "ann", Email: "ann@example.com"}, Internal: "x"},
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	router := gin.New()
+	router.GET("/posts", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) ([]post, error) {
+		return posts, nil
+	}, WithSparseFieldsets("id", "title", "author")))
+	router.GET("/post", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (post, error) {
+		return posts[0], nil
+	}, WithSparseFieldsets("id", "author.name")))
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "array", path: "/posts?fields=id,title", expectedStatus: http.StatusOK, expectedBody: `{"data":[{"id":9007199254740993,"title":"Hello"}],"status":"success"}`},
+		{name: "nested path", path: "/posts?fields=author.email", expectedStatus: http.StatusOK, expectedBody: `{"data":[{"author":{"email":"ann@example.com"}}],"status":"success"}`},
+		{name: "object", path: "/post?fields=id,author.name", expectedStatus: http.StatusOK, expectedBody: `{"data":{"author":{"name":"ann"},"id":9007199254740993},"status":"success"}`},
+		{name: "not allowed", path: "/posts?fields=id,internal", expectedStatus: http.StatusBadRequest},
+		{name: "not allowed below allowed", path: "/post?fields=author", expectedStatus: http.StatusBadRequest},
+		{name: "no selection", path: "/post", expectedStatus: http.StatusOK, expectedBody: `{"data":{"id":9007199254740993,"title":"Hello","author":{"name":"ann","email":"ann@example.com"},"internal":"x"},"status":"success"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
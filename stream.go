@@ -0,0 +1,155 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MIMENDJSON is the media type of newline-delimited JSON bodies
+const MIMENDJSON = "application/x-ndjson"
+
+// Stream is a handler parameter reading the items of an application/x-ndjson
+// request body one at a time, for endpoints such as bulk imports whose
+// payload should not be held in memory at once:
+//
+//	func importUsers(c *gin.Context, users ginbinding.Stream[User]) (int, error) {
+//		n := 0
+//		for user, err := range users.All() {
+//			if err != nil {
+//				return n, err
+//			}
+//			...
+//			n++
+//		}
+//		return n, nil
+//	}
+//
+// It takes the place of the request struct, or of the body struct after a
+// struct binding the other sources. Items of struct types go through the
+// stages of the pipeline between Bind and Handle, so that they get their
+// defaults and are validated. Items that cannot be decoded or fail validation
+// are reported as a BindingError, which ends the stream. Bodies of any other
+// media type are rejected with ErrUnsupportedMediaType before the handler is
+// called.
+type Stream[T any] struct {
+	src *streamSource
+}
+
+// streamSource is the request body read by a Stream
+type streamSource struct {
+	ctx     *gin.Context
+	builder *BasicFormBindingGinHandlerBuilder
+	dec     *json.Decoder
+	// items counts the items read
+	items int
+	// err ends the stream
+	err error
+}
+
+// streamParam is implemented by pointers to Stream types
+type streamParam interface {
+	setSource(src *streamSource)
+}
+
+var streamParamTy = reflect.TypeOf((*streamParam)(nil)).Elem()
+
+func (s *Stream[T]) setSource(src *streamSource) {
+	s.src = src
+}
+
+// isStreamType reports whether ty is a Stream type
+func isStreamType(ty reflect.Type) bool {
+	return ty.Kind() == reflect.Struct && reflect.PointerTo(ty).Implements(streamParamTy)
+}
+
+// Next returns the next item of the stream. It returns io.EOF once every item
+// was read, and the error that ended the stream after a failure.
+func (s Stream[T]) Next() (T, error) {
+	var item T
+	src := s.src
+	if src == nil {
+		return item, io.EOF
+	}
+	if src.err != nil {
+		return item, src.err
+	}
+
+	if err := src.dec.Decode(&item); err != nil {
+		if errors.Is(err, io.EOF) {
+			src.err = io.EOF
+		} else {
+			src.err = src.builder.bindingError(reflect.TypeOf(item), &inputError{source: SourceBody, err: fmt.Errorf("item %d: %w", src.items+1, err)})
+		}
+		return item, src.err
+	}
+	src.items++
+
+	if err := src.process(reflect.ValueOf(&item).Elem()); err != nil {
+		src.err = err
+		return item, err
+	}
+	return item, nil
+}
+
+// All iterates over the items of the stream. Iteration ends after the first
+// error, which is yielded with the zero value.
+func (s Stream[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			item, err := s.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(item, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// process runs the decoded item val through the stages of the pipeline when
+// it is a struct or a non-nil pointer to one
+func (src *streamSource) process(val reflect.Value) error {
+	ty := val.Type()
+	switch {
+	case ty.Kind() == reflect.Struct:
+		val = val.Addr()
+	case ty.Kind() == reflect.Pointer && ty.Elem().Kind() == reflect.Struct && !val.IsNil():
+	default:
+		return nil
+	}
+
+	// Items are bound from the body alone, so their unbindable fields are
+	// zeroed
+	if fields := typeInfoOf(val.Type().Elem()).unbindable; fields != nil {
+		resetUnbindable(val.Elem(), reflect.Value{}, fields)
+	}
+	return src.builder.runStages(src.ctx, ty, val, nil)
+}
+
+// bindStream returns a value of the Stream type ty reading the body of ctx
+func (builder *BasicFormBindingGinHandlerBuilder) bindStream(ctx *gin.Context, ty reflect.Type) (reflect.Value, error) {
+	stream := reflect.New(ty)
+	body := ctx.Request.Body
+	if body == nil || body == http.NoBody || ctx.Request.ContentLength == 0 {
+		return stream.Elem(), nil
+	}
+
+	if contentType := ctx.ContentType(); contentType != MIMENDJSON {
+		return reflect.Value{}, fmt.Errorf("content type %q is not %s: %w", contentType, MIMENDJSON, ErrUnsupportedMediaType)
+	}
+
+	dec := json.NewDecoder(body)
+	if builder.useNumber {
+		dec.UseNumber()
+	}
+	stream.Interface().(streamParam).setSource(&streamSource{ctx: ctx, builder: builder, dec: dec})
+	return stream.Elem(), nil
+}
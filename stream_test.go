@@ -0,0 +1,89 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type importQuery struct {
+		Source string `form:"source"`
+	}
+	type user struct {
+		Name string `json:"name" binding:"required"`
+		Role string `json:"role" default:"member"`
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	router := gin.New()
+	router.POST("/import", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context, q importQuery, users Stream[user]) (map[string]any, error) {
+		var names []string
+		for u, err := range users.All() {
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, u.Name+":"+u.Role)
+		}
+		return map[string]any{"source": q.Source, "names": names}, nil
+	}))
+
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		expectedStatus int
+		expectedNames  []any
+	}{
+		{name: "items", contentType: MIMENDJSON, body: "{\"name\":\"ann\"}\n{\"name\":\"bob\",\"role\":\"admin\"}\n", expectedStatus: http.StatusOK, expectedNames: []any{"ann:member", "bob:admin"}},
+		{name: "empty", contentType: MIMENDJSON, expectedStatus: http.StatusOK},
+		{name: "invalid item", contentType: MIMENDJSON, body: "{\"name\":\"ann\"}\n{\"role\":\"admin\"}\n", expectedStatus: http.StatusBadRequest},
+		{name: "malformed item", contentType: MIMENDJSON, body: "{\"name\":\"ann\"}\n{\"name\":\n", expectedStatus: http.StatusBadRequest},
+		{name: "wrong content type", contentType: "application/json", body: `{"name":"ann"}`, expectedStatus: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/import?source=csv", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data map[string]any `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, "csv", body.Data["source"])
+			if tt.expectedNames != nil {
+				assert.Equal(t, tt.expectedNames, body.Data["names"])
+			}
+		})
+	}
+}
+
+func TestStreamSignature(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	meta, err := builder.Describe(func(c *gin.Context, items Stream[int]) error { return nil })
+	require.NoError(t, err)
+	assert.Nil(t, meta.RequestType)
+	assert.Equal(t, reflect.TypeOf(Stream[int]{}), meta.BodyType)
+
+	_, err = builder.Describe(func(c *gin.Context, items Stream[int], other Stream[int]) error { return nil })
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultStreamFlushEvery is the number of items of a streamed response
+// written between flushes unless WithStreamFlushEvery sets another
+const DefaultStreamFlushEvery = 64
+
+// WithStreamFlushEvery flushes streamed responses after every n items
+// instead of every DefaultStreamFlushEvery items. n is at least 1.
+func WithStreamFlushEvery(n int) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.streamFlushEvery = max(n, 1)
+	}
+}
+
+// itemStream yields the items of a streamed response, with a non-nil error
+// ending the stream
+type itemStream func(yield func(item any, err error) bool)
+
+// responseStream returns the items of data if it is a streamed response: a
+// receive channel, an iter.Seq or an iter.Seq2 whose values are errors
+func responseStream(data any) (itemStream, bool) {
+	if data == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(data)
+	ty := v.Type()
+
+	switch ty.Kind() {
+	case reflect.Chan:
+		if ty.ChanDir()&reflect.RecvDir == 0 || v.IsNil() {
+			return nil, false
+		}
+		return func(yield func(any, error) bool) {
+			for {
+				item, ok := v.Recv()
+				if !ok || !yield(item.Interface(), nil) {
+					return
+				}
+			}
+		}, true
+
+	case reflect.Func:
+		if ty.NumIn() != 1 || ty.NumOut() != 0 || v.IsNil() {
+			return nil, false
+		}
+		yieldTy := ty.In(0)
+		if yieldTy.Kind() != reflect.Func || yieldTy.NumOut() != 1 || yieldTy.Out(0).Kind() != reflect.Bool {
+			return nil, false
+		}
+		switch {
+		case yieldTy.NumIn() == 1:
+		case yieldTy.NumIn() == 2 && yieldTy.In(1) == errTy:
+		default:
+			return nil, false
+		}
+		return func(yield func(any, error) bool) {
+			v.Call([]reflect.Value{reflect.MakeFunc(yieldTy, func(args []reflect.Value) []reflect.Value {
+				var err error
+				if len(args) == 2 && !args[1].IsNil() {
+					err = args[1].Interface().(error)
+				}
+				return []reflect.Value{reflect.ValueOf(yield(args[0].Interface(), err))}
+			})})
+		}, true
+	}
+	return nil, false
+}
+
+// writeStream writes the items of items as a JSON array, or as NDJSON when
+// the client accepts application/x-ndjson, flushing periodically. It returns
+// the number of items it started writing and the error that ended the stream,
+// if any, in which case the response is left unfinished. Nothing is written
+// when the stream fails before its first item.
+func (builder *BasicFormBindingGinHandlerBuilder) writeStream(ctx *gin.Context, items itemStream) (int, error) {
+	ndjson := strings.Contains(ctx.GetHeader("Accept"), MIMENDJSON)
+	flushEvery := builder.streamFlushEvery
+	if flushEvery == 0 {
+		flushEvery = DefaultStreamFlushEvery
+	}
+
+	w := ctx.Writer
+	start := func() {
+		if ndjson {
+			ctx.Header("Content-Type", MIMENDJSON)
+		} else {
+			ctx.Header("Content-Type", "application/json; charset=utf-8")
+		}
+		w.WriteHeader(http.StatusOK)
+		if !ndjson {
+			_, _ = w.WriteString("[")
+		}
+	}
+
+	n := 0
+	var failed error
+	items(func(item any, err error) bool {
+		var b []byte
+		if err == nil {
+			b, err = json.Marshal(item)
+		}
+		if err != nil {
+			failed = err
+			return false
+		}
+
+		if n == 0 {
+			start()
+		} else if !ndjson {
+			_, _ = w.WriteString(",")
+		}
+		if ndjson {
+			b = append(b, '\n')
+		}
+		n++
+		if _, err := w.Write(b); err != nil {
+			failed = err
+			return false
+		}
+		if n%flushEvery == 0 {
+			w.Flush()
+		}
+		// Stop producing items for clients that went away
+		return ctx.Request.Context().Err() == nil
+	})
+	if failed != nil {
+		return n, failed
+	}
+
+	if n == 0 {
+		start()
+	}
+	if !ndjson {
+		_, _ = w.WriteString("]")
+	}
+	w.Flush()
+	return n, nil
+}
@@ -0,0 +1,96 @@
+package ginbinding
+
+import (
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamedResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	var hookErr error
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithStreamFlushEvery(1))
+	builder.OnError(func(ctx *gin.Context, req any, err error) { hookErr = err })
+
+	router := gin.New()
+	router.GET("/seq", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (iter.Seq[item], error) {
+		return func(yield func(item) bool) {
+			for i := 1; i <= 3; i++ {
+				if !yield(item{ID: i}) {
+					return
+				}
+			}
+		}, nil
+	}))
+	router.GET("/chan", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (<-chan item, error) {
+		ch := make(chan item)
+		go func() {
+			defer close(ch)
+			ch <- item{ID: 1}
+			ch <- item{ID: 2}
+		}()
+		return ch, nil
+	}))
+	router.GET("/empty", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (iter.Seq[item], error) {
+		return func(yield func(item) bool) {}, nil
+	}))
+	router.GET("/fail-first", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (iter.Seq2[item, error], error) {
+		return func(yield func(item, error) bool) {
+			yield(item{}, ErrNotFound)
+		}, nil
+	}))
+	router.GET("/fail-later", builder.MustFormBindingGinHandlerFunc(func(c *gin.Context) (iter.Seq2[item, error], error) {
+		return func(yield func(item, error) bool) {
+			if yield(item{ID: 1}, nil) {
+				yield(item{}, errors.New("database gone"))
+			}
+		}, nil
+	}))
+
+	tests := []struct {
+		name           string
+		path           string
+		accept         string
+		expectedStatus int
+		expectedType   string
+		expectedBody   string
+	}{
+		{name: "seq as array", path: "/seq", expectedStatus: http.StatusOK, expectedType: "application/json; charset=utf-8", expectedBody: `[{"id":1},{"id":2},{"id":3}]`},
+		{name: "seq as ndjson", path: "/seq", accept: MIMENDJSON, expectedStatus: http.StatusOK, expectedType: MIMENDJSON, expectedBody: "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"},
+		{name: "channel", path: "/chan", expectedStatus: http.StatusOK, expectedBody: `[{"id":1},{"id":2}]`},
+		{name: "empty", path: "/empty", expectedStatus: http.StatusOK, expectedBody: `[]`},
+		{name: "fails before first item", path: "/fail-first", expectedStatus: http.StatusNotFound},
+		{name: "fails later", path: "/fail-later", expectedStatus: http.StatusOK, expectedBody: `[{"id":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedType != "" {
+				assert.Equal(t, tt.expectedType, w.Header().Get("Content-Type"))
+			}
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+
+	assert.EqualError(t, hookErr, "database gone")
+}
@@ -0,0 +1,60 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"strings"
+)
+
+// pathTag returns the path parameter name of sf from its "path" tag, or from
+// the "uri" tag used by gin's ShouldBindUri
+func pathTag(sf reflect.StructField) (string, bool) {
+	if key, ok := sf.Tag.Lookup("path"); ok {
+		return key, true
+	}
+	if tag, ok := sf.Tag.Lookup("uri"); ok {
+		key, _, _ := strings.Cut(tag, ",")
+		return key, true
+	}
+	return "", false
+}
+
+// queryTag returns the query tag of sf, either "form" or its "query" alias
+func queryTag(sf reflect.StructField) (string, bool) {
+	if tag, ok := sf.Tag.Lookup("form"); ok {
+		return tag, true
+	}
+	return sf.Tag.Lookup("query")
+}
+
+// headerField is a header tag along with the header name it binds
+type headerField struct {
+	// key is the name as written in the tag, which gin's mapping looks up
+	key string
+	// name is the canonical form of key
+	name string
+}
+
+// canonicalHeaderName returns the canonical form of the header name of a
+// header tag, so that tags such as header:"x-api-key" bind however they are
+// cased. The "*" of wildcard tags is kept.
+func canonicalHeaderName(key string) string {
+	if prefix, ok := strings.CutSuffix(key, "*"); ok {
+		return textproto.CanonicalMIMEHeaderKey(prefix) + "*"
+	}
+	return textproto.CanonicalMIMEHeaderKey(key)
+}
+
+// headerValuesFold returns the values of the header with the canonical name,
+// including those stored under keys cased differently, which requests built
+// by setting the header map directly may contain
+func headerValuesFold(header http.Header, name string) []string {
+	values := header[name]
+	for key, v := range header {
+		if key != name && strings.EqualFold(key, name) {
+			values = append(values[:len(values):len(values)], v...)
+		}
+	}
+	return values
+}
@@ -0,0 +1,272 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// defaultTimeFormats are tried in order when a time.Time field has no time_format tag
+var defaultTimeFormats = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05Z",
+	"2006-01-02T15:04:05.000Z",
+	"2006-01-02",
+	"15:04:05",
+}
+
+// isUnixTimeFormat reports whether the time_format tag denotes an epoch timestamp
+func isUnixTimeFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case "unix", "unixmilli", "unixmicro", "unixnano":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseTime parses s according to the time_format tag value. Epoch formats
+// (unix, unixmilli, unixmicro, unixnano) expect an integer, any other
+// non-empty format is used as a layout before falling back to the default formats.
+// Values without zone information are interpreted in loc.
+func parseTime(s string, format string, loc *time.Location) (time.Time, error) {
+	if isUnixTimeFormat(format) {
+		return parseUnixTime(s, format)
+	}
+
+	formats := defaultTimeFormats
+	if format != "" {
+		formats = append([]string{format}, defaultTimeFormats...)
+	}
+
+	var parsedTime time.Time
+	var parseErr error
+
+	for _, f := range formats {
+		parsedTime, parseErr = time.ParseInLocation(f, s, loc)
+		if parseErr == nil {
+			return parsedTime, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time format %q: %w", s, parseErr)
+}
+
+// parseUnixTime converts an integer epoch value in the given unit to a UTC time
+func parseUnixTime(s string, format string) (time.Time, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s timestamp %q: %w", strings.ToLower(format), s, err)
+	}
+
+	switch strings.ToLower(format) {
+	case "unix":
+		return time.Unix(v, 0).UTC(), nil
+	case "unixmilli":
+		return time.UnixMilli(v).UTC(), nil
+	case "unixmicro":
+		return time.UnixMicro(v).UTC(), nil
+	default:
+		return time.Unix(0, v).UTC(), nil
+	}
+}
+
+// unixTimeJSON locates the time.Time fields with an epoch time_format in the
+// JSON encoding of a type: format is set for such a field, fields for a struct
+// holding some, and elem for slices, arrays and maps of them
+type unixTimeJSON struct {
+	format string
+	fields map[string]*unixTimeJSON
+	elem   *unixTimeJSON
+}
+
+// unixTimeJSONOf returns the unixTimeJSON of ty, or nil when its JSON holds no
+// epoch time fields. Embedded structs are flattened the same way encoding/json
+// does. seen holds the struct types being visited, so that recursive types end.
+func unixTimeJSONOf(ty reflect.Type, seen map[reflect.Type]bool) *unixTimeJSON {
+	for ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+
+	switch ty.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if elem := unixTimeJSONOf(ty.Elem(), seen); elem != nil {
+			return &unixTimeJSON{elem: elem}
+		}
+		return nil
+	case reflect.Struct:
+		if ty == timeTy || seen[ty] {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	fields := make(map[string]*unixTimeJSON)
+	collectUnixTimeJSON(ty, fields, seen)
+	if len(fields) == 0 {
+		return nil
+	}
+	return &unixTimeJSON{fields: fields}
+}
+
+// collectUnixTimeJSON adds the epoch time fields of the struct type ty to
+// fields, by JSON key
+func collectUnixTimeJSON(ty reflect.Type, fields map[string]*unixTimeJSON, seen map[reflect.Type]bool) {
+	seen[ty] = true
+	defer delete(seen, ty)
+
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+
+		jsonKey, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if jsonKey == "-" {
+			continue
+		}
+
+		fieldTy := sf.Type
+		if fieldTy.Kind() == reflect.Pointer {
+			fieldTy = fieldTy.Elem()
+		}
+
+		if sf.Anonymous && jsonKey == "" && fieldTy.Kind() == reflect.Struct {
+			if !seen[fieldTy] {
+				collectUnixTimeJSON(fieldTy, fields, seen)
+			}
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+		if jsonKey == "" {
+			jsonKey = sf.Name
+		}
+
+		if fieldTy == timeTy {
+			if format := sf.Tag.Get("time_format"); isUnixTimeFormat(format) {
+				fields[jsonKey] = &unixTimeJSON{format: format}
+			}
+		} else if nested := unixTimeJSONOf(fieldTy, seen); nested != nil {
+			fields[jsonKey] = nested
+		}
+	}
+}
+
+// rewrite replaces the numeric epoch values in raw with RFC3339 strings, and
+// reports whether any was replaced. path is the JSON path of raw, for errors.
+func (u *unixTimeJSON) rewrite(raw json.RawMessage, path string) (json.RawMessage, bool, error) {
+	if u.format != "" {
+		var num json.Number
+		if err := json.Unmarshal(raw, &num); err != nil || num == "" {
+			// Not a number, e.g. already an RFC3339 string or null
+			return raw, false, nil
+		}
+
+		t, err := parseUnixTime(num.String(), u.format)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to parse body field %q: %w", path, err)
+		}
+		raw, err = json.Marshal(t.Format(time.RFC3339Nano))
+		return raw, true, err
+	}
+
+	if u.elem != nil {
+		var arr []json.RawMessage
+		if err := json.Unmarshal(raw, &arr); err == nil {
+			rewritten := false
+			for i := range arr {
+				elem, ok, err := u.elem.rewrite(arr[i], joinJSONPath(path, strconv.Itoa(i)))
+				if err != nil {
+					return nil, false, err
+				}
+				arr[i], rewritten = elem, rewritten || ok
+			}
+			return marshalRewritten(raw, arr, rewritten)
+		}
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		// Malformed values are left to the regular JSON binding to report
+		return raw, false, nil
+	}
+
+	rewritten := false
+	for key, value := range obj {
+		field := u.elem
+		if field == nil {
+			var ok bool
+			if field, ok = lookupJSONField(u.fields, key); !ok {
+				continue
+			}
+		}
+
+		value, ok, err := field.rewrite(value, joinJSONPath(path, key))
+		if err != nil {
+			return nil, false, err
+		}
+		obj[key], rewritten = value, rewritten || ok
+	}
+	return marshalRewritten(raw, obj, rewritten)
+}
+
+// marshalRewritten returns the JSON of v when rewritten is set, and raw otherwise
+func marshalRewritten(raw json.RawMessage, v any, rewritten bool) (json.RawMessage, bool, error) {
+	if !rewritten {
+		return raw, false, nil
+	}
+	data, err := json.Marshal(v)
+	return data, true, err
+}
+
+// rewriteUnixTimeJSON replaces numeric epoch values in a JSON request body with
+// RFC3339 strings so encoding/json can decode them into time.Time fields,
+// including those of nested structs, slices and maps
+func rewriteUnixTimeJSON(ctx *gin.Context, fields *unixTimeJSON) error {
+	if ctx.Request.Body == nil || ctx.ContentType() != binding.MIMEJSON {
+		return nil
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return err
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	body, rewritten, err := fields.rewrite(body, "")
+	if err != nil || !rewritten {
+		return err
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	ctx.Request.ContentLength = int64(len(body))
+
+	return nil
+}
+
+// lookupJSONField matches a JSON key to a field name the way encoding/json
+// does: exact match first, then case-insensitive
+func lookupJSONField[T any](fields map[string]T, key string) (T, bool) {
+	if v, ok := fields[key]; ok {
+		return v, true
+	}
+
+	for k, v := range fields {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
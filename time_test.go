@@ -0,0 +1,223 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnixTimeFormatBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		PathTime    time.Time  `path:"ts" time_format:"unix"`
+		QueryTime   time.Time  `form:"since" time_format:"unixmilli"`
+		BodyTime    time.Time  `json:"created" time_format:"unix"`
+		BodyPtr     *time.Time `json:"updated" time_format:"unixmilli"`
+		DefaultTime time.Time  `json:"expires" time_format:"unix" default:"1700000000"`
+	}) (interface{}, error) {
+		return gin.H{
+			"path":    req.PathTime.UTC().Format(time.RFC3339),
+			"query":   req.QueryTime.UTC().Format(time.RFC3339Nano),
+			"body":    req.BodyTime.UTC().Format(time.RFC3339),
+			"ptr":     req.BodyPtr.UTC().Format(time.RFC3339Nano),
+			"default": req.DefaultTime.UTC().Format(time.RFC3339),
+		}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/events/:ts", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/events/1600000000?since=1600000000123",
+		strings.NewReader(`{"created":1500000000,"updated":1500000000456}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "2020-09-13T12:26:40Z", data["path"])
+	assert.Equal(t, "2020-09-13T12:26:40.123Z", data["query"])
+	assert.Equal(t, "2017-07-14T02:40:00Z", data["body"])
+	assert.Equal(t, "2017-07-14T02:40:00.456Z", data["ptr"])
+	assert.Equal(t, "2023-11-14T22:13:20Z", data["default"])
+}
+
+func TestUnixTimeFormatBodyAcceptsRFC3339(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Created time.Time `json:"created" time_format:"unix"`
+	}) (interface{}, error) {
+		return gin.H{"created": req.Created.UTC().Format(time.RFC3339)}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/test", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/test", strings.NewReader(`{"created":"2023-01-01T00:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "2023-01-01T00:00:00Z")
+}
+
+func TestUnixTimeFormatInvalidValue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		PathTime time.Time `path:"ts" time_format:"unix"`
+	}) (interface{}, error) {
+		return nil, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/events/:ts", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/events/yesterday", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid unix timestamp")
+}
+
+type unixTimeEvent struct {
+	At   time.Time      `json:"at" time_format:"unix"`
+	Next *unixTimeEvent `json:"next"`
+}
+
+func TestUnixTimeFormatNestedBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		Window struct {
+			From time.Time  `json:"from" time_format:"unixmilli"`
+			To   *time.Time `json:"to" time_format:"unixmilli"`
+		} `json:"window"`
+		Events []unixTimeEvent           `json:"events"`
+		ByName map[string]*unixTimeEvent `json:"by_name"`
+	}
+
+	router := gin.New()
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router.POST("/test", builder.MustFormBindingGinHandlerFunc(func(req request) (any, error) {
+		return gin.H{
+			"from":   req.Window.From.Format(time.RFC3339),
+			"to":     req.Window.To.Format(time.RFC3339),
+			"event":  req.Events[0].At.Format(time.RFC3339),
+			"next":   req.Events[0].Next.At.Format(time.RFC3339),
+			"byName": req.ByName["a"].At.Format(time.RFC3339),
+		}, nil
+	}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/test", strings.NewReader(`{
+		"window": {"from": 1700000000000, "to": 1700003600000},
+		"events": [{"at": 1700000000, "next": {"at": "2023-11-14T23:13:20Z"}}],
+		"by_name": {"a": {"at": 1700000000}}
+	}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"success","data":{
+		"from":"2023-11-14T22:13:20Z",
+		"to":"2023-11-14T23:13:20Z",
+		"event":"2023-11-14T22:13:20Z",
+		"next":"2023-11-14T23:13:20Z",
+		"byName":"2023-11-14T22:13:20Z"
+	}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/test", strings.NewReader(`{"events":[{"at":1},{"at":1.5}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `failed to parse body field \"events.1.at\"`)
+
+	assert.NotNil(t, typeInfoOf(reflect.TypeOf(request{})).unixTimes)
+	assert.Nil(t, typeInfoOf(reflect.TypeOf(struct {
+		At    time.Time `json:"at"`
+		Items []struct{ Name string }
+	}{})).unixTimes)
+}
+
+func TestBuilderTimeLocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Day      time.Time `path:"day"`
+		Start    time.Time `json:"start" default:"2024-01-02 09:00:00"`
+		Explicit time.Time `json:"explicit" default:"2024-01-02T09:00:00Z"`
+	}) (interface{}, error) {
+		return gin.H{
+			"day":      req.Day.UTC().Format(time.RFC3339),
+			"start":    req.Start.UTC().Format(time.RFC3339),
+			"explicit": req.Explicit.UTC().Format(time.RFC3339),
+		}, nil
+	}
+
+	shanghai := time.FixedZone("CST", 8*60*60)
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithTimeLocation(shanghai))
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/reports/:day", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/reports/2024-01-02", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "2024-01-01T16:00:00Z", data["day"])
+	assert.Equal(t, "2024-01-02T01:00:00Z", data["start"])
+	assert.Equal(t, "2024-01-02T09:00:00Z", data["explicit"])
+}
+
+func TestBuilderTimeLocationDefaultsToUTC(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	assert.Equal(t, time.UTC, builder.timeLocation)
+
+	builder = NewBasicFormBindingGinHandlerBuilder(nil, nil, WithTimeLocation(nil))
+	assert.Equal(t, time.UTC, builder.timeLocation)
+}
@@ -0,0 +1,177 @@
+package ginbinding
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithTimeout bounds the execution time of the handler function. The function
+// runs with a request context that expires after d; if it has not returned by
+// then, the handler responds with context.DeadlineExceeded (504 with the
+// default response handler) and anything the late function writes to the
+// response is discarded. Binding and validation are not counted against the
+// budget. A zero or negative d disables the timeout.
+//
+// While a timeout is set the function receives a copy of the *gin.Context, so
+// values it stores with ctx.Set are not visible to later middleware.
+func WithTimeout(d time.Duration) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.timeout = d
+	}
+}
+
+// call invokes funcVal with in, enforcing the configured timeout. in[0] is
+// the first argument as described by ctxParam. When funcVal is still running
+// after the timeout, running is closed once it returns; until then it may use
+// in and whatever they reference.
+func (builder *BasicFormBindingGinHandlerBuilder) call(
+	ctx *gin.Context,
+	funcVal reflect.Value,
+	in []reflect.Value,
+	ctxParam contextParam,
+) (out []reflect.Value, running <-chan struct{}, err error) {
+	if builder.timeout <= 0 {
+		return funcVal.Call(in), nil, nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Request.Context(), builder.timeout)
+	defer cancel()
+
+	writer := &timeoutWriter{ResponseWriter: ctx.Writer, ctx: timeoutCtx, header: make(http.Header)}
+	cp := ctx.Copy()
+	cp.Request = ctx.Request.WithContext(timeoutCtx)
+	cp.Writer = writer
+	if ctxParam != contextNone {
+		in[0] = ctxParam.value(cp, timeoutCtx)
+	}
+
+	done := make(chan []reflect.Value, 1)
+	panicked := make(chan any, 1)
+	finished := make(chan struct{})
+
+	go func() {
+		defer close(finished)
+		defer func() {
+			if p := recover(); p != nil {
+				panicked <- p
+			}
+		}()
+		done <- funcVal.Call(in)
+	}()
+
+	expired := func() error {
+		if err := ctx.Request.Context().Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("handler timed out after %s: %w", builder.timeout, context.DeadlineExceeded)
+	}
+
+	select {
+	case out := <-done:
+		// A function returning after the deadline lost the race to it
+		if !writer.finish(true) {
+			return nil, nil, expired()
+		}
+		return out, nil, nil
+	case p := <-panicked:
+		writer.finish(false)
+		panic(p)
+	case <-timeoutCtx.Done():
+		writer.finish(false)
+		return nil, finished, expired()
+	}
+}
+
+// timeoutWriter guards the response of a handler running under a timeout.
+// Headers are buffered until the first write so that the function never
+// touches the shared header map, and every write after finish or after ctx
+// expired is dropped.
+type timeoutWriter struct {
+	gin.ResponseWriter
+
+	// ctx is the context of the function, whose expiry closes the writer
+	// even before finish is called
+	ctx      context.Context
+	mu       sync.Mutex
+	header   http.Header
+	finished bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed() {
+		return
+	}
+	w.flushHeader()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed() {
+		return
+	}
+	w.flushHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed() {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.flushHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed() {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.flushHeader()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// closed reports whether writes are dropped; callers must hold mu
+func (w *timeoutWriter) closed() bool {
+	return w.finished || w.ctx.Err() != nil
+}
+
+// finish stops forwarding writes, and reports false if they already were
+// because ctx expired. When the function returned in time its buffered
+// headers are copied to the real response so they are sent along with
+// whatever the response handler writes.
+func (w *timeoutWriter) finish(flush bool) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	inTime := !w.closed()
+	if flush && inTime {
+		w.flushHeader()
+	}
+	w.finished = true
+	return inTime
+}
+
+// flushHeader copies the buffered headers to the underlying writer; callers
+// must hold mu
+func (w *timeoutWriter) flushHeader() {
+	dst := w.ResponseWriter.Header()
+	for k, v := range w.header {
+		dst[k] = v
+	}
+}
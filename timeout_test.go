@@ -0,0 +1,106 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeoutExceeded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	finished := make(chan struct{})
+	handler := func(c *gin.Context) (interface{}, error) {
+		defer close(finished)
+		<-c.Request.Context().Done()
+		// Late writes must not reach the response
+		c.Header("X-Late", "true")
+		c.String(http.StatusOK, "late")
+		return gin.H{"late": true}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler, WithTimeout(20*time.Millisecond))
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/report", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/report", nil)
+
+	router.ServeHTTP(w, req)
+	<-finished
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.Empty(t, w.Header().Get("X-Late"))
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "error", response["status"])
+	assert.Equal(t, "handler timed out after 20ms: context deadline exceeded", response["message"])
+}
+
+func TestWithTimeoutWithinBudget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Name string `form:"name"`
+	}) (interface{}, error) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		c.Header("X-Handled", "true")
+		return gin.H{"name": req.Name, "has_deadline": hasDeadline}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithTimeout(time.Second))
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/report", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/report?name=daily", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get("X-Handled"))
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "daily", data["name"])
+	assert.Equal(t, true, data["has_deadline"])
+}
+
+func TestWithTimeoutPanicPropagates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context) (interface{}, error) {
+		panic("boom")
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler, WithTimeout(time.Second))
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.GET("/report", ginHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/report", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
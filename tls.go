@@ -0,0 +1,65 @@
+package ginbinding
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNoClientCertificate is returned when a "tls" tagged field is bound for a
+// request that did not present a client certificate
+var ErrNoClientCertificate = errors.New("no client certificate presented")
+
+var strSliceTy = reflect.TypeOf([]string{})
+
+// tlsTagTypes lists the supported "tls" tag values and their field types
+var tlsTagTypes = map[string]reflect.Type{
+	"cn":          strTy,
+	"dns_names":   strSliceTy,
+	"fingerprint": strTy,
+}
+
+// checkTLSTags verifies every "tls" tag of ty at handler build time
+func checkTLSTags(ty reflect.Type) error {
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, ok := sf.Tag.Lookup("tls")
+		if !ok {
+			continue
+		}
+		want, ok := tlsTagTypes[tag]
+		if !ok {
+			return fmt.Errorf("field %s: unknown tls tag %q, expected cn, dns_names or fingerprint", sf.Name, tag)
+		}
+		if sf.Type != want {
+			return fmt.Errorf("field %s: tls:%q requires a %s field, got %s", sf.Name, tag, want, sf.Type)
+		}
+	}
+	return nil
+}
+
+// peerCertificateValue extracts the value named by a "tls" tag from the leaf
+// client certificate. The fingerprint is the lowercase hex SHA-256 digest of
+// the DER encoded certificate.
+func peerCertificateValue(state *tls.ConnectionState, key string) (reflect.Value, error) {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return reflect.Value{}, ErrNoClientCertificate
+	}
+
+	cert := state.PeerCertificates[0]
+	switch key {
+	case "cn":
+		return reflect.ValueOf(cert.Subject.CommonName), nil
+	case "dns_names":
+		return reflect.ValueOf(append([]string(nil), cert.DNSNames...)), nil
+	default:
+		sum := sha256.Sum256(cert.Raw)
+		return reflect.ValueOf(hex.EncodeToString(sum[:])), nil
+	}
+}
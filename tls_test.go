@@ -0,0 +1,146 @@
+package ginbinding
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClientCertificate(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "billing-service"},
+		DNSNames:     []string{"billing.internal", "billing.svc"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert
+}
+
+func TestTLSBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		CommonName  string   `tls:"cn"`
+		DNSNames    []string `tls:"dns_names"`
+		Fingerprint string   `tls:"fingerprint"`
+	}) (interface{}, error) {
+		return gin.H{"cn": req.CommonName, "dns_names": req.DNSNames, "fingerprint": req.Fingerprint}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/internal", ginHandler)
+
+	cert := newTestClientCertificate(t)
+	sum := sha256.Sum256(cert.Raw)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/internal", nil)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "billing-service", data["cn"])
+	assert.Equal(t, []interface{}{"billing.internal", "billing.svc"}, data["dns_names"])
+	assert.Equal(t, hex.EncodeToString(sum[:]), data["fingerprint"])
+}
+
+func TestTLSBindingWithoutCertificate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		CommonName string `tls:"cn"`
+	}) (interface{}, error) {
+		return gin.H{"cn": req.CommonName}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/internal", ginHandler)
+
+	for _, state := range []*tls.ConnectionState{nil, {}} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/internal", nil)
+		req.TLS = state
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response map[string]interface{}
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "field CommonName: no client certificate presented", response["message"])
+	}
+}
+
+func TestTLSTagInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  any
+		expected string
+	}{
+		{
+			name: "unknown key",
+			handler: func(c *gin.Context, req struct {
+				Serial string `tls:"serial"`
+			}) error {
+				return nil
+			},
+			expected: `field Serial: unknown tls tag "serial", expected cn, dns_names or fingerprint`,
+		},
+		{
+			name: "wrong type",
+			handler: func(c *gin.Context, req struct {
+				DNSNames string `tls:"dns_names"`
+			}) error {
+				return nil
+			},
+			expected: `field DNSNames: tls:"dns_names" requires a []string field, got string`,
+		},
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := builder.FormBindingGinHandlerFunc(tt.handler)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
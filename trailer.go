@@ -0,0 +1,96 @@
+package ginbinding
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pseudoTags lists the supported "pseudo" tag values
+var pseudoTags = []string{":method", ":scheme", ":authority", ":path"}
+
+// checkPseudoTags verifies every "pseudo" tag of ty at handler build time
+func checkPseudoTags(ty reflect.Type) error {
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, ok := sf.Tag.Lookup("pseudo")
+		if !ok {
+			continue
+		}
+		if _, err := pseudoValue(nil, tag); err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// pseudoValue returns the HTTP/2 pseudo-header named by a "pseudo" tag. Go's
+// HTTP servers expose them as fields of the request, from which HTTP/1
+// requests get the same values. It only validates key if ctx is nil.
+func pseudoValue(ctx *gin.Context, key string) (string, error) {
+	switch key {
+	case ":method", ":scheme", ":authority", ":path":
+	default:
+		return "", fmt.Errorf("unknown pseudo tag %q, expected %s", key, strings.Join(pseudoTags, ", "))
+	}
+	if ctx == nil {
+		return "", nil
+	}
+
+	req := ctx.Request
+	switch key {
+	case ":method":
+		return req.Method, nil
+	case ":scheme":
+		if req.URL.Scheme != "" {
+			return req.URL.Scheme, nil
+		}
+		if req.TLS != nil {
+			return "https", nil
+		}
+		return "http", nil
+	case ":authority":
+		return req.Host, nil
+	default:
+		return req.URL.RequestURI(), nil
+	}
+}
+
+// bindTrailers sets the fields of the struct pointed to by val tagged
+// `trailer:"name"` to the trailers of the request. Trailers only arrive after
+// the body, so the rest of the body is read first.
+func (builder *BasicFormBindingGinHandlerBuilder) bindTrailers(ctx *gin.Context, val reflect.Value) error {
+	if ctx.Request.Body != nil {
+		if _, err := io.Copy(io.Discard, ctx.Request.Body); err != nil {
+			return &inputError{source: SourceBody, err: err}
+		}
+	}
+
+	ty := val.Type().Elem()
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+		name, ok := sf.Tag.Lookup("trailer")
+		if !ok || !sf.IsExported() {
+			continue
+		}
+
+		// Trailer fields are never bound from the body
+		value := ctx.Request.Trailer.Get(name)
+		if value == "" {
+			val.Elem().Field(i).SetZero()
+			continue
+		}
+		sfv, err := builder.stringToVal(value, sf.Type, sf.Tag)
+		if err != nil {
+			return &fieldError{path: sf.Name, err: fmt.Errorf("failed to parse trailer %q: %w", name, err), value: value}
+		}
+		val.Elem().Field(i).Set(sfv)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package ginbinding
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type checksumUploadRequest struct {
+	Name     string `json:"name"`
+	Size     int    `trailer:"X-Size"`
+	Checksum string `trailer:"X-Checksum"`
+}
+
+// postWithTrailers sends body chunked to the server with trailers, which
+// only a real connection carries
+func postWithTrailers(t *testing.T, url string, body string, trailer http.Header) (int, map[string]interface{}) {
+	req, err := http.NewRequest("POST", url, io.NopCloser(strings.NewReader(body)))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = -1
+	req.Trailer = trailer
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	var response map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return resp.StatusCode, response
+}
+
+func TestTrailerBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req checksumUploadRequest) (interface{}, error) {
+		return req, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.POST("/uploads", builder.MustFormBindingGinHandlerFunc(handler))
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	t.Run("bound from trailers", func(t *testing.T) {
+		status, response := postWithTrailers(t, server.URL+"/uploads", `{"name":"report.csv"}`,
+			http.Header{"X-Checksum": {"sha256:abc"}, "X-Size": {"21"}})
+
+		assert.Equal(t, http.StatusOK, status)
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, "report.csv", data["name"])
+		assert.Equal(t, "sha256:abc", data["Checksum"])
+		assert.Equal(t, float64(21), data["Size"])
+	})
+
+	t.Run("not bound from the body", func(t *testing.T) {
+		status, response := postWithTrailers(t, server.URL+"/uploads", `{"name":"report.csv","Checksum":"forged"}`, nil)
+
+		assert.Equal(t, http.StatusOK, status)
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, "", data["Checksum"])
+	})
+
+	t.Run("invalid trailer", func(t *testing.T) {
+		status, response := postWithTrailers(t, server.URL+"/uploads", `{"name":"report.csv"}`,
+			http.Header{"X-Size": {"large"}})
+
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, fmt.Sprint(response), `failed to parse trailer "X-Size"`)
+	})
+}
+
+func TestPseudoHeaderBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Method    string `pseudo:":method"`
+		Scheme    string `pseudo:":scheme"`
+		Authority string `pseudo:":authority"`
+		Path      string `pseudo:":path"`
+	}) (interface{}, error) {
+		return req, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	router := gin.New()
+	router.DELETE("/files/:id", builder.MustFormBindingGinHandlerFunc(handler))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/files/7?force=true", nil)
+	req.Host = "files.example.com"
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, map[string]interface{}{
+		"Method":    "DELETE",
+		"Scheme":    "http",
+		"Authority": "files.example.com",
+		"Path":      "/files/7?force=true",
+	}, response["data"])
+}
+
+func TestPseudoTagValidation(t *testing.T) {
+	handler := func(c *gin.Context, req struct {
+		Status string `pseudo:":status"`
+	}) error {
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	_, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.EqualError(t, err, `field Status: unknown pseudo tag ":status", expected :method, :scheme, :authority, :path`)
+}
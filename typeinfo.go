@@ -0,0 +1,149 @@
+package ginbinding
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// typeInfo summarizes which binding features a request struct type uses, so
+// that per-request passes can be skipped for types that do not need them
+type typeInfo struct {
+	modifiers  bool
+	enums      bool
+	required   bool
+	pagination bool
+	sort       bool
+	filter     bool
+	// discriminated is set when a top-level field has a kind tag
+	discriminated bool
+	// bigNumbers is set when a top-level field is a big.Int or big.Float
+	bigNumbers bool
+	// rewriteQuery is set when a top-level query field may need its value
+	// rewritten before gin binds it
+	rewriteQuery bool
+	// validated is set when a field has binding tags for gin's validator
+	validated bool
+	// contextValues is set when a top-level field has a ctx tag
+	contextValues bool
+	// trailers is set when a top-level field has a trailer tag
+	trailers bool
+	// nestedForm is set when a top-level form or query field can be bound
+	// from nested form keys
+	nestedForm bool
+	// multipartParts is set when a top-level field has a file or part tag
+	multipartParts bool
+	// sanitized is set when a field has a sanitize tag
+	sanitized bool
+	// patterns is set when a field has a pattern tag
+	patterns bool
+	// idsafe is set when a field has an idsafe tag
+	idsafe bool
+	// headers are the header tags other than wildcards, with their header
+	// names canonicalized
+	headers []headerField
+	// deprecated are the deprecated fields sent requests are checked for
+	deprecated []taggedField
+	// roleFields are the fields guarded by requires_role tags
+	roleFields []taggedField
+	// unbindable are the fields tagged bindable:"false", named by their JSON
+	// paths
+	unbindable []taggedField
+	// unixTimes locates the epoch time fields of JSON bodies, and is nil
+	// for types without any
+	unixTimes *unixTimeJSON
+	// pool reuses instances of types implementing Resetter, and is nil for
+	// other types
+	pool *sync.Pool
+}
+
+var typeInfoCache sync.Map // map[reflect.Type]*typeInfo
+
+// typeInfoOf returns the cached typeInfo of the struct type ty
+func typeInfoOf(ty reflect.Type) *typeInfo {
+	if info, ok := typeInfoCache.Load(ty); ok {
+		return info.(*typeInfo)
+	}
+
+	info := &typeInfo{
+		pool:       newRequestPool(ty),
+		deprecated: deprecatedFields(ty),
+		roleFields: roleFields(ty),
+		unbindable: unbindableFields(ty),
+		unixTimes:  unixTimeJSONOf(ty, make(map[reflect.Type]bool)),
+	}
+	_ = walkTypeFields(ty, func(sf reflect.StructField) error {
+		if _, ok := sf.Tag.Lookup("mod"); ok {
+			info.modifiers = true
+		}
+		if _, ok := sf.Tag.Lookup("sanitize"); ok {
+			info.sanitized = true
+		}
+		if _, ok := sf.Tag.Lookup("enum"); ok {
+			info.enums = true
+		}
+		if _, ok := sf.Tag.Lookup("pattern"); ok {
+			info.patterns = true
+		}
+		if _, ok := sf.Tag.Lookup("idsafe"); ok {
+			info.idsafe = true
+		}
+		if _, ok := sf.Tag.Lookup("require"); ok || strings.Contains(sf.Tag.Get("binding"), "required") {
+			info.required = true
+		}
+		if _, ok := sf.Tag.Lookup("binding"); ok {
+			info.validated = true
+		}
+		if key, ok := sf.Tag.Lookup("header"); ok {
+			key, _, _ = strings.Cut(key, ",")
+			if !strings.HasSuffix(key, "*") && !slices.ContainsFunc(info.headers, func(h headerField) bool { return h.key == key }) {
+				info.headers = append(info.headers, headerField{key: key, name: canonicalHeaderName(key)})
+			}
+		}
+
+		fieldTy := sf.Type
+		if fieldTy.Kind() == reflect.Pointer {
+			fieldTy = fieldTy.Elem()
+		}
+		switch fieldTy {
+		case paginationTy:
+			info.pagination = true
+		case sortTy:
+			info.sort = true
+		case filterTy:
+			info.filter = true
+		}
+		return nil
+	})
+
+	for i := 0; i < ty.NumField(); i++ {
+		if _, ok := ty.Field(i).Tag.Lookup("kind"); ok {
+			info.discriminated = true
+		}
+		if isBigNumber(ty.Field(i).Type) {
+			info.bigNumbers = true
+		}
+		if rewritesQuery(ty.Field(i)) {
+			info.rewriteQuery = true
+		}
+		if _, ok := ty.Field(i).Tag.Lookup("ctx"); ok {
+			info.contextValues = true
+		}
+		if _, ok := ty.Field(i).Tag.Lookup("file"); ok {
+			info.multipartParts = true
+		}
+		if _, ok := ty.Field(i).Tag.Lookup("part"); ok {
+			info.multipartParts = true
+		}
+		if _, ok := ty.Field(i).Tag.Lookup("trailer"); ok {
+			info.trailers = true
+		}
+		if _, ok := queryTag(ty.Field(i)); ok && ty.Field(i).IsExported() && isNestedFormType(ty.Field(i).Type) {
+			info.nestedForm = true
+		}
+	}
+
+	actual, _ := typeInfoCache.LoadOrStore(ty, info)
+	return actual.(*typeInfo)
+}
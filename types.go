@@ -4,6 +4,8 @@
 package ginbinding
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -26,12 +28,34 @@ type FormBindingGinHandlerBuilder interface {
 	//  1. func(*gin.Context, any struct) error
 	//  2. func(*gin.Context, any struct) (any, error)
 	//  3. func(*gin.Context) (any, error)
-	FormBindingGinHandlerFunc(i any) (gin.HandlerFunc, error)
+	// Options override the builder configuration for this handler only.
+	FormBindingGinHandlerFunc(i any, opts ...Option) (gin.HandlerFunc, error)
 }
 
+// Sources of request inputs reported by BindingError
+const (
+	SourcePath    = "path"
+	SourceQuery   = "query"
+	SourceHeader  = "header"
+	SourceHost    = "host"
+	SourceTLS     = "tls"
+	SourceBody    = "body"
+	SourceTrailer = "trailer"
+	SourceDefault = "default"
+)
+
 // BindingError represents an error that occurred during form binding
 type BindingError struct {
 	Err error
+	// Source is the request input the error was raised for, one of the
+	// Source constants, or empty if it is unknown
+	Source string
+	// Field is the key of the input within Source, such as the query
+	// parameter name or the dotted JSON path of a body field
+	Field string
+	// Value is the offending input as sent, if known. It is left empty when
+	// errors are redacted.
+	Value string
 }
 
 // Error implements the error interface
@@ -43,3 +67,55 @@ func (e *BindingError) Error() string {
 func (e *BindingError) Unwrap() error {
 	return e.Err
 }
+
+// ValidationError wraps the failure of gin's validation of binding tags or of
+// the builder's validator, telling it apart from other binding errors and
+// from handler errors. It is reported within a BindingError.
+type ValidationError struct {
+	Err error
+	// Status is the HTTP status code of the error response, chosen with
+	// WithValidationStatus. Zero means 400 Bad Request.
+	Status int
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode implements StatusCoder
+func (e *ValidationError) StatusCode() int {
+	if e.Status == 0 {
+		return http.StatusBadRequest
+	}
+	return e.Status
+}
+
+// StatusCoder can be implemented by errors returned from handlers to choose the
+// HTTP status code of the error response
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// ETagger can be implemented by data returned from handlers to set the ETag
+// header of the success response and answer conditional GET requests
+type ETagger interface {
+	ETag() string
+}
+
+// ErrorCoder can be implemented by errors returned from handlers to choose the
+// machine-readable code of the error response
+type ErrorCoder interface {
+	ErrorCode() string
+}
+
+// PublicMessager can be implemented by errors returned from handlers to choose
+// the message shown to clients instead of Error()
+type PublicMessager interface {
+	PublicMessage() string
+}
@@ -0,0 +1,127 @@
+package ginbinding
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// byteUnits maps the lower-cased byte size suffixes accepted by unit:"bytes"
+// to their multiplier. Decimal prefixes are powers of 1000, binary prefixes
+// such as KiB powers of 1024.
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"t":   1e12,
+	"tb":  1e12,
+	"p":   1e15,
+	"pb":  1e15,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+// checkUnitTags verifies at handler build time that unit tags name a known
+// unit and are placed on fields of a matching type
+func checkUnitTags(ty reflect.Type) error {
+	return walkTypeFields(ty, func(sf reflect.StructField) error {
+		unit, ok := sf.Tag.Lookup("unit")
+		if !ok {
+			return nil
+		}
+
+		fieldTy := sf.Type
+		if fieldTy.Kind() == reflect.Pointer {
+			fieldTy = fieldTy.Elem()
+		}
+
+		switch unit {
+		case "bytes":
+			switch fieldTy.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				if fieldTy != durationTy {
+					return nil
+				}
+			}
+			return fmt.Errorf("field %s: unit %q requires an integer type, got %s", sf.Name, unit, sf.Type)
+		case "duration":
+			if fieldTy != durationTy {
+				return fmt.Errorf("field %s: unit %q requires time.Duration, got %s", sf.Name, unit, sf.Type)
+			}
+		case "percent":
+			if fieldTy.Kind() != reflect.Float32 && fieldTy.Kind() != reflect.Float64 {
+				return fmt.Errorf("field %s: unit %q requires a float type, got %s", sf.Name, unit, sf.Type)
+			}
+		default:
+			return fmt.Errorf("field %s: unknown unit %q", sf.Name, unit)
+		}
+		return nil
+	})
+}
+
+// parseUnit converts s from the human-readable form of unit, such as "10MB",
+// "1.5h" or "25%", to the plain number or duration gin and stringToVal parse
+func parseUnit(s string, unit string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	switch unit {
+	case "bytes":
+		i := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if i < 0 {
+			i = len(s)
+		}
+		multiplier, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+		if !ok {
+			return "", fmt.Errorf("invalid byte size %q", s)
+		}
+		n, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid byte size %q", s)
+		}
+		size := n * multiplier
+		if size > math.MaxInt64 {
+			return "", fmt.Errorf("byte size %q is out of range", s)
+		}
+		return strconv.FormatInt(int64(size), 10), nil
+	case "duration":
+		// Days are not supported by time.ParseDuration
+		if days, ok := strings.CutSuffix(s, "d"); ok {
+			n, err := strconv.ParseFloat(days, 64)
+			if err != nil {
+				return "", fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(n * float64(24*time.Hour)).String(), nil
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid duration %q", s)
+		}
+		return d.String(), nil
+	case "percent":
+		// "25%" is 0.25, a number without percent sign is already a fraction
+		number, isPercent := strings.CutSuffix(s, "%")
+		f, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid percentage %q", s)
+		}
+		if isPercent {
+			f /= 100
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), nil
+	}
+	return s, nil
+}
@@ -0,0 +1,100 @@
+package ginbinding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitTags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		MaxSize int64         `form:"max_size" unit:"bytes" default:"1KiB"`
+		TTL     time.Duration `form:"ttl" unit:"duration" default:"7d"`
+		Ratio   float64       `form:"ratio" unit:"percent" default:"50%"`
+	}
+
+	var got request
+	var rawQuery string
+	handler := func(c *gin.Context, req request) error {
+		got = req
+		rawQuery = c.Query("max_size")
+		return nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	ginHandler, err := builder.FormBindingGinHandlerFunc(handler)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/uploads", ginHandler)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expected       request
+	}{
+		{
+			name:           "units",
+			query:          "max_size=10MB&ttl=1.5h&ratio=25%25",
+			expectedStatus: http.StatusOK,
+			expected:       request{MaxSize: 10_000_000, TTL: 90 * time.Minute, Ratio: 0.25},
+		},
+		{
+			name:           "plain values",
+			query:          "max_size=512&ttl=30s&ratio=0.1",
+			expectedStatus: http.StatusOK,
+			expected:       request{MaxSize: 512, TTL: 30 * time.Second, Ratio: 0.1},
+		},
+		{
+			name:           "defaults",
+			expectedStatus: http.StatusOK,
+			expected:       request{MaxSize: 1024, TTL: 7 * 24 * time.Hour, Ratio: 0.5},
+		},
+		{
+			name:           "invalid size",
+			query:          "max_size=10XB",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = request{}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/uploads?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expected, got)
+				assert.Equal(t, req.URL.Query().Get("max_size"), rawQuery)
+			}
+		})
+	}
+}
+
+func TestUnitTags_BuildErrors(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+
+	_, err := builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Size string `form:"size" unit:"bytes"`
+	}) error {
+		return nil
+	})
+	assert.EqualError(t, err, `field Size: unit "bytes" requires an integer type, got string`)
+
+	_, err = builder.FormBindingGinHandlerFunc(func(c *gin.Context, req struct {
+		Size int64 `form:"size" unit:"furlongs"`
+	}) error {
+		return nil
+	})
+	assert.EqualError(t, err, `field Size: unknown unit "furlongs"`)
+}
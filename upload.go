@@ -0,0 +1,177 @@
+package ginbinding
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+var (
+	uploadPtrTy   = reflect.TypeOf((*Upload)(nil))
+	uploadSliceTy = reflect.TypeOf([]*Upload(nil))
+)
+
+// WithMaxUploadSize rejects requests uploading a file of more than n bytes to
+// a `file` tagged field with ErrRequestEntityTooLarge (413) through the
+// response handler, and bounds what Upload.SaveTo and Upload.SaveToFS copy.
+// Zero means no limit.
+func WithMaxUploadSize(n int64) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.maxUploadSize = n
+	}
+}
+
+// Upload is an uploaded file bound from a multipart part by a `file` tag to a
+// *Upload or []*Upload field. It reads the file as an io.Reader without
+// loading it into memory: gin keeps the parts larger than the engine's
+// MaxMultipartMemory in temporary files, which Upload reads from.
+//
+//	type AvatarRequest struct {
+//		Avatar *ginbinding.Upload `file:"avatar" binding:"required"`
+//	}
+//
+//	func setAvatar(c *gin.Context, req AvatarRequest) error {
+//		_, err := req.Avatar.SaveToFS(ginbinding.UploadDir("/var/avatars"), userID(c)+".png")
+//		return err
+//	}
+type Upload struct {
+	// Filename is the file name sent by the client, which must not be
+	// trusted as a path
+	Filename string
+	// Size is the size of the file in bytes
+	Size int64
+	// ContentType is the content type sent by the client for the part
+	ContentType string
+	// Header is the MIME header of the part
+	Header textproto.MIMEHeader
+
+	file  *multipart.FileHeader
+	limit int64
+	r     multipart.File
+}
+
+// newUpload returns the Upload of file with the upload size limit of builder
+func (builder *BasicFormBindingGinHandlerBuilder) newUpload(file *multipart.FileHeader) *Upload {
+	return &Upload{
+		Filename:    file.Filename,
+		Size:        file.Size,
+		ContentType: file.Header.Get("Content-Type"),
+		Header:      file.Header,
+		file:        file,
+		limit:       builder.maxUploadSize,
+	}
+}
+
+// Open opens a new reader of the file, independent of Read
+func (u *Upload) Open() (multipart.File, error) {
+	return u.file.Open()
+}
+
+// Read implements io.Reader, opening the file on the first call
+func (u *Upload) Read(p []byte) (int, error) {
+	if u.r == nil {
+		r, err := u.file.Open()
+		if err != nil {
+			return 0, err
+		}
+		u.r = r
+	}
+	return u.r.Read(p)
+}
+
+// Close closes the file opened by Read, if any
+func (u *Upload) Close() error {
+	if u.r == nil {
+		return nil
+	}
+	err := u.r.Close()
+	u.r = nil
+	return err
+}
+
+// SaveTo copies the file to dst and returns the number of bytes copied. It
+// fails with ErrRequestEntityTooLarge once more bytes than the upload size
+// limit of the builder have been copied.
+func (u *Upload) SaveTo(dst io.Writer) (int64, error) {
+	f, err := u.file.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if u.limit <= 0 {
+		return io.Copy(dst, f)
+	}
+	n, err := io.Copy(dst, io.LimitReader(f, u.limit+1))
+	if err == nil && n > u.limit {
+		err = &uploadTooLargeError{name: u.Filename, limit: u.limit}
+	}
+	return n, err
+}
+
+// UploadFS is a file system uploads are saved to
+type UploadFS interface {
+	// Create creates the file name for writing
+	Create(name string) (io.WriteCloser, error)
+}
+
+// SaveToFS saves the file as name in fsys, like SaveTo
+func (u *Upload) SaveToFS(fsys UploadFS, name string) (int64, error) {
+	dst, err := fsys.Create(name)
+	if err != nil {
+		return 0, err
+	}
+	n, err := u.SaveTo(dst)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
+// UploadDir is an UploadFS saving files in a directory of the local file
+// system. Names are slash-separated paths within the directory, whose parent
+// directories are created as needed; existing files are not overwritten.
+type UploadDir string
+
+// Create implements UploadFS
+func (d UploadDir) Create(name string) (io.WriteCloser, error) {
+	path := filepath.FromSlash(name)
+	if !filepath.IsLocal(path) {
+		return nil, fmt.Errorf("invalid upload path %q", name)
+	}
+	path = filepath.Join(string(d), path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+}
+
+// uploadTooLargeError reports an upload over the size limit of the builder
+type uploadTooLargeError struct {
+	name  string
+	limit int64
+}
+
+func (e *uploadTooLargeError) Error() string {
+	return fmt.Sprintf("file %q exceeds the upload size limit of %d bytes: %s", e.name, e.limit, ErrRequestEntityTooLarge)
+}
+
+func (e *uploadTooLargeError) Unwrap() error {
+	return ErrRequestEntityTooLarge
+}
+
+// StatusCode implements StatusCoder, since binding errors are otherwise
+// reported as 400 Bad Request
+func (e *uploadTooLargeError) StatusCode() int {
+	return http.StatusRequestEntityTooLarge
+}
+
+// ErrorCode implements ErrorCoder
+func (e *uploadTooLargeError) ErrorCode() string {
+	return CodeRequestEntityTooLarge
+}
@@ -0,0 +1,112 @@
+package ginbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dir := t.TempDir()
+	handler := func(c *gin.Context, req struct {
+		Avatar *Upload   `file:"file" binding:"required"`
+		Extras []*Upload `file:"attachments"`
+	}) (interface{}, error) {
+		content, err := io.ReadAll(req.Avatar)
+		if err != nil {
+			return nil, err
+		}
+		defer req.Avatar.Close()
+
+		n, err := req.Avatar.SaveToFS(UploadDir(dir), "avatars/"+req.Avatar.Filename)
+		if err != nil {
+			return nil, err
+		}
+		return gin.H{"content": string(content), "saved": n, "size": req.Avatar.Size, "extras": len(req.Extras)}, nil
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithMaxUploadSize(64))
+	router := gin.New()
+	router.POST("/avatars", builder.MustFormBindingGinHandlerFunc(handler))
+
+	send := func(files map[string][]string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		body, contentType := newDocumentUpload(t, "", false, files)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/avatars", body)
+		req.Header.Set("Content-Type", contentType)
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("saved", func(t *testing.T) {
+		w, response := send(map[string][]string{"file": {"me.png"}, "attachments": {"a.txt", "b.txt"}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, map[string]interface{}{
+			"content": "content of me.png",
+			"saved":   float64(17),
+			"size":    float64(17),
+			"extras":  float64(2),
+		}, response["data"])
+
+		saved, err := os.ReadFile(filepath.Join(dir, "avatars", "me.png"))
+		assert.NoError(t, err)
+		assert.Equal(t, "content of me.png", string(saved))
+	})
+
+	t.Run("over the size limit", func(t *testing.T) {
+		w, response := send(map[string][]string{"file": {strings.Repeat("x", 100) + ".png"}})
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Equal(t, CodeRequestEntityTooLarge, response["code"])
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		w, _ := send(nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestUploadSaveToLimit(t *testing.T) {
+	body, contentType := newDocumentUpload(t, "", false, map[string][]string{"file": {"big.bin"}})
+	req, _ := http.NewRequest("POST", "/", body)
+	req.Header.Set("Content-Type", contentType)
+	assert.NoError(t, req.ParseMultipartForm(1<<20))
+
+	upload := (&BasicFormBindingGinHandlerBuilder{maxUploadSize: 4}).newUpload(req.MultipartForm.File["file"][0])
+	var dst bytes.Buffer
+	n, err := upload.SaveTo(&dst)
+	assert.True(t, errors.Is(err, ErrRequestEntityTooLarge))
+	assert.Equal(t, int64(5), n)
+}
+
+func TestUploadDir(t *testing.T) {
+	dir := UploadDir(t.TempDir())
+
+	for _, name := range []string{"../escape.txt", "/etc/passwd", ""} {
+		_, err := dir.Create(name)
+		assert.Error(t, err, name)
+	}
+
+	f, err := dir.Create("a/b.txt")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	_, err = dir.Create("a/b.txt")
+	assert.True(t, errors.Is(err, os.ErrExist))
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -107,7 +109,7 @@ func TestValidationFailure(t *testing.T) {
 
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	var response map[string]interface{}
 	err = json.Unmarshal(w.Body.Bytes(), &response)
@@ -203,3 +205,159 @@ func (h *testValidationResponseHandler) HandleSuccess(ctx *gin.Context, data int
 func (h *testValidationResponseHandler) HandleError(ctx *gin.Context, err error) {
 	ctx.String(http.StatusBadRequest, "validation error")
 }
+
+func TestWithGinDefaultValidator(t *testing.T) {
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, WithGinDefaultValidator())
+	assert.Equal(t, binding.Validator, builder.validator)
+
+	validator := &mockValidator{}
+	builder = NewBasicFormBindingGinHandlerBuilder(validator, nil, WithGinDefaultValidator())
+	assert.Equal(t, validator, builder.validator)
+}
+
+type ginDefaultValidatorRange struct {
+	From int `form:"from"`
+	To   int `form:"to"`
+}
+
+func TestWithGinDefaultValidatorBehavior(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	binding.Validator.Engine().(*validator.Validate).RegisterStructValidation(func(sl validator.StructLevel) {
+		r := sl.Current().Interface().(ginDefaultValidatorRange)
+		if r.From > r.To {
+			sl.ReportError(r.To, "To", "To", "gtefield", "From")
+		}
+	}, ginDefaultValidatorRange{})
+
+	serve := func(target string, opts ...Option) *httptest.ResponseRecorder {
+		builder := NewBasicFormBindingGinHandlerBuilder(nil, nil, opts...)
+		router := gin.New()
+		router.GET("/items", builder.MustFormBindingGinHandlerFunc(func(req struct {
+			Page int `form:"page" binding:"required"`
+		}) error {
+			return nil
+		}))
+		router.GET("/range", builder.MustFormBindingGinHandlerFunc(func(req ginDefaultValidatorRange) error {
+			return nil
+		}))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", target, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("binding tags are enforced either way", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/items?page=1").Code)
+		assert.Equal(t, http.StatusOK, serve("/items?page=1", WithGinDefaultValidator()).Code)
+	})
+
+	t.Run("missing required input", func(t *testing.T) {
+		w := serve("/items")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `missing required query parameter \"page\"`)
+
+		w = serve("/items", WithGinDefaultValidator())
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `'required' tag`)
+	})
+
+	t.Run("struct-level validation without binding tags", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/range?from=5&to=1").Code)
+
+		w := serve("/range?from=5&to=1", WithGinDefaultValidator())
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `'gtefield' tag`)
+		assert.Equal(t, http.StatusOK, serve("/range?from=1&to=5", WithGinDefaultValidator()).Code)
+	})
+}
+
+func TestValidationAfterDefaults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Limit int    `form:"limit" default:"500" binding:"max=100"`
+		Name  string `form:"name" default:"anonymous" binding:"required"`
+	}) error {
+		return nil
+	}
+
+	tests := []struct {
+		name      string
+		validator binding.StructValidator
+		target    string
+		valid     bool
+	}{
+		{name: "default value", target: "/items", valid: false},
+		{name: "query value", target: "/items?limit=10", valid: true},
+		{name: "gin default validator", validator: binding.Validator, target: "/items", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewBasicFormBindingGinHandlerBuilder(tt.validator, nil)
+
+			router := gin.New()
+			router.GET("/items", builder.MustFormBindingGinHandlerFunc(handler))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.target, nil)
+			router.ServeHTTP(w, req)
+
+			if tt.valid {
+				assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			} else {
+				assert.Contains(t, w.Body.String(), `"code":"VALIDATION_FAILED"`)
+			}
+		})
+	}
+}
+
+func TestWithValidationStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context, req struct {
+		Name string `json:"name" binding:"required"`
+	}) error {
+		return nil
+	}
+
+	tests := []struct {
+		name           string
+		validator      binding.StructValidator
+		opts           []Option
+		expectedStatus int
+	}{
+		{name: "default", expectedStatus: http.StatusBadRequest},
+		{name: "binding tags", opts: []Option{WithValidationStatus(http.StatusUnprocessableEntity)}, expectedStatus: http.StatusUnprocessableEntity},
+		{
+			name:           "builder validator",
+			validator:      &mockValidator{shouldError: true},
+			opts:           []Option{WithValidationStatus(http.StatusUnprocessableEntity)},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *ValidationError
+			builder := NewBasicFormBindingGinHandlerBuilder(tt.validator, nil, tt.opts...).
+				OnError(func(c *gin.Context, req any, err error) {
+					assert.ErrorAs(t, err, &validationErr)
+				})
+
+			router := gin.New()
+			router.POST("/test", builder.MustFormBindingGinHandlerFunc(handler))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/test", strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.NotNil(t, validationErr)
+			assert.Contains(t, w.Body.String(), `"code":"VALIDATION_FAILED"`)
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package ginbinding
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VersionResolver returns the API version requested by ctx, or "" for the
+// default version
+type VersionResolver func(ctx *gin.Context) string
+
+// HeaderVersion resolves the API version from the request header name, e.g.
+// API-Version
+func HeaderVersion(name string) VersionResolver {
+	return func(ctx *gin.Context) string {
+		return ctx.GetHeader(name)
+	}
+}
+
+// PathVersion resolves the API version from the path parameter name, e.g. for
+// routes like /:version/users
+func PathVersion(name string) VersionResolver {
+	return func(ctx *gin.Context) string {
+		return ctx.Param(name)
+	}
+}
+
+// VersionedType is a request type of an API version, registered with
+// VersionType
+type VersionedType struct {
+	version string
+	// from is the type requests of version are bound into, and to the
+	// request type of the handlers convert turns it into
+	from, to reflect.Type
+	convert  reflect.Value
+}
+
+// VersionType binds the requests of version into Old instead of the request
+// type New of the handler, and turns them into New with convert, so that a
+// handler keeps serving clients of a version whose payload shape differs too
+// much for versioned tags:
+//
+//	ginbinding.VersionType("v1", func(old CreateUserV1) (CreateUserRequest, error) {
+//		first, last, _ := strings.Cut(old.FullName, " ")
+//		return CreateUserRequest{FirstName: first, LastName: last}, nil
+//	})
+//
+// Old goes through defaults and validation like other requests. Errors of
+// convert are reported as binding errors.
+func VersionType[Old, New any](version string, convert func(Old) (New, error)) VersionedType {
+	return VersionedType{
+		version: version,
+		from:    reflect.TypeOf((*Old)(nil)).Elem(),
+		to:      reflect.TypeOf((*New)(nil)).Elem(),
+		convert: reflect.ValueOf(convert),
+	}
+}
+
+// apiVersioning selects the shape of requests by their API version
+type apiVersioning struct {
+	resolver VersionResolver
+	types    []VersionedType
+}
+
+// WithAPIVersion lets one route serve several payload shapes, selected by the
+// API version resolver returns for each request. Input tags suffixed with
+// @ and a version replace the tag for requests of that version, and a tag of
+// "-" leaves the field out:
+//
+//	type CreateUserRequest struct {
+//		Name  string `json:"name" json@v1:"full_name"`
+//		Email string `json:"email" header@v1:"X-Email" json@v1:"-"`
+//	}
+//
+//	r.POST("/users", createUser, ginbinding.WithAPIVersion(ginbinding.HeaderVersion("API-Version")))
+//
+// The json, form, query, path, uri and header tags of top-level fields can be
+// versioned; other tags, such as binding and default, apply to every version.
+// Errors name fields by their unversioned tags.
+// types registers request types of versions that need their own struct, see
+// VersionType. Requests without a version, or with a version that neither
+// types nor tags name, are bound as usual.
+func WithAPIVersion(resolver VersionResolver, types ...VersionedType) Option {
+	return func(builder *BasicFormBindingGinHandlerBuilder) {
+		builder.apiVersion = &apiVersioning{resolver: resolver, types: slices.Clone(types)}
+	}
+}
+
+// versionedTagKeys are the input tags that can be versioned
+var versionedTagKeys = []string{"json", "form", "query", "path", "uri", "header"}
+
+// versionedTypes holds the struct types with the tags of each version of a
+// request struct type
+type versionedTypes struct {
+	types map[string]reflect.Type
+	err   error
+}
+
+var versionedTypesCache sync.Map // map[reflect.Type]*versionedTypes
+
+// versionedTypesOf returns the struct types with the tags of each version
+// named by the versioned tags of the struct type ty, which convert to ty
+func versionedTypesOf(ty reflect.Type) (map[string]reflect.Type, error) {
+	if cached, ok := versionedTypesCache.Load(ty); ok {
+		v := cached.(*versionedTypes)
+		return v.types, v.err
+	}
+
+	v := &versionedTypes{}
+	v.types, v.err = buildVersionedTypes(ty)
+	actual, _ := versionedTypesCache.LoadOrStore(ty, v)
+	v = actual.(*versionedTypes)
+	return v.types, v.err
+}
+
+func buildVersionedTypes(ty reflect.Type) (types map[string]reflect.Type, err error) {
+	var versions []string
+	for i := 0; i < ty.NumField(); i++ {
+		for _, kv := range parseStructTag(ty.Field(i).Tag) {
+			if key, version, ok := strings.Cut(kv[0], "@"); ok && slices.Contains(versionedTagKeys, key) && !slices.Contains(versions, version) {
+				versions = append(versions, version)
+			}
+		}
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	// StructOf panics on the fields it does not support, such as embedded
+	// pointers to types with methods
+	defer func() {
+		if r := recover(); r != nil {
+			types, err = nil, fmt.Errorf("ginbinding: versioned tags of %s: %v", ty, r)
+		}
+	}()
+
+	types = make(map[string]reflect.Type, len(versions))
+	for _, version := range versions {
+		fields := make([]reflect.StructField, ty.NumField())
+		for i := range fields {
+			fields[i] = ty.Field(i)
+			fields[i].Tag = versionTag(fields[i].Tag, version)
+		}
+		types[version] = reflect.StructOf(fields)
+	}
+	return types, nil
+}
+
+// versionTag returns tag with the input tags replaced by their versions for
+// version, and without versioned tags
+func versionTag(tag reflect.StructTag, version string) reflect.StructTag {
+	pairs := parseStructTag(tag)
+
+	overrides := map[string]string{}
+	for _, kv := range pairs {
+		if key, v, ok := strings.Cut(kv[0], "@"); ok && v == version && slices.Contains(versionedTagKeys, key) {
+			overrides[key] = kv[1]
+		}
+	}
+
+	var b strings.Builder
+	write := func(key, value string) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(key + ":" + strconv.Quote(value))
+	}
+	for _, kv := range pairs {
+		if strings.Contains(kv[0], "@") {
+			continue
+		}
+		if value, ok := overrides[kv[0]]; ok {
+			write(kv[0], value)
+			delete(overrides, kv[0])
+			continue
+		}
+		write(kv[0], kv[1])
+	}
+	// Tags only set for the version
+	for _, key := range versionedTagKeys {
+		if value, ok := overrides[key]; ok {
+			write(key, value)
+		}
+	}
+	return reflect.StructTag(b.String())
+}
+
+// parseStructTag splits tag into its key and value pairs, following the
+// conventional format reflect.StructTag.Lookup parses
+func parseStructTag(tag reflect.StructTag) [][2]string {
+	var pairs [][2]string
+	s := string(tag)
+	for s != "" {
+		s = strings.TrimLeft(s, " ")
+		i := 0
+		for i < len(s) && s[i] > ' ' && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(s) || s[i] != ':' || s[i+1] != '"' {
+			break
+		}
+		key := s[:i]
+		s = s[i+1:]
+
+		i = 1
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		value, err := strconv.Unquote(s[:i+1])
+		if err != nil {
+			break
+		}
+		s = s[i+1:]
+		pairs = append(pairs, [2]string{key, value})
+	}
+	return pairs
+}
+
+// checkVersionedTypes verifies at handler build time that the versioned tags
+// of the request type ty can be applied
+func (builder *BasicFormBindingGinHandlerBuilder) checkVersionedTypes(ty reflect.Type) error {
+	if builder.apiVersion == nil || ty == nil || ty == mapAnyTy {
+		return nil
+	}
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+	_, err := versionedTypesOf(ty)
+	return err
+}
+
+// bindVersioned binds the request of type ty of version through the
+// VersionType registered for it, if any. ok is false when no VersionType
+// applies.
+func (builder *BasicFormBindingGinHandlerBuilder) bindVersioned(ctx *gin.Context, version string, ty reflect.Type, scope bindScope) (form reflect.Value, ok bool, err error) {
+	for _, vt := range builder.apiVersion.types {
+		if vt.version != version || vt.to != ty {
+			continue
+		}
+
+		old, err := builder.bindParam(ctx, vt.from, scope)
+		if err != nil {
+			return reflect.Value{}, true, err
+		}
+		out := vt.convert.Call([]reflect.Value{old})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return reflect.Value{}, true, builder.bindingError(ty, err)
+		}
+		if out[0].Kind() == reflect.Pointer && out[0].IsNil() {
+			return reflect.Value{}, true, builder.bindingError(ty, fmt.Errorf("version %s request converted to nil %s", version, ty))
+		}
+		return adoptRequest(out[0], ty), true, nil
+	}
+	return reflect.Value{}, false, nil
+}
+
+// versionedBindType returns the type the request of type ty is bound into for
+// version: ty itself, or a struct type with the tags of the version
+func versionedBindType(ty reflect.Type, version string) reflect.Type {
+	structTy := ty
+	if structTy.Kind() == reflect.Pointer {
+		structTy = structTy.Elem()
+	}
+	types, _ := versionedTypesOf(structTy)
+	if types == nil {
+		return ty
+	}
+
+	versioned, ok := types[version]
+	if !ok {
+		return ty
+	}
+	if ty.Kind() == reflect.Pointer {
+		return reflect.PointerTo(versioned)
+	}
+	return versioned
+}
+
+// adoptRequest copies the request v into a new or pooled request of type ty,
+// converting it from a struct type with other tags if needed, so that it can
+// be released like requests bound into ty
+func adoptRequest(v reflect.Value, ty reflect.Type) reflect.Value {
+	structTy := ty
+	if structTy.Kind() == reflect.Pointer {
+		structTy = structTy.Elem()
+	}
+	val := newRequest(structTy)
+	val.Elem().Set(reflect.Indirect(v).Convert(structTy))
+	if ty.Kind() == reflect.Pointer {
+		return val
+	}
+	return val.Elem()
+}
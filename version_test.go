@@ -0,0 +1,154 @@
+package ginbinding
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type versionedUserRequest struct {
+	Name  string `json:"name" json@v1:"full_name" binding:"required"`
+	Email string `json:"email" json@v1:"-" header@v1:"X-Email"`
+	Page  int    `form:"page" form@v3:"p" default:"1"`
+}
+
+type versionedUserV0 struct {
+	First string `json:"first" binding:"required"`
+	Last  string `json:"last"`
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	handler := func(req *versionedUserRequest) (any, error) {
+		return req, nil
+	}
+	convert := func(old versionedUserV0) (*versionedUserRequest, error) {
+		if old.First == "nobody" {
+			return nil, errors.New("unknown user")
+		}
+		return &versionedUserRequest{Name: old.First + " " + old.Last, Email: "legacy"}, nil
+	}
+
+	router := gin.New()
+	router.POST("/users", builder.MustFormBindingGinHandlerFunc(handler,
+		WithAPIVersion(HeaderVersion("API-Version"), VersionType("v0", convert))))
+	router.POST("/:version/users", builder.MustFormBindingGinHandlerFunc(handler,
+		WithAPIVersion(PathVersion("version"))))
+
+	tests := []struct {
+		name           string
+		target         string
+		version        string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "default version",
+			target:         "/users?page=2",
+			body:           `{"name":"Ann","email":"ann@example.com","full_name":"x"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann","email":"ann@example.com","Page":2}`,
+		},
+		{
+			name:           "versioned tags",
+			target:         "/users",
+			version:        "v1",
+			body:           `{"full_name":"Ann","name":"x","email":"x"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann","email":"ann@example.com","Page":1}`,
+		},
+		{
+			name:           "versioned tags keep validation",
+			target:         "/users",
+			version:        "v1",
+			body:           `{"name":"Ann"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"field":"name"`,
+		},
+		{
+			name:           "path version",
+			target:         "/v3/users?p=4&page=9",
+			body:           `{"name":"Ann"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann","email":"","Page":4}`,
+		},
+		{
+			name:           "unknown version",
+			target:         "/v9/users?page=3",
+			body:           `{"name":"Ann"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann","email":"","Page":3}`,
+		},
+		{
+			name:           "version type",
+			target:         "/users",
+			version:        "v0",
+			body:           `{"first":"Ann","last":"Lee"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Ann Lee","email":"legacy","Page":0}`,
+		},
+		{
+			name:           "version type validation",
+			target:         "/users",
+			version:        "v0",
+			body:           `{"last":"Lee"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"field":"first"`,
+		},
+		{
+			name:           "version type conversion error",
+			target:         "/users",
+			version:        "v0",
+			body:           `{"first":"nobody"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `unknown user`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Email", "ann@example.com")
+			if tt.version != "" {
+				req.Header.Set("API-Version", tt.version)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestVersionTag(t *testing.T) {
+	tag := reflect.StructTag(`json:"name" json@v1:"full_name" binding:"required" header@v2:"X-Name" header@v1:"X-Full-Name"`)
+
+	assert.Equal(t, reflect.StructTag(`json:"full_name" binding:"required" header:"X-Full-Name"`), versionTag(tag, "v1"))
+	assert.Equal(t, reflect.StructTag(`json:"name" binding:"required" header:"X-Name"`), versionTag(tag, "v2"))
+	assert.Equal(t, reflect.StructTag(`json:"name" binding:"required"`), versionTag(tag, "v3"))
+}
+
+func TestWithAPIVersionBuildErrors(t *testing.T) {
+	type embedded struct {
+		*time.Location
+		Name string `json:"name" json@v1:"full_name"`
+	}
+
+	builder := NewBasicFormBindingGinHandlerBuilder(nil, nil)
+	_, err := builder.FormBindingGinHandlerFunc(func(req embedded) error {
+		return nil
+	}, WithAPIVersion(HeaderVersion("API-Version")))
+	assert.ErrorContains(t, err, "versioned tags of")
+}
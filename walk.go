@@ -0,0 +1,173 @@
+package ginbinding
+
+import (
+	"reflect"
+	"strings"
+)
+
+// taggedField is a field of a request struct type selected by taggedFields
+type taggedField struct {
+	// path is the dotted Go field path of the field, with embedded struct
+	// names omitted
+	path []string
+	// name is the key of the field in the request, e.g. the query parameter
+	// or the dotted JSON path of a body field
+	name string
+	// value is the value lookup returned for the field
+	value string
+}
+
+// taggedFields returns the fields of the struct type ty and of the structs
+// nested in it for which lookup reports true. The structs within slices and
+// arrays are only entered with elems, and share the path of their container;
+// those within maps are not entered.
+func taggedFields(ty reflect.Type, elems bool, lookup func(sf reflect.StructField) (string, bool)) []taggedField {
+	var fields []taggedField
+	var walk func(ty reflect.Type, path []string, name string, seen map[reflect.Type]bool)
+	walk = func(ty reflect.Type, path []string, name string, seen map[reflect.Type]bool) {
+		if seen[ty] {
+			return
+		}
+		seen[ty] = true
+		defer delete(seen, ty)
+
+		for i := 0; i < ty.NumField(); i++ {
+			sf := ty.Field(i)
+			// Fields without JSON encoding can still be bound from inputs
+			// such as headers at the top level
+			if !sf.IsExported() || sf.Tag.Get("json") == "-" && (len(path) > 0 || strings.HasPrefix(fieldSource(sf), SourceBody+":")) {
+				continue
+			}
+
+			fieldPath, fieldName := path, name
+			if !sf.Anonymous || jsonFieldName(sf) != sf.Name {
+				fieldPath = append(path[:len(path):len(path)], sf.Name)
+				if len(path) == 0 {
+					_, fieldName, _ = strings.Cut(fieldSource(sf), ":")
+				} else {
+					fieldName = name + "." + jsonFieldName(sf)
+				}
+			}
+
+			if value, ok := lookup(sf); ok {
+				fields = append(fields, taggedField{path: fieldPath, name: fieldName, value: value})
+			}
+
+			nested := sf.Type
+			for nested.Kind() == reflect.Pointer || elems && (nested.Kind() == reflect.Slice || nested.Kind() == reflect.Array) {
+				nested = nested.Elem()
+			}
+			if nested.Kind() == reflect.Struct && nested != timeTy {
+				walk(nested, fieldPath, fieldName, seen)
+			}
+		}
+	}
+	walk(ty, nil, "", map[reflect.Type]bool{})
+	return fields
+}
+
+// walkFields calls fn for every exported field of the struct val and of the
+// structs nested in it. Nested structs are entered through pointers, slices
+// and arrays; time.Time is treated as a leaf. path is the dotted field path
+// relative to val, with embedded struct names omitted.
+func walkFields(val reflect.Value, path string, fn func(path string, sf reflect.StructField, fieldVal reflect.Value) error) error {
+	ty := val.Type()
+
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		fieldPath := path
+		if !sf.Anonymous {
+			fieldPath = joinFieldPath(path, sf.Name)
+		}
+
+		fieldVal := val.Field(i)
+		if err := fn(fieldPath, sf, fieldVal); err != nil {
+			return err
+		}
+
+		if nestedStructType(sf.Type) == nil {
+			continue
+		}
+
+		switch fieldVal.Kind() {
+		case reflect.Pointer:
+			if !fieldVal.IsNil() {
+				if err := walkFields(fieldVal.Elem(), fieldPath, fn); err != nil {
+					return err
+				}
+			}
+		case reflect.Slice, reflect.Array:
+			for j := 0; j < fieldVal.Len(); j++ {
+				elem := reflect.Indirect(fieldVal.Index(j))
+				if elem.IsValid() {
+					if err := walkFields(elem, fieldPath, fn); err != nil {
+						return err
+					}
+				}
+			}
+		default:
+			if err := walkFields(fieldVal, fieldPath, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkTypeFields calls fn for every exported field of the struct type ty and
+// of the struct types nested in it, visiting each struct type only once
+func walkTypeFields(ty reflect.Type, fn func(sf reflect.StructField) error) error {
+	return walkTypeFieldsSeen(ty, map[reflect.Type]bool{}, fn)
+}
+
+func walkTypeFieldsSeen(ty reflect.Type, seen map[reflect.Type]bool, fn func(sf reflect.StructField) error) error {
+	if seen[ty] {
+		return nil
+	}
+	seen[ty] = true
+
+	for i := 0; i < ty.NumField(); i++ {
+		sf := ty.Field(i)
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		if err := fn(sf); err != nil {
+			return err
+		}
+
+		if nested := nestedStructType(sf.Type); nested != nil {
+			if err := walkTypeFieldsSeen(nested, seen, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// nestedStructType returns the struct type reachable through ty's pointers and
+// slices, or nil when ty does not contain a struct other than time.Time
+func nestedStructType(ty reflect.Type) reflect.Type {
+	for ty.Kind() == reflect.Pointer || ty.Kind() == reflect.Slice || ty.Kind() == reflect.Array {
+		ty = ty.Elem()
+	}
+	if ty.Kind() != reflect.Struct || ty == timeTy {
+		return nil
+	}
+	return ty
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}